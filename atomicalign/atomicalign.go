@@ -0,0 +1,266 @@
+// Package atomicalign provides an analyzer to detect 64-bit atomic operations
+// on struct fields that are not 64-bit aligned on 32-bit platforms.
+package atomicalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for 64-bit atomic operations on misaligned struct fields
+
+The 64-bit functions of sync/atomic, such as atomic.AddInt64 and
+atomic.LoadUint64, panic on 386 and 32-bit ARM and MIPS when their operand is
+not 64-bit aligned. Only the first word of an allocated struct, array, or
+slice is guaranteed to be aligned there. This analyzer computes the offset of
+the field passed as &x.f with the 32-bit layout and reports fields whose
+offset is not a multiple of 8. Move such fields to the start of the struct, or
+use the atomic.Int64 and atomic.Uint64 types of Go 1.19, which are always
+aligned. The analyzer reports diagnostics only.`
+
+// Analyzer is the main analyzer for misaligned 64-bit atomic operations.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "atomicalign",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/atomicalign",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// atomicFuncs maps the 64-bit functions of sync/atomic to the type that
+// replaces their operand.
+//
+//nolint:gochecknoglobals // static table of checked functions
+var atomicFuncs = map[string]string{
+	"AddInt64":             "atomic.Int64",
+	"AndInt64":             "atomic.Int64",
+	"CompareAndSwapInt64":  "atomic.Int64",
+	"LoadInt64":            "atomic.Int64",
+	"OrInt64":              "atomic.Int64",
+	"StoreInt64":           "atomic.Int64",
+	"SwapInt64":            "atomic.Int64",
+	"AddUint64":            "atomic.Uint64",
+	"AndUint64":            "atomic.Uint64",
+	"CompareAndSwapUint64": "atomic.Uint64",
+	"LoadUint64":           "atomic.Uint64",
+	"OrUint64":             "atomic.Uint64",
+	"StoreUint64":          "atomic.Uint64",
+	"SwapUint64":           "atomic.Uint64",
+}
+
+// sizes is the layout of the 32-bit platforms, where 64-bit integers are
+// only 4-byte aligned.
+//
+//nolint:gochecknoglobals // shared read-only layout
+var sizes = types.SizesFor("gc", "386")
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) == 0 {
+		return nil
+	}
+
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" {
+		return nil
+	}
+
+	replacement, ok := atomicFuncs[fn.Name()]
+	if !ok {
+		return nil
+	}
+
+	addr, ok := ast.Unparen(call.Args[0]).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND {
+		return nil
+	}
+
+	field, ok := ast.Unparen(addr.X).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	offset, ok := fieldOffset(pass.TypesInfo, field)
+	if !ok || offset%8 == 0 {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "atomicalign") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("atomic.%s on field %s at offset %d, which is not 64-bit aligned on 32-bit platforms "+
+			"and panics there; move the field to the start of the struct or use %s",
+			fn.Name(), field.Sel.Name, offset, replacement),
+	}
+}
+
+// fieldOffset returns the offset of the field selected by sel from the start
+// of its allocation with the 32-bit layout. The selection is followed back
+// through fields of struct values; a pointer indirection starts a new
+// allocation, whose first word is aligned. It reports false if sel does not
+// select a field.
+func fieldOffset(info *types.Info, sel *ast.SelectorExpr) (int64, bool) {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return 0, false
+	}
+
+	var base int64
+
+	if !selection.Indirect() {
+		if inner, ok := ast.Unparen(sel.X).(*ast.SelectorExpr); ok {
+			if offset, ok := fieldOffset(info, inner); ok {
+				base = offset
+			}
+		}
+	}
+
+	offset, ok := pathOffset(selection.Recv(), selection.Index())
+	if !ok {
+		return 0, false
+	}
+
+	return base + offset, true
+}
+
+// pathOffset returns the offset of the field reached from typ through the
+// field indices of path, restarting at 0 after each pointer indirection.
+func pathOffset(typ types.Type, path []int) (int64, bool) {
+	var offset int64
+
+	for _, index := range path {
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+			offset = 0
+		}
+
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok || index >= st.NumFields() {
+			return 0, false
+		}
+
+		fields := make([]*types.Var, st.NumFields())
+		for i := range fields {
+			fields[i] = st.Field(i)
+		}
+
+		offset += sizes.Offsetsof(fields)[index]
+		typ = st.Field(index).Type()
+	}
+
+	return offset, true
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,16 @@
+package atomicalign_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/atomicalign"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, atomicalign.Analyzer, "a")
+}
@@ -0,0 +1,12 @@
+// Command atomicaligngodernize runs the atomicalign analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/atomicalign"
+)
+
+func main() {
+	singlechecker.Main(atomicalign.Analyzer)
+}
@@ -0,0 +1,95 @@
+package a
+
+import (
+	"sync/atomic"
+	sa "sync/atomic"
+)
+
+type misaligned struct {
+	flag  bool
+	count int64
+	total uint64
+}
+
+type aligned struct {
+	count int64
+	total uint64
+	flag  bool
+}
+
+type padded struct {
+	flag  int32
+	_     int32
+	count int64
+}
+
+type afterInt32 struct {
+	a, b  int32
+	c     int32
+	count int64
+}
+
+type nested struct {
+	inner misaligned
+}
+
+// shifted moves the fields of misaligned by 4 bytes, aligning them.
+type shifted struct {
+	name  bool
+	inner misaligned
+}
+
+type embedsValue struct {
+	flag bool
+	aligned
+}
+
+type embedsPointer struct {
+	flag bool
+	*aligned
+}
+
+type typed struct {
+	flag  bool
+	count atomic.Int64
+}
+
+var global misaligned
+
+func misalignedFields(m *misaligned, n *nested, e *embedsValue) {
+	atomic.AddInt64(&m.count, 1)                      // want `atomic.AddInt64 on field count at offset 4, which is not 64-bit aligned on 32-bit platforms and panics there; move the field to the start of the struct or use atomic.Int64`
+	_ = atomic.LoadUint64(&m.total)                   // want `atomic.LoadUint64 on field total at offset 12, .* use atomic.Uint64`
+	sa.StoreInt64(&(global.count), 2)                 // want `atomic.StoreInt64 on field count at offset 4`
+	atomic.CompareAndSwapUint64(&n.inner.total, 0, 1) // want `atomic.CompareAndSwapUint64 on field total at offset 12`
+	atomic.AddInt64(&e.count, 1)                      // want `atomic.AddInt64 on field count at offset 4`
+
+	var a afterInt32
+	atomic.SwapInt64(&a.count, 1) // want `atomic.SwapInt64 on field count at offset 12`
+}
+
+func alignedFields(a *aligned, p *padded, e *embedsPointer, t *typed, s *shifted) {
+	atomic.AddInt64(&a.count, 1)
+	_ = atomic.LoadUint64(&a.total)
+	atomic.AddInt64(&p.count, 1)
+	atomic.AddInt64(&e.count, 1)
+	t.count.Add(1)
+
+	var count int64
+	atomic.AddInt64(&count, 1)
+
+	atomic.AddInt64(&s.inner.count, 1)
+	_ = atomic.LoadUint64(&s.inner.total)
+
+	var flag int32
+	atomic.AddInt32(&flag, 1)
+}
+
+//godernize:ignore=atomicalign
+func ignoredFunc(m *misaligned) {
+	atomic.AddInt64(&m.count, 1)
+}
+
+func ignoredLine(m *misaligned) {
+	//godernize:ignore
+	atomic.AddInt64(&m.count, 1)
+}
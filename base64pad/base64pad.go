@@ -0,0 +1,345 @@
+// Package base64pad provides an analyzer to detect "=" padding added or
+// stripped by hand around base64 encoding and decoding.
+package base64pad
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const (
+	base64Path  = "encoding/base64"
+	stringsPath = "strings"
+)
+
+// paddedEncodings maps the padded encodings of encoding/base64 to their
+// unpadded counterparts.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var paddedEncodings = map[string]string{
+	"StdEncoding": "RawStdEncoding",
+	"URLEncoding": "RawURLEncoding",
+}
+
+// Doc describes what this analyzer does.
+const Doc = `check for "=" padding handled by hand around base64
+
+This analyzer reports "=" padding added to a string before it is decoded with a
+padded encoding of encoding/base64, and padding stripped from the output of a
+padded encoding, where the unpadded encodings do the same:
+- base64.StdEncoding.DecodeString(s + "==") -> base64.RawStdEncoding.DecodeString(s)
+- s += strings.Repeat("=", n); base64.URLEncoding.DecodeString(s) -> base64.RawURLEncoding.DecodeString(s)
+- strings.TrimRight(base64.StdEncoding.EncodeToString(b), "=") -> base64.RawStdEncoding.EncodeToString(b)
+
+No fix is offered, since the input may already be padded in some cases.`
+
+// Analyzer is the main analyzer for manual base64 padding.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "base64pad",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/base64pad",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil || len(call.Args) == 0 {
+		return nil
+	}
+
+	var message string
+
+	if encoding := encodingMethod(pass.TypesInfo, call, "DecodeString"); encoding != "" {
+		if !isPadded(pass.TypesInfo, call.Args[0], enclosingBody(stack), call.Pos()) {
+			return nil
+		}
+
+		message = fmt.Sprintf(`"=" padding added before base64.%s.DecodeString can be avoided `+
+			"by decoding the unpadded input with base64.%s", encoding, paddedEncodings[encoding])
+	} else {
+		encoding := strippedEncoding(pass.TypesInfo, call)
+		if encoding == "" {
+			return nil
+		}
+
+		message = fmt.Sprintf(`"=" padding stripped from base64.%s.EncodeToString can be avoided `+
+			"by encoding with base64.%s", encoding, paddedEncodings[encoding])
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "base64pad") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: message,
+	}
+}
+
+// encodingMethod returns the name of the padded encoding if call is the
+// method name called on base64.StdEncoding or base64.URLEncoding, and ""
+// otherwise.
+func encodingMethod(info *types.Info, call *ast.CallExpr, name string) string {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return ""
+	}
+
+	method, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || method.Pkg() == nil || method.Pkg().Path() != base64Path {
+		return ""
+	}
+
+	var ident *ast.Ident
+
+	switch x := ast.Unparen(sel.X).(type) {
+	case *ast.SelectorExpr:
+		ident = x.Sel
+	case *ast.Ident:
+		ident = x
+	default:
+		return ""
+	}
+
+	encoding, ok := info.Uses[ident].(*types.Var)
+	if !ok || encoding.Pkg() == nil || encoding.Pkg().Path() != base64Path || encoding.Parent() != encoding.Pkg().Scope() {
+		return ""
+	}
+
+	if _, ok := paddedEncodings[encoding.Name()]; !ok {
+		return ""
+	}
+
+	return encoding.Name()
+}
+
+// strippedEncoding returns the name of the padded encoding if call is
+// strings.TrimRight or strings.TrimSuffix removing "=" from the result of its
+// EncodeToString method, and "" otherwise.
+func strippedEncoding(info *types.Info, call *ast.CallExpr) string {
+	if len(call.Args) != 2 || !isStringsFunc(info, call, "TrimRight", "TrimSuffix") || !isPadding(info, call.Args[1]) {
+		return ""
+	}
+
+	encode, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+
+	return encodingMethod(info, encode, "EncodeToString")
+}
+
+// isPadded checks if expr appends padding to a string, either directly or,
+// for a variable, in an assignment of body before pos.
+func isPadded(info *types.Info, expr ast.Expr, body *ast.BlockStmt, pos token.Pos) bool {
+	if isPaddedConcat(info, expr) {
+		return true
+	}
+
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok || body == nil {
+		return false
+	}
+
+	obj := info.ObjectOf(ident)
+	if obj == nil {
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if found || !ok || assign.Pos() >= pos || len(assign.Lhs) != len(assign.Rhs) {
+			return !found
+		}
+
+		for i, lhs := range assign.Lhs {
+			lhsIdent, ok := lhs.(*ast.Ident)
+			if !ok || info.ObjectOf(lhsIdent) != obj {
+				continue
+			}
+
+			switch assign.Tok {
+			case token.ADD_ASSIGN:
+				found = found || isPadding(info, assign.Rhs[i])
+			case token.ASSIGN, token.DEFINE:
+				found = found || isPaddedConcat(info, assign.Rhs[i])
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isPaddedConcat checks if expr is a string concatenation ending with
+// padding, such as s + "==".
+func isPaddedConcat(info *types.Info, expr ast.Expr) bool {
+	binary, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+
+	return ok && binary.Op == token.ADD && isPadding(info, binary.Y)
+}
+
+// isPadding checks if expr is a constant made of "=" only or
+// strings.Repeat("=", n).
+func isPadding(info *types.Info, expr ast.Expr) bool {
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		if tv.Value.Kind() != constant.String {
+			return false
+		}
+
+		value := constant.StringVal(tv.Value)
+
+		return value != "" && strings.Trim(value, "=") == ""
+	}
+
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !isStringsFunc(info, call, "Repeat") {
+		return false
+	}
+
+	tv, ok := info.Types[call.Args[0]]
+
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) == "="
+}
+
+func isStringsFunc(info *types.Info, call *ast.CallExpr, names ...string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != stringsPath {
+		return false
+	}
+
+	return slices.Contains(names, fn.Name())
+}
+
+// enclosingBody returns the body of the innermost function in stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+
+	return nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,16 @@
+package base64pad_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/base64pad"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, base64pad.Analyzer, "a")
+}
@@ -0,0 +1,12 @@
+// Command base64padgodernize runs the base64pad analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/base64pad"
+)
+
+func main() {
+	singlechecker.Main(base64pad.Analyzer)
+}
@@ -0,0 +1,67 @@
+package a
+
+import (
+	"encoding/base64"
+	b64 "encoding/base64"
+	"strings"
+
+	fake "fake/base64"
+)
+
+func decode(s string, n int) {
+	base64.StdEncoding.DecodeString(s + "==") // want `"=" padding added before base64.StdEncoding.DecodeString can be avoided by decoding the unpadded input with base64.RawStdEncoding`
+
+	base64.URLEncoding.DecodeString(s + strings.Repeat("=", n)) // want `"=" padding added before base64.URLEncoding.DecodeString can be avoided by decoding the unpadded input with base64.RawURLEncoding`
+
+	b64.StdEncoding.DecodeString((s + "=")) // want `"=" padding added before base64.StdEncoding.DecodeString`
+}
+
+func decodeVariable(s string) ([]byte, error) {
+	if m := len(s) % 4; m != 0 {
+		s += strings.Repeat("=", 4-m)
+	}
+
+	return base64.URLEncoding.DecodeString(s) // want `"=" padding added before base64.URLEncoding.DecodeString`
+}
+
+func decodeAssigned(s string) ([]byte, error) {
+	padded := s + "="
+
+	return base64.StdEncoding.DecodeString(padded) // want `"=" padding added before base64.StdEncoding.DecodeString`
+}
+
+func encode(b []byte) {
+	_ = strings.TrimRight(base64.StdEncoding.EncodeToString(b), "=") // want `"=" padding stripped from base64.StdEncoding.EncodeToString can be avoided by encoding with base64.RawStdEncoding`
+
+	_ = strings.TrimSuffix(base64.URLEncoding.EncodeToString(b), "==") // want `"=" padding stripped from base64.URLEncoding.EncodeToString can be avoided by encoding with base64.RawURLEncoding`
+}
+
+func untouched(s string, b []byte, sep string) {
+	// Already unpadded encodings.
+	base64.RawStdEncoding.DecodeString(s)
+	base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	_ = base64.RawStdEncoding.EncodeToString(b)
+
+	// Not padding.
+	base64.StdEncoding.DecodeString(s + sep)
+	base64.StdEncoding.DecodeString(s + "=a")
+	base64.StdEncoding.DecodeString("=" + s)
+	_ = strings.TrimRight(base64.StdEncoding.EncodeToString(b), "\n")
+
+	// Padding added after the decode.
+	base64.StdEncoding.DecodeString(s)
+	s += "="
+
+	// A custom encoding.
+	enc := base64.StdEncoding.WithPadding('*')
+	enc.DecodeString(s + "=")
+
+	// Not the encoding/base64 package.
+	fake.StdEncoding.DecodeString(s + "=")
+	_ = strings.TrimRight(fake.StdEncoding.EncodeToString(b), "=")
+}
+
+func ignored(s string) {
+	//godernize:ignore=base64pad
+	base64.StdEncoding.DecodeString(s + "=")
+}
@@ -0,0 +1,10 @@
+// Package base64 mimics the API of the standard encoding/base64 package.
+package base64
+
+type Encoding struct{}
+
+var StdEncoding = &Encoding{}
+
+func (e *Encoding) DecodeString(s string) ([]byte, error) { return nil, nil }
+
+func (e *Encoding) EncodeToString(src []byte) string { return "" }
@@ -0,0 +1,315 @@
+// Package bytesbuffer provides an analyzer to detect bytes.NewBuffer(nil) and
+// bytes.NewBufferString("") calls that can be replaced with a zero
+// bytes.Buffer.
+package bytesbuffer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const bytesPath = "bytes"
+
+// Doc describes what this analyzer does.
+const Doc = `check for bytes.NewBuffer(nil) and bytes.NewBufferString("")
+
+This analyzer reports buffers created from no initial contents, which suggest
+that the contents matter, and suggests the zero value of bytes.Buffer:
+- bytes.NewBuffer(nil) -> &bytes.Buffer{}
+- bytes.NewBufferString("") -> &bytes.Buffer{}
+- *bytes.NewBuffer(nil) -> bytes.Buffer{}`
+
+// Analyzer is the main analyzer for empty bytes.Buffer constructors.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "bytesbuffer",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/bytesbuffer",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	fn := emptyBufferFunc(pass.TypesInfo, call)
+	if fn == "" || shouldIgnore(pass.Fset, file, call, "bytesbuffer") {
+		return nil
+	}
+
+	bufferType := bufferTypeName(pass, call)
+	if bufferType == "" {
+		return &analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: emptyBufferMessage(fn),
+		}
+	}
+
+	// A dereferenced call becomes the value itself; otherwise the pointer
+	// returned by the call is kept.
+	var replaced ast.Node = call
+
+	replacement := "&" + bufferType + "{}"
+	if star := dereference(stack); star != nil {
+		replaced = star
+		replacement = bufferType + "{}"
+	}
+
+	if needsParens(stack, replaced) {
+		replacement = "(" + replacement + ")"
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: emptyBufferMessage(fn),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     replaced.Pos(),
+				End:     replaced.End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	}
+}
+
+func emptyBufferMessage(fn string) string {
+	if fn == "NewBufferString" {
+		return `bytes.NewBufferString("") can be replaced with &bytes.Buffer{}, since the zero Buffer is empty and ready to use`
+	}
+
+	return "bytes.NewBuffer(nil) can be replaced with &bytes.Buffer{}, since the zero Buffer is empty and ready to use"
+}
+
+// emptyBufferFunc returns the name of the bytes function if call is
+// bytes.NewBuffer(nil) or bytes.NewBufferString(""), and "" otherwise.
+func emptyBufferFunc(info *types.Info, call *ast.CallExpr) string {
+	if len(call.Args) != 1 || call.Ellipsis.IsValid() {
+		return ""
+	}
+
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != bytesPath {
+		return ""
+	}
+
+	tv, ok := info.Types[call.Args[0]]
+	if !ok {
+		return ""
+	}
+
+	switch fn.Name() {
+	case "NewBuffer":
+		if tv.IsNil() {
+			return fn.Name()
+		}
+	case "NewBufferString":
+		if tv.Value != nil && tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) == "" {
+			return fn.Name()
+		}
+	}
+
+	return ""
+}
+
+// bufferTypeName returns how bytes.Buffer is referenced at call, matching
+// the reference to the called function, or "" if a dot-imported Buffer is
+// shadowed there.
+func bufferTypeName(pass *analysis.Pass, call *ast.CallExpr) string {
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			return ident.Name + ".Buffer"
+		}
+
+		return ""
+	}
+
+	scope := pass.Pkg.Scope().Innermost(call.Pos())
+	if scope == nil {
+		return ""
+	}
+
+	_, obj := scope.LookupParent("Buffer", call.Pos())
+	if typeName, ok := obj.(*types.TypeName); !ok || typeName.Pkg() == nil || typeName.Pkg().Path() != bytesPath {
+		return ""
+	}
+
+	return "Buffer"
+}
+
+// dereference returns the star expression dereferencing the call, which is
+// the last element of the stack, possibly through parentheses.
+func dereference(stack []ast.Node) *ast.StarExpr {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.ParenExpr:
+			continue
+		case *ast.StarExpr:
+			return node
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// needsParens reports whether the composite literal replacing node, an
+// element of stack, must be parenthesized: as the operand of a selector, as
+// in (&bytes.Buffer{}).String(), where the selector would otherwise apply to
+// the literal before the & operator, and in the header of an if, for, or
+// switch statement, where the brace of the literal would start the block
+// unless parentheses, brackets, or braces enclose it.
+func needsParens(stack []ast.Node, node ast.Node) bool {
+	index := slices.Index(stack, node)
+	if index < 1 {
+		return false
+	}
+
+	if sel, ok := stack[index-1].(*ast.SelectorExpr); ok && sel.X == node {
+		return true
+	}
+
+	for i := index - 1; i >= 0; i-- {
+		child := stack[i+1]
+
+		switch parent := stack[i].(type) {
+		case *ast.ParenExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.CompositeLit, *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if child != parent.Fun {
+				return false
+			}
+		case *ast.IfStmt:
+			return child != parent.Body && child != parent.Else
+		case *ast.ForStmt:
+			return child != parent.Body
+		case *ast.RangeStmt:
+			return child != parent.Body
+		case *ast.SwitchStmt:
+			return child != parent.Body
+		case *ast.TypeSwitchStmt:
+			return child != parent.Body
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause, ast.Decl:
+			return false
+		}
+	}
+
+	return false
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
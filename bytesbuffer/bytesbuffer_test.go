@@ -0,0 +1,23 @@
+package bytesbuffer_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/bytesbuffer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, bytesbuffer.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, bytesbuffer.Analyzer, "autofix", "dot")
+}
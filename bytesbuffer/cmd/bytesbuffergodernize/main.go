@@ -0,0 +1,12 @@
+// Command bytesbuffergodernize runs the bytesbuffer analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/bytesbuffer"
+)
+
+func main() {
+	singlechecker.Main(bytesbuffer.Analyzer)
+}
@@ -0,0 +1,35 @@
+package a
+
+import (
+	"bytes"
+	"io"
+)
+
+const empty = ""
+
+func buffers(data []byte, s string) {
+	_ = bytes.NewBuffer(nil)         // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}, since the zero Buffer is empty and ready to use`
+	_ = bytes.NewBufferString("")    // want `bytes.NewBufferString\(""\) can be replaced with &bytes.Buffer\{\}, since the zero Buffer is empty and ready to use`
+	_ = bytes.NewBufferString(empty) // want `bytes.NewBufferString\(""\) can be replaced with &bytes.Buffer\{\}`
+
+	var w io.Writer = bytes.NewBuffer(nil) // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+	_ = w
+
+	buf := *bytes.NewBuffer(nil) // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+	_ = buf.Len()
+}
+
+// Buffers with initial contents are left alone.
+func untouched(data []byte, s string) {
+	_ = bytes.NewBuffer(data)
+	_ = bytes.NewBuffer([]byte{})
+	_ = bytes.NewBuffer(make([]byte, 0, 64))
+	_ = bytes.NewBufferString(s)
+	_ = bytes.NewBufferString("x")
+	_ = &bytes.Buffer{}
+}
+
+func ignored() {
+	//godernize:ignore=bytesbuffer
+	_ = bytes.NewBuffer(nil)
+}
@@ -0,0 +1,38 @@
+package autofix
+
+import (
+	b "bytes"
+	"fmt"
+)
+
+func pointer() *b.Buffer {
+	return b.NewBuffer(nil) // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+}
+
+func str() {
+	buf := b.NewBufferString("") // want `bytes.NewBufferString\(""\) can be replaced with &bytes.Buffer\{\}`
+	fmt.Fprint(buf, "x")
+}
+
+func value() b.Buffer {
+	return *(b.NewBuffer(nil)) // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+}
+
+func selector() string {
+	return b.NewBuffer(nil).String() // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+}
+
+func header() {
+	if buf := b.NewBuffer(nil); buf.Len() == 0 { // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+		fmt.Fprint(buf, "x")
+	}
+
+	for buf := *b.NewBufferString(""); buf.Len() == 0; { // want `bytes.NewBufferString\(""\) can be replaced with &bytes.Buffer\{\}`
+		buf.WriteString("x")
+	}
+
+	switch fmt.Sprint(b.NewBuffer(nil)) { // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+	case "":
+		fmt.Println("empty")
+	}
+}
@@ -0,0 +1,15 @@
+package dot
+
+import . "bytes"
+
+func dot() *Buffer {
+	return NewBuffer(nil) // want `bytes.NewBuffer\(nil\) can be replaced with &bytes.Buffer\{\}`
+}
+
+// Buffer is shadowed, so the call is reported without a fix.
+func shadowed() {
+	type Buffer struct{}
+
+	_ = NewBufferString("") // want `bytes.NewBufferString\(""\) can be replaced with &bytes.Buffer\{\}`
+	_ = Buffer{}
+}
@@ -0,0 +1,383 @@
+// Package chmodrace provides an analyzer to detect os.Chmod and os.Chown calls
+// racing with an earlier look at the same path.
+package chmodrace
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for os.Chmod and os.Chown on a path that was just inspected
+
+This analyzer reports os.Chmod and os.Chown calls on a path that was checked
+with os.Stat or os.Lstat, or read with os.Readlink, earlier in the same
+function. Both calls follow symbolic links, so the path may be replaced by a
+link to another file between the check and the change. Open the file and use
+(*os.File).Chmod or (*os.File).Chown, or use os.Lchown, instead. The analyzer
+reports diagnostics only.`
+
+// Analyzer is the main analyzer for racy os.Chmod and os.Chown calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "chmodrace",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/chmodrace",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// changeFuncs maps the os functions changing file metadata through symbolic
+// links to the safer alternative.
+//
+//nolint:gochecknoglobals // static table of checked functions
+var changeFuncs = map[string]string{
+	"Chmod": "open the file and use (*os.File).Chmod instead",
+	"Chown": "use os.Lchown, or open the file and use (*os.File).Chown, instead",
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil || len(call.Args) == 0 {
+		return nil
+	}
+
+	name, alternative := findChangeFunc(pass.TypesInfo, call)
+	if name == "" {
+		return nil
+	}
+
+	source := findPathSource(pass.TypesInfo, call.Args[0], stack)
+	if source == "" {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "chmodrace") {
+		return nil
+	}
+
+	origin := "checked by " + source
+	if source == "os.Readlink" {
+		origin = "read with os.Readlink"
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("os.%s on a path %s follows symbolic links, so the path may be replaced in between; %s",
+			name, origin, alternative),
+	}
+}
+
+func findChangeFunc(info *types.Info, call *ast.CallExpr) (string, string) {
+	for name, alternative := range changeFuncs {
+		if isPkgFunc(info, call, "os", name) {
+			return name, alternative
+		}
+	}
+
+	return "", ""
+}
+
+// findPathSource looks for the call that produced or inspected path before
+// the call on top of stack, in the statements preceding it in each enclosing
+// block and in the init and condition of enclosing if and switch statements,
+// up to the enclosing function. It returns the name of that call, or "" if
+// there is none or path is reassigned in between.
+func findPathSource(info *types.Info, path ast.Expr, stack []ast.Node) string {
+	if !isTrackable(path) {
+		return ""
+	}
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return ""
+		case *ast.IfStmt:
+			if child == node.Init || child == node.Cond {
+				continue
+			}
+
+			for _, part := range []ast.Node{node.Cond, node.Init} {
+				if source := pathSource(info, part, path); source != "" {
+					return source
+				}
+			}
+		case *ast.SwitchStmt:
+			if child != node.Body {
+				continue
+			}
+
+			for _, part := range []ast.Node{node.Tag, node.Init} {
+				if source := pathSource(info, part, path); source != "" {
+					return source
+				}
+			}
+		case *ast.BlockStmt:
+			if source, done := precedingSource(info, node.List, child, path); done {
+				return source
+			}
+		case *ast.CaseClause:
+			if source, done := precedingSource(info, node.Body, child, path); done {
+				return source
+			}
+		case *ast.CommClause:
+			if source, done := precedingSource(info, node.Body, child, path); done {
+				return source
+			}
+		}
+	}
+
+	return ""
+}
+
+// precedingSource scans the statements before child in list, nearest first.
+// It reports true when the search is over, either because the source was
+// found or because path is assigned a new value.
+func precedingSource(info *types.Info, list []ast.Stmt, child ast.Node, path ast.Expr) (string, bool) {
+	index := -1
+
+	for i, stmt := range list {
+		if stmt == child {
+			index = i
+
+			break
+		}
+	}
+
+	for i := index - 1; i >= 0; i-- {
+		if source := pathSource(info, list[i], path); source != "" {
+			return source, true
+		}
+
+		if assigns(info, list[i], path) {
+			return "", true
+		}
+	}
+
+	return "", false
+}
+
+// pathSource returns "os.Stat" or "os.Lstat" if node calls it on path, or
+// "os.Readlink" if node assigns the result of os.Readlink to path. Function
+// literals are not searched.
+func pathSource(info *types.Info, node ast.Node, path ast.Expr) string {
+	if node == nil {
+		return ""
+	}
+
+	var source string
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if source != "" {
+			return false
+		}
+
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			for _, name := range []string{"Stat", "Lstat"} {
+				if isPkgFunc(info, n, "os", name) && len(n.Args) == 1 && sameExpr(info, n.Args[0], path) {
+					source = "os." + name
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 && sameExpr(info, n.Lhs[0], path) {
+				if call, ok := ast.Unparen(n.Rhs[0]).(*ast.CallExpr); ok && isPkgFunc(info, call, "os", "Readlink") {
+					source = "os.Readlink"
+				}
+			}
+		}
+
+		return true
+	})
+
+	return source
+}
+
+// assigns checks if node assigns to path or to the variable it is a field of.
+func assigns(info *types.Info, node ast.Node, path ast.Expr) bool {
+	root := path
+	for {
+		sel, ok := root.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		root = sel.X
+	}
+
+	found := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || found {
+			return !found
+		}
+
+		for _, lhs := range assign.Lhs {
+			if sameExpr(info, lhs, path) || sameExpr(info, lhs, root) {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isTrackable checks if path is a string literal or a variable, possibly
+// through field selections, whose identity can be compared across calls.
+func isTrackable(path ast.Expr) bool {
+	switch expr := ast.Unparen(path).(type) {
+	case *ast.BasicLit:
+		return expr.Kind == token.STRING
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return isTrackable(expr.X)
+	}
+
+	return false
+}
+
+// sameExpr checks if two side-effect free expressions denote the same
+// variable or string literal.
+func sameExpr(info *types.Info, a, b ast.Expr) bool {
+	switch exprA := ast.Unparen(a).(type) {
+	case *ast.BasicLit:
+		exprB, ok := ast.Unparen(b).(*ast.BasicLit)
+
+		return ok && exprA.Kind == token.STRING && exprB.Kind == token.STRING && exprA.Value == exprB.Value
+	case *ast.Ident:
+		exprB, ok := ast.Unparen(b).(*ast.Ident)
+
+		return ok && info.ObjectOf(exprA) != nil && info.ObjectOf(exprA) == info.ObjectOf(exprB)
+	case *ast.SelectorExpr:
+		exprB, ok := ast.Unparen(b).(*ast.SelectorExpr)
+
+		return ok && exprA.Sel.Name == exprB.Sel.Name && sameExpr(info, exprA.X, exprB.X)
+	}
+
+	return false
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath && fn.Name() == name
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,16 @@
+package chmodrace_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/chmodrace"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, chmodrace.Analyzer, "a")
+}
@@ -0,0 +1,12 @@
+// Command chmodracegodernize runs the chmodrace analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/chmodrace"
+)
+
+func main() {
+	singlechecker.Main(chmodrace.Analyzer)
+}
@@ -0,0 +1,56 @@
+package a
+
+import (
+	"os"
+)
+
+func afterStat(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		return os.Chmod(path, 0o600) // want `os.Chmod on a path checked by os.Stat follows symbolic links, so the path may be replaced in between; open the file and use \(\*os.File\).Chmod instead`
+	}
+
+	return nil
+}
+
+func inIfInit(path string, uid, gid int) error {
+	if _, err := os.Lstat(path); err == nil {
+		return os.Chown(path, uid, gid) // want `os.Chown on a path checked by os.Lstat follows symbolic links, so the path may be replaced in between; use os.Lchown, or open the file and use \(\*os.File\).Chown, instead`
+	}
+
+	return nil
+}
+
+func afterReadlink(link string) error {
+	target, err := os.Readlink(link)
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(target, 0o644) // want `os.Chmod on a path read with os.Readlink follows symbolic links`
+}
+
+func literalPath() error {
+	if _, err := os.Stat("/tmp/config"); err != nil {
+		return err
+	}
+
+	return os.Chmod("/tmp/config", 0o600) // want `os.Chmod on a path checked by os.Stat`
+}
+
+type config struct {
+	path string
+}
+
+func fieldPath(c config) error {
+	switch _, err := os.Stat(c.path); {
+	case err == nil:
+		return os.Chmod(c.path, 0o600) // want `os.Chmod on a path checked by os.Stat`
+	default:
+		return err
+	}
+}
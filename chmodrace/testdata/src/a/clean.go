@@ -0,0 +1,80 @@
+package a
+
+import (
+	"os"
+)
+
+func withoutStat(path string) error {
+	return os.Chmod(path, 0o600)
+}
+
+func otherPath(path, other string) error {
+	if _, err := os.Stat(other); err != nil {
+		return err
+	}
+
+	return os.Chmod(path, 0o600)
+}
+
+func reassigned(path, other string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	path = other
+
+	return os.Chmod(path, 0o600)
+}
+
+func fieldReassigned(c config, other config) error {
+	if _, err := os.Stat(c.path); err != nil {
+		return err
+	}
+
+	c = other
+
+	return os.Chmod(c.path, 0o600)
+}
+
+func closure(path string) func() error {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+
+	return func() error {
+		return os.Chmod(path, 0o600)
+	}
+}
+
+func viaFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Stat(); err != nil {
+		return err
+	}
+
+	return f.Chmod(0o600)
+}
+
+func afterChmod(path string) error {
+	if err := os.Chmod(path, 0o600); err != nil {
+		return err
+	}
+
+	_, err := os.Stat(path)
+
+	return err
+}
+
+//godernize:ignore=chmodrace
+func ignored(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	return os.Chmod(path, 0o600)
+}
@@ -0,0 +1,350 @@
+// Package clearbuiltin provides an analyzer to detect loops that empty a map
+// or zero a slice, which the clear builtin does.
+package clearbuiltin
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for loops that can use the clear builtin
+
+This analyzer reports loops that only reset a map or a slice and suggests the
+Go 1.21 clear builtin:
+- for k := range m { delete(m, k) } -> clear(m)
+- for i := range s { s[i] = 0 } -> clear(s)
+
+Map loops are fixed automatically; clear also removes NaN keys, which the
+loop cannot delete. Slice loops assigning the zero value of the element type
+are reported without a fix, so that the intent of the loop can be checked
+first. Files built for Go versions before 1.21, and scopes where clear is
+shadowed, are skipped.`
+
+// Analyzer is the main analyzer for loops resetting maps and slices.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "clearbuiltin",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/clearbuiltin",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		stmt, ok := n.(*ast.RangeStmt)
+		if !ok || stmt == nil {
+			return
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if diagnostic := diagnoseRangeStmt(pass, file, stmt); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseRangeStmt(pass *analysis.Pass, file *ast.File, stmt *ast.RangeStmt) *analysis.Diagnostic {
+	if file == nil || !supportsClear(pass, file) || !isBlank(stmt.Value) || len(stmt.Body.List) != 1 {
+		return nil
+	}
+
+	// A key variable declared outside the loop keeps the last key, and may
+	// be left unused without the loop.
+	key, ok := stmt.Key.(*ast.Ident)
+	if !ok || key.Name == "_" || stmt.Tok != token.DEFINE || !isTrackable(stmt.X) {
+		return nil
+	}
+
+	keyObj := pass.TypesInfo.ObjectOf(key)
+
+	typ := pass.TypesInfo.TypeOf(stmt.X)
+	if typ == nil {
+		return nil
+	}
+
+	var isMap bool
+
+	switch typ := typ.Underlying().(type) {
+	case *types.Map:
+		if !isDeleteOf(pass.TypesInfo, stmt.Body.List[0], stmt.X, keyObj) {
+			return nil
+		}
+
+		isMap = true
+	case *types.Slice:
+		if !isZeroingOf(pass.TypesInfo, stmt.Body.List[0], stmt.X, keyObj, typ.Elem()) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if isShadowed(pass, stmt) || shouldIgnore(pass.Fset, file, stmt, "clearbuiltin") {
+		return nil
+	}
+
+	replacement := "clear(" + types.ExprString(stmt.X) + ")"
+
+	if !isMap {
+		return &analysis.Diagnostic{
+			Pos:     stmt.Pos(),
+			End:     stmt.End(),
+			Message: "loop setting every element of the slice to the zero value can be replaced with " + replacement,
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     stmt.Pos(),
+		End:     stmt.End(),
+		Message: "loop deleting every key of the map can be replaced with " + replacement,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     stmt.Pos(),
+				End:     stmt.End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	}
+}
+
+// supportsClear reports whether the file is compiled with a Go version that
+// has the clear builtin. Files without version information are assumed to
+// be recent enough.
+func supportsClear(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.21") >= 0
+}
+
+// isShadowed checks if clear refers to something other than the builtin at
+// stmt.
+func isShadowed(pass *analysis.Pass, stmt *ast.RangeStmt) bool {
+	scope := pass.Pkg.Scope().Innermost(stmt.Pos())
+	if scope == nil {
+		return true
+	}
+
+	_, obj := scope.LookupParent("clear", stmt.Pos())
+	_, isBuiltin := obj.(*types.Builtin)
+
+	return !isBuiltin
+}
+
+// isDeleteOf checks if stmt is delete(m, key) with m the same map as x.
+func isDeleteOf(info *types.Info, stmt ast.Stmt, x ast.Expr, key types.Object) bool {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !isBuiltin(info, call.Fun, "delete") {
+		return false
+	}
+
+	return sameExpr(info, call.Args[0], x) && refersTo(info, call.Args[1], key)
+}
+
+// isZeroingOf checks if stmt is s[index] = v with s the same slice as x and
+// v the zero value of elem.
+func isZeroingOf(info *types.Info, stmt ast.Stmt, x ast.Expr, index types.Object, elem types.Type) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false
+	}
+
+	lhs, ok := ast.Unparen(assign.Lhs[0]).(*ast.IndexExpr)
+	if !ok || !sameExpr(info, lhs.X, x) || !refersTo(info, lhs.Index, index) {
+		return false
+	}
+
+	return isZero(info, assign.Rhs[0], elem)
+}
+
+// isZero checks if expr is the zero value of typ: nil, an empty composite
+// literal, or a zero constant. Constants converted to an interface type are
+// not zero values of it.
+func isZero(info *types.Info, expr ast.Expr, typ types.Type) bool {
+	tv, ok := info.Types[expr]
+	if !ok {
+		return false
+	}
+
+	if tv.IsNil() {
+		return true
+	}
+
+	if lit, ok := ast.Unparen(expr).(*ast.CompositeLit); ok {
+		return len(lit.Elts) == 0 && types.Identical(tv.Type, typ)
+	}
+
+	if tv.Value == nil || !types.Identical(types.Default(tv.Type), typ) {
+		return false
+	}
+
+	switch tv.Value.Kind() {
+	case constant.Bool:
+		return !constant.BoolVal(tv.Value)
+	case constant.String:
+		return constant.StringVal(tv.Value) == ""
+	case constant.Int, constant.Float, constant.Complex:
+		return constant.Sign(tv.Value) == 0
+	}
+
+	return false
+}
+
+func isBuiltin(info *types.Info, fun ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+
+	return ok && builtin.Name() == name
+}
+
+func isBlank(expr ast.Expr) bool {
+	if expr == nil {
+		return true
+	}
+
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// isTrackable checks if expr is a variable, possibly through field
+// selections, which can be evaluated again without side effects.
+func isTrackable(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return isTrackable(e.X)
+	}
+
+	return false
+}
+
+// sameExpr checks if two side-effect free expressions denote the same
+// variable.
+func sameExpr(info *types.Info, a, b ast.Expr) bool {
+	switch exprA := ast.Unparen(a).(type) {
+	case *ast.Ident:
+		exprB, ok := ast.Unparen(b).(*ast.Ident)
+
+		return ok && info.ObjectOf(exprA) != nil && info.ObjectOf(exprA) == info.ObjectOf(exprB)
+	case *ast.SelectorExpr:
+		exprB, ok := ast.Unparen(b).(*ast.SelectorExpr)
+
+		return ok && exprA.Sel.Name == exprB.Sel.Name && sameExpr(info, exprA.X, exprB.X)
+	}
+
+	return false
+}
+
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && obj != nil && info.Uses[ident] == obj
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
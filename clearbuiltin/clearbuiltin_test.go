@@ -0,0 +1,33 @@
+package clearbuiltin_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/clearbuiltin"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, clearbuiltin.Analyzer, "a", "shadowed", "localshadow")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, clearbuiltin.Analyzer, "autofix")
+}
+
+// TestGoVersion checks that modules before Go 1.21, which lack the clear
+// builtin, are skipped.
+func TestGoVersion(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "src", "old"), clearbuiltin.Analyzer, "./...")
+}
@@ -0,0 +1,12 @@
+// Command clearbuiltingodernize runs the clearbuiltin analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/clearbuiltin"
+)
+
+func main() {
+	singlechecker.Main(clearbuiltin.Analyzer)
+}
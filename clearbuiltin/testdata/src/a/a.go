@@ -0,0 +1,101 @@
+package a
+
+type point struct{ x, y int }
+
+type cache struct {
+	entries map[string]int
+	ids     []int
+}
+
+func maps(m map[string]int, c *cache) {
+	for k := range m { // want `loop deleting every key of the map can be replaced with clear\(m\)`
+		delete(m, k)
+	}
+
+	for key := range c.entries { // want `can be replaced with clear\(c.entries\)`
+		delete(c.entries, key)
+	}
+}
+
+func slices(s []int, names []string, points []point, ptrs []*point, anys []any, c *cache) {
+	for i := range s { // want `loop setting every element of the slice to the zero value can be replaced with clear\(s\)`
+		s[i] = 0
+	}
+
+	for i := range names { // want `can be replaced with clear\(names\)`
+		names[i] = ""
+	}
+
+	for i := range points { // want `can be replaced with clear\(points\)`
+		points[i] = point{}
+	}
+
+	for i := range ptrs { // want `can be replaced with clear\(ptrs\)`
+		ptrs[i] = nil
+	}
+
+	for i := range anys { // want `can be replaced with clear\(anys\)`
+		anys[i] = nil
+	}
+
+	for i := range c.ids { // want `can be replaced with clear\(c.ids\)`
+		c.ids[i] = 0
+	}
+}
+
+func notClearing(m, other map[string]int, s []int, anys []any, arr [4]int, f func() map[string]int) {
+	for k := range m {
+		delete(other, k)
+	}
+
+	for k := range m {
+		if k == "" {
+			delete(m, k)
+		}
+	}
+
+	for k, v := range m {
+		_ = v
+		delete(m, k)
+	}
+
+	for k := range m {
+		delete(m, k)
+		println(k)
+	}
+
+	var last string
+	for last = range m {
+		delete(m, last)
+	}
+
+	for k := range f() {
+		delete(f(), k)
+	}
+
+	for i := range s {
+		s[i] = 1
+	}
+
+	for i := range s {
+		s[0] = 0
+		_ = i
+	}
+
+	// Storing an int in an interface is not its zero value.
+	for i := range anys {
+		anys[i] = 0
+	}
+
+	// clear does not accept arrays.
+	for i := range arr {
+		arr[i] = 0
+	}
+}
+
+func ignored(m map[string]int) {
+	//godernize:ignore=clearbuiltin
+	for k := range m {
+		delete(m, k)
+	}
+}
@@ -0,0 +1,21 @@
+package autofix
+
+type registry struct {
+	handlers map[string]func()
+}
+
+func (r *registry) reset(seen map[int]bool) {
+	for name := range r.handlers { // want `can be replaced with clear\(r.handlers\)`
+		delete(r.handlers, name)
+	}
+
+	for id := range seen { // want `can be replaced with clear\(seen\)`
+		delete(seen, id)
+	}
+}
+
+func zero(s []int) {
+	for i := range s { // want `can be replaced with clear\(s\)`
+		s[i] = 0
+	}
+}
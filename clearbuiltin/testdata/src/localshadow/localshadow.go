@@ -0,0 +1,20 @@
+package localshadow
+
+func reset(m map[string]int, s []int) {
+	clear := func() {}
+	clear()
+
+	for k := range m {
+		delete(m, k)
+	}
+
+	for i := range s {
+		s[i] = 0
+	}
+}
+
+func outsideShadow(m map[string]int) {
+	for k := range m { // want `can be replaced with clear\(m\)`
+		delete(m, k)
+	}
+}
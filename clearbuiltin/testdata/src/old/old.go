@@ -0,0 +1,8 @@
+package old
+
+// The clear builtin does not exist before Go 1.21.
+func reset(m map[string]int) {
+	for k := range m {
+		delete(m, k)
+	}
+}
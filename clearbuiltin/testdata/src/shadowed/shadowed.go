@@ -0,0 +1,11 @@
+package shadowed
+
+func clear(m map[string]int) {
+	for k := range m {
+		delete(m, k)
+	}
+}
+
+func reset(m map[string]int) {
+	clear(m)
+}
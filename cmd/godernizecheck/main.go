@@ -2,15 +2,287 @@
 package main
 
 import (
-	"golang.org/x/tools/go/analysis/multichecker"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
-	"github.com/jaeyeom/godernize/ctxnil"
-	"github.com/jaeyeom/godernize/oserrors"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jaeyeom/godernize"
+	"github.com/jaeyeom/godernize/internal/driver"
+	"github.com/jaeyeom/godernize/internal/fix"
+	"github.com/jaeyeom/godernize/internal/report"
+)
+
+const (
+	// listFlag makes godernizecheck print the available analyzers and exit.
+	listFlag = "godernize-list"
+	// sarifFlag makes godernizecheck write all diagnostics to a SARIF file.
+	sarifFlag = "sarif"
+	// writeFlag makes godernizecheck apply the suggested fixes to the files.
+	writeFlag = "write"
+	// defaultDisabledFlag sets the default-disabled flag of every analyzer
+	// that has one.
+	defaultDisabledFlag  = "godernize.default-disabled"
+	defaultDisabledUsage = "only report in functions and files with a //godernize:enable directive, " +
+		"for analyzers that support it"
 )
 
 func main() {
-	multichecker.Main(
-		ctxnil.Analyzer,
-		oserrors.Analyzer,
-	)
+	// Registered so that multichecker accepts the flags and lists them in -help.
+	flag.Bool(listFlag, false, "print the name, URL, and summary of each analyzer and exit")
+	flag.String(sarifFlag, "", "run all analyzers in a single pass and write the diagnostics to this SARIF file")
+	flag.Var(&analyzersFlag{name: "default-disabled", analyzers: godernize.Analyzers()},
+		defaultDisabledFlag, defaultDisabledUsage)
+	flag.Bool(writeFlag, false, "run all analyzers in a single pass and apply their non-conflicting suggested fixes in place")
+
+	if isListRequested(os.Args[1:]) {
+		if err := listAnalyzers(os.Stdout, godernize.Analyzers()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if isSARIFRequested(os.Args[1:]) {
+		if err := runSARIF(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if isWriteRequested(os.Args[1:]) {
+		if err := runWrite(os.Args[1:], os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	driver.Main(godernize.Analyzers()...)
+}
+
+// isListRequested checks if args contain -godernize-list before the first
+// package pattern. Any other flag is left to driver.Main.
+func isListRequested(args []string) bool {
+	return isBoolFlagRequested(args, listFlag)
+}
+
+// isWriteRequested checks if args contain -write before the first package
+// pattern.
+func isWriteRequested(args []string) bool {
+	return isBoolFlagRequested(args, writeFlag)
+}
+
+// isBoolFlagRequested checks if the boolean flag is set to true before the
+// first package pattern.
+func isBoolFlagRequested(args []string, flagName string) bool {
+	for _, arg := range args {
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			return false
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != flagName {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+
+		return !hasValue || (err == nil && enabled)
+	}
+
+	return false
+}
+
+// isSARIFRequested checks if args contain -sarif before the first package
+// pattern.
+func isSARIFRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			return false
+		}
+
+		if name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "="); name == sarifFlag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runSARIF parses args, analyzes the packages matching the remaining patterns,
+// and writes the diagnostics to the SARIF file. Only the analyzer flags are
+// supported besides -sarif. Diagnostics do not make it fail, since they are
+// the content of the report.
+func runSARIF(args []string) error {
+	all := godernize.Analyzers()
+
+	flags := newAnalyzerFlagSet(all)
+	sarifPath := flags.String(sarifFlag, "", "SARIF output file")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *sarifPath == "" {
+		return errors.New("-sarif requires an output file")
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		return errors.New("-sarif requires at least one package pattern")
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	results, err := godernize.Check(context.Background(), patterns...)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(*sarifPath)
+	if err != nil {
+		return err
+	}
+
+	if err := report.WriteSARIF(out, all, results, dir); err != nil {
+		out.Close()
+
+		return err
+	}
+
+	return out.Close()
+}
+
+// runWrite parses args, analyzes the packages matching the remaining
+// patterns, and applies the first suggested fix of every diagnostic to the
+// files. Fixes overlapping an applied fix are skipped and reported to stderr;
+// running again applies them to the fixed code. Only the analyzer flags are
+// supported besides -write.
+func runWrite(args []string, stderr io.Writer) error {
+	flags := newAnalyzerFlagSet(godernize.Analyzers())
+	flags.Bool(writeFlag, false, "apply suggested fixes in place")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		return errors.New("-write requires at least one package pattern")
+	}
+
+	results, err := godernize.Check(context.Background(), patterns...)
+	if err != nil {
+		return err
+	}
+
+	files, skipped, err := fix.Apply(results)
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range slices.Sorted(maps.Keys(files)) {
+		if err := writeFile(filename, files[filename]); err != nil {
+			return err
+		}
+	}
+
+	for _, result := range skipped {
+		fmt.Fprintf(stderr, "%s: %s: fix skipped, it overlaps another fix: %s\n", result.Pos, result.Analyzer, result.Message)
+	}
+
+	return nil
+}
+
+// writeFile replaces the content of an existing file, keeping its permissions.
+func writeFile(filename string, content []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, content, info.Mode().Perm())
+}
+
+// newAnalyzerFlagSet returns a flag set with -godernize.default-disabled and
+// the flags of every analyzer, prefixed with the analyzer name.
+func newAnalyzerFlagSet(analyzers []*analysis.Analyzer) *flag.FlagSet {
+	flags := flag.NewFlagSet("godernizecheck", flag.ContinueOnError)
+	flags.Var(&analyzersFlag{name: "default-disabled", analyzers: analyzers}, defaultDisabledFlag, defaultDisabledUsage)
+
+	for _, analyzer := range analyzers {
+		analyzer.Flags.VisitAll(func(f *flag.Flag) {
+			flags.Var(f.Value, analyzer.Name+"."+f.Name, f.Usage)
+		})
+	}
+
+	return flags
+}
+
+// analyzersFlag is a boolean flag that sets the flag of the same name of every
+// analyzer that has one.
+type analyzersFlag struct {
+	name      string
+	analyzers []*analysis.Analyzer
+	value     bool
+}
+
+func (f *analyzersFlag) String() string {
+	if f == nil {
+		return "false"
+	}
+
+	return strconv.FormatBool(f.value)
+}
+
+func (f *analyzersFlag) Set(value string) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+
+	for _, analyzer := range f.analyzers {
+		if analyzerFlag := analyzer.Flags.Lookup(f.name); analyzerFlag != nil {
+			if err := analyzerFlag.Value.Set(value); err != nil {
+				return fmt.Errorf("%s.%s: %w", analyzer.Name, f.name, err)
+			}
+		}
+	}
+
+	f.value = enabled
+
+	return nil
+}
+
+func (f *analyzersFlag) IsBoolFlag() bool {
+	return true
+}
+
+// listAnalyzers writes the name, URL, and summary line of each analyzer.
+func listAnalyzers(w io.Writer, analyzers []*analysis.Analyzer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	for _, analyzer := range analyzers {
+		summary, _, _ := strings.Cut(analyzer.Doc, "\n")
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", analyzer.Name, analyzer.URL, summary)
+	}
+
+	return tw.Flush()
 }
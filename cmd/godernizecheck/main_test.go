@@ -0,0 +1,433 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/jaeyeom/godernize"
+	"github.com/jaeyeom/godernize/internal/fix"
+	"github.com/jaeyeom/godernize/internal/report"
+)
+
+type expectedDiagnostic struct {
+	analyzer string
+	line     int
+	message  string
+}
+
+func (d expectedDiagnostic) String() string {
+	return fmt.Sprintf("%d: %s: %s", d.line, d.analyzer, d.message)
+}
+
+// TestAnalyzersTogether runs every analyzer of the combined command over a
+// package with issues for several analyzers, checking that each still reports
+// exactly its own diagnostics at valid positions.
+func TestAnalyzersTogether(t *testing.T) {
+	t.Parallel()
+
+	graph := analyze(t, "mixed")
+
+	expected := []expectedDiagnostic{
+		{"ctxnil", 9, "condition is always false, remove entire if statement"},
+		{"oserrors", 18, "os.IsNotExist is deprecated, use errors.Is(err, fs.ErrNotExist) instead"},
+		{"ctxnil", 31, "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"},
+		{"oserrors", 31, "os.IsNotExist is deprecated, use errors.Is(err, fs.ErrNotExist) instead"},
+		{"ctxnil", 35, "condition is always true"},
+		{"oserrors", 37, "os.IsExist is deprecated, use errors.Is(err, fs.ErrExist) instead"},
+		{"oserrors", 37, "os.IsPermission is deprecated, use errors.Is(err, fs.ErrPermission) instead"},
+	}
+
+	actual := collectDiagnostics(t, graph)
+
+	sortDiagnostics(expected)
+	sortDiagnostics(actual)
+
+	if !slices.Equal(expected, actual) {
+		t.Errorf("diagnostics mismatch\nwant:\n%s\ngot:\n%s", joinDiagnostics(expected), joinDiagnostics(actual))
+	}
+
+	// A second run must not be affected by state left over from the first.
+	again := collectDiagnostics(t, analyze(t, "mixed"))
+	sortDiagnostics(again)
+
+	if !slices.Equal(actual, again) {
+		t.Errorf("diagnostics changed on second run\nfirst:\n%s\nsecond:\n%s", joinDiagnostics(actual), joinDiagnostics(again))
+	}
+}
+
+func TestListAnalyzers(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := listAnalyzers(&buf, godernize.Analyzers()); err != nil {
+		t.Fatalf("listAnalyzers failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(godernize.Analyzers()) {
+		t.Fatalf("listAnalyzers printed %d lines, want one per analyzer (%d):\n%s", len(lines), len(godernize.Analyzers()), buf.String())
+	}
+
+	for i, analyzer := range godernize.Analyzers() {
+		summary, _, _ := strings.Cut(analyzer.Doc, "\n")
+
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 || fields[0] != analyzer.Name || fields[1] != analyzer.URL || !strings.HasSuffix(lines[i], summary) {
+			t.Errorf("line %d = %q, want %s, %s, and %q", i, lines[i], analyzer.Name, analyzer.URL, summary)
+		}
+	}
+}
+
+func TestIsListRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"-godernize-list"}, true},
+		{[]string{"--godernize-list"}, true},
+		{[]string{"-fix", "-godernize-list=true"}, true},
+		{[]string{"-godernize-list=false"}, false},
+		{[]string{"./..."}, false},
+		{[]string{"./...", "-godernize-list"}, false},
+		{[]string{"--", "-godernize-list"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isListRequested(tt.args); got != tt.expected {
+			t.Errorf("isListRequested(%q) = %v, want %v", tt.args, got, tt.expected)
+		}
+	}
+}
+
+// TestSARIF checks that the SARIF report of the mixed package lists every
+// analyzer as a rule and every diagnostic as a result.
+func TestSARIF(t *testing.T) {
+	t.Parallel()
+
+	results, err := godernize.Check(context.Background(), "./testdata/src/mixed")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	src, err := filepath.Abs(filepath.Join("testdata", "src"))
+	if err != nil {
+		t.Fatalf("Failed to resolve testdata: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, godernize.Analyzers(), results, src); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var log struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("SARIF output is not valid JSON: %v", err)
+	}
+
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+
+	if rules := run.Tool.Driver.Rules; len(rules) != len(godernize.Analyzers()) {
+		t.Errorf("got %d rules, want one per analyzer (%d)", len(rules), len(godernize.Analyzers()))
+	}
+
+	counts := make(map[string]int)
+	for _, result := range run.Results {
+		counts[result.RuleID]++
+
+		if uri := result.Locations[0].PhysicalLocation.ArtifactLocation.URI; !strings.HasPrefix(uri, "mixed/") {
+			t.Errorf("result %s has uri %q, want it relative to the source root", result.RuleID, uri)
+		}
+	}
+
+	if len(run.Results) != 7 || counts["ctxnil"] != 3 || counts["oserrors"] != 4 {
+		t.Errorf("got %d results by rule %v, want 3 ctxnil and 4 oserrors", len(run.Results), counts)
+	}
+}
+
+func TestIsSARIFRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"-sarif", "out.sarif", "./..."}, true},
+		{[]string{"--sarif=out.sarif", "./..."}, true},
+		{[]string{"-ctxnil.ctx-funcs-only", "-sarif=out.sarif"}, true},
+		{[]string{"./...", "-sarif", "out.sarif"}, false},
+		{[]string{"--", "-sarif", "out.sarif"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isSARIFRequested(tt.args); got != tt.expected {
+			t.Errorf("isSARIFRequested(%q) = %v, want %v", tt.args, got, tt.expected)
+		}
+	}
+}
+
+// TestWrite applies the fixes of the mixed package to a copy of it, checking
+// that the copy is rewritten, that the fix overlapping another one is skipped,
+// and that the result still compiles.
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	results, err := godernize.Check(context.Background(), "./testdata/src/mixed")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	gopath := t.TempDir()
+	copied := filepath.Join(gopath, "src", "mixed", "mixed.go")
+
+	original, err := os.ReadFile(filepath.Join("testdata", "src", "mixed", "mixed.go"))
+	if err != nil {
+		t.Fatalf("Failed to read testdata: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(copied), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(copied, original, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The copy has the same content, so the positions are valid in it too.
+	moved := func(pos token.Position) token.Position {
+		pos.Filename = copied
+
+		return pos
+	}
+
+	for i := range results {
+		results[i].Pos = moved(results[i].Pos)
+
+		for _, suggested := range results[i].SuggestedFixes {
+			for j := range suggested.Edits {
+				suggested.Edits[j].Pos = moved(suggested.Edits[j].Pos)
+				suggested.Edits[j].End = moved(suggested.Edits[j].End)
+			}
+		}
+	}
+
+	files, skipped, err := fix.Apply(results)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	for filename, content := range files {
+		if err := writeFile(filename, content); err != nil {
+			t.Fatalf("writeFile failed: %v", err)
+		}
+	}
+
+	// The ctxnil fix of the if statement on line 35 replaces the oserrors
+	// calls in its body, which the oserrors fix on line 18 already replaced.
+	if len(skipped) != 1 || skipped[0].Analyzer != "ctxnil" || skipped[0].Pos.Line != 35 {
+		t.Errorf("skipped = %v, want the ctxnil fix on line 35", skipped)
+	}
+
+	fixed, err := os.ReadFile(copied)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"errors.Is(err, fs.ErrNotExist)", "errors.Is(err, fs.ErrPermission)", "return true && "} {
+		if !bytes.Contains(fixed, []byte(want)) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+
+	if bytes.Contains(fixed, []byte("ctx == nil")) {
+		t.Errorf("fixed source still checks ctx == nil:\n%s", fixed)
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir: filepath.Join(gopath, "src"),
+		Env: append(os.Environ(), "GOPATH="+gopath, "GO111MODULE=off", "GOPROXY=off"),
+	}
+
+	pkgs, err := packages.Load(cfg, "mixed")
+	if err != nil {
+		t.Fatalf("Failed to load the fixed package: %v", err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Errorf("fixed package does not compile:\n%s", fixed)
+	}
+}
+
+func TestIsWriteRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"-write", "./..."}, true},
+		{[]string{"--write=true", "./..."}, true},
+		{[]string{"-ctxnil.ctx-funcs-only", "-write"}, true},
+		{[]string{"-write=false", "./..."}, false},
+		{[]string{"./...", "-write"}, false},
+		{[]string{"--", "-write"}, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isWriteRequested(tt.args); got != tt.expected {
+			t.Errorf("isWriteRequested(%q) = %v, want %v", tt.args, got, tt.expected)
+		}
+	}
+}
+
+func TestAnalyzersFlag(t *testing.T) {
+	t.Parallel()
+
+	supported := &analysis.Analyzer{Name: "supported"}
+	enabled := supported.Flags.Bool("default-disabled", false, "")
+	unsupported := &analysis.Analyzer{Name: "unsupported"}
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(&analyzersFlag{name: "default-disabled", analyzers: []*analysis.Analyzer{supported, unsupported}},
+		defaultDisabledFlag, defaultDisabledUsage)
+
+	if err := flags.Parse([]string{"-" + defaultDisabledFlag, "./..."}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if !*enabled {
+		t.Error("-godernize.default-disabled did not set supported.default-disabled")
+	}
+
+	if got := flags.Lookup(defaultDisabledFlag).Value.String(); got != "true" {
+		t.Errorf("flag value = %q, want true", got)
+	}
+
+	if err := flags.Set(defaultDisabledFlag, "maybe"); err == nil {
+		t.Error("Set accepted a value that is not a boolean")
+	}
+}
+
+// collectDiagnostics returns the diagnostics of the root actions of graph,
+// failing the test for analyzer errors and invalid positions.
+func collectDiagnostics(t *testing.T, graph *checker.Graph) []expectedDiagnostic {
+	t.Helper()
+
+	var actual []expectedDiagnostic
+
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			t.Fatalf("%s failed: %v", act, act.Err)
+		}
+
+		fset := act.Package.Fset
+
+		for _, diagnostic := range act.Diagnostics {
+			if !diagnostic.Pos.IsValid() || (diagnostic.End.IsValid() && diagnostic.End < diagnostic.Pos) || fset.File(diagnostic.Pos) == nil {
+				t.Errorf("%s reported %q at an invalid range", act.Analyzer.Name, diagnostic.Message)
+			}
+
+			actual = append(actual, expectedDiagnostic{
+				analyzer: act.Analyzer.Name,
+				line:     fset.Position(diagnostic.Pos).Line,
+				message:  diagnostic.Message,
+			})
+		}
+	}
+
+	return actual
+}
+
+// analyze loads the testdata package pattern and runs the godernizecheck
+// analyzers on it.
+func analyze(t *testing.T, pattern string) *checker.Graph {
+	t.Helper()
+
+	testdata, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatalf("Failed to resolve testdata: %v", err)
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
+			packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedDeps | packages.NeedModule,
+		Dir: filepath.Join(testdata, "src"),
+		Env: append(os.Environ(), "GOPATH="+testdata, "GO111MODULE=off", "GOPROXY=off"),
+	}
+
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", pattern, err)
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatalf("Failed to load %s", pattern)
+	}
+
+	graph, err := checker.Analyze(godernize.Analyzers(), pkgs, nil)
+	if err != nil {
+		t.Fatalf("Failed to analyze %s: %v", pattern, err)
+	}
+
+	return graph
+}
+
+func sortDiagnostics(diagnostics []expectedDiagnostic) {
+	slices.SortFunc(diagnostics, func(a, b expectedDiagnostic) int {
+		return strings.Compare(fmt.Sprintf("%04d %s", a.line, a), fmt.Sprintf("%04d %s", b.line, b))
+	})
+}
+
+func joinDiagnostics(diagnostics []expectedDiagnostic) string {
+	lines := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		lines[i] = "\t" + diagnostic.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
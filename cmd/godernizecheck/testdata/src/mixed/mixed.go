@@ -0,0 +1,45 @@
+package mixed
+
+import (
+	"context"
+	"os"
+)
+
+func load(ctx context.Context, name string) ([]byte, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(name)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+func exists(ctx context.Context, name string) bool {
+	_, err := os.Stat(name)
+	if err == nil {
+		return ctx.Err() == nil
+	}
+
+	return ctx != nil && !os.IsNotExist(err)
+}
+
+func create(ctx context.Context, name string) error {
+	if ctx != nil {
+		f, err := os.Create(name)
+		if os.IsExist(err) || os.IsPermission(err) {
+			return err
+		}
+
+		return f.Close()
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,12 @@
+// Command containsidxgodernize runs the containsidx analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/containsidx"
+)
+
+func main() {
+	singlechecker.Main(containsidx.Analyzer)
+}
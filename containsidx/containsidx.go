@@ -0,0 +1,274 @@
+// Package containsidx provides an analyzer to detect strings.Index and
+// bytes.Index results compared with 0 or -1, which only check whether the
+// substring is present, as Contains does.
+package containsidx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for strings.Index and bytes.Index compared with 0 or -1
+
+This analyzer reports comparisons of an Index result that only check whether
+the substring is present, and suggests Contains:
+- strings.Index(s, sub) >= 0 -> strings.Contains(s, sub)
+- strings.Index(s, sub) != -1 -> strings.Contains(s, sub)
+- strings.Index(s, sub) > -1 -> strings.Contains(s, sub)
+- strings.Index(s, sub) < 0 -> !strings.Contains(s, sub)
+- strings.Index(s, sub) == -1 -> !strings.Contains(s, sub)
+- bytes.Index(b, sub) >= 0 -> bytes.Contains(b, sub)
+
+The comparisons are also recognized with the operands swapped, as in
+-1 != strings.Index(s, sub). Index results used as a position, such as one
+assigned to a variable before the comparison, are not reported. The fix
+renames the call and drops the comparison, keeping the arguments and the
+package name as written.`
+
+// Analyzer is the main analyzer for Index results compared with 0 or -1.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "containsidx",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/containsidx",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr, ok := n.(*ast.BinaryExpr)
+		if !ok || expr == nil {
+			return
+		}
+
+		file := fileMap.File(expr.Pos())
+
+		if diagnostic := diagnoseBinaryExpr(pass, file, expr); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExpr) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	// The Index call is kept, with the comparison on its other side removed.
+	operand, other, op := expr.X, expr.Y, expr.Op
+	if _, ok := ast.Unparen(operand).(*ast.CallExpr); !ok {
+		operand, other, op = expr.Y, expr.X, swap(expr.Op)
+	}
+
+	call, ok := ast.Unparen(operand).(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return nil
+	}
+
+	contains, ok := checksContains(pass.TypesInfo, op, other)
+	if !ok {
+		return nil
+	}
+
+	pkg := ""
+
+	for _, pkgPath := range []string{"strings", "bytes"} {
+		if isPkgFunc(pass.TypesInfo, call, pkgPath, "Index") {
+			pkg = pkgPath
+		}
+	}
+
+	name := funcName(call.Fun)
+	if pkg == "" || name == nil || shouldIgnore(pass.Fset, file, expr, "containsidx") {
+		return nil
+	}
+
+	replacement := pkg + ".Contains"
+	if !contains {
+		replacement = "!" + replacement
+	}
+
+	edits := []analysis.TextEdit{{Pos: name.Pos(), End: name.End(), NewText: []byte("Contains")}}
+
+	if operand == expr.X {
+		edits = append(edits, analysis.TextEdit{Pos: operand.End(), End: expr.End()})
+	} else {
+		edits = append(edits, analysis.TextEdit{Pos: expr.Pos(), End: operand.Pos()})
+	}
+
+	if !contains {
+		edits = append(edits, analysis.TextEdit{Pos: operand.Pos(), End: operand.Pos(), NewText: []byte("!")})
+	}
+
+	return &analysis.Diagnostic{
+		Pos: expr.Pos(),
+		End: expr.End(),
+		Message: fmt.Sprintf("%s.Index compared with %s only checks whether the substring is present, use %s instead",
+			pkg, types.ExprString(other), replacement),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Replace with " + replacement,
+			TextEdits: edits,
+		}},
+	}
+}
+
+// checksContains reports whether comparing an Index result with value by op
+// checks that the substring is present, when contains is true, or absent.
+// It returns false for comparisons that depend on the position.
+func checksContains(info *types.Info, op token.Token, value ast.Expr) (contains, ok bool) {
+	tv, found := info.Types[value]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return false, false
+	}
+
+	n, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return false, false
+	}
+
+	switch {
+	case n == 0 && op == token.GEQ, n == -1 && (op == token.NEQ || op == token.GTR):
+		return true, true
+	case n == 0 && op == token.LSS, n == -1 && (op == token.EQL || op == token.LEQ):
+		return false, true
+	}
+
+	return false, false
+}
+
+// swap returns the operator comparing the operands in the reverse order.
+func swap(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.GTR:
+		return token.LSS
+	case token.LEQ:
+		return token.GEQ
+	case token.GEQ:
+		return token.LEQ
+	}
+
+	return op
+}
+
+// funcName returns the identifier naming the called function, as in
+// strings.Replace or, with a dot import, Replace.
+func funcName(fun ast.Expr) *ast.Ident {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	}
+
+	return nil
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
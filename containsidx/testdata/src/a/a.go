@@ -0,0 +1,58 @@
+package a
+
+import (
+	"bytes"
+	"strings"
+	str "strings"
+)
+
+const notFound = -1
+
+func contains(s string, b []byte) {
+	_ = strings.Index(s, "a") >= 0        // want `strings.Index compared with 0 only checks whether the substring is present, use strings.Contains instead`
+	_ = strings.Index(s, "a") != -1       // want `strings.Index compared with -1 only checks whether the substring is present, use strings.Contains instead`
+	_ = strings.Index(s, "a") > -1        // want `strings.Index compared with -1 only checks whether the substring is present, use strings.Contains instead`
+	_ = strings.Index(s, "a") != notFound // want `strings.Index compared with notFound only checks`
+	_ = bytes.Index(b, []byte("a")) >= 0  // want `bytes.Index compared with 0 only checks whether the substring is present, use bytes.Contains instead`
+	_ = str.Index(s, "a") >= 0            // want `strings.Index compared with 0`
+	_ = -1 != strings.Index(s, "a")       // want `strings.Index compared with -1 .* use strings.Contains instead`
+	_ = 0 <= (strings.Index(s, "a"))      // want `strings.Index compared with 0 .* use strings.Contains instead`
+}
+
+func notContains(s string, b []byte) {
+	_ = strings.Index(s, "a") < 0         // want `strings.Index compared with 0 only checks whether the substring is present, use !strings.Contains instead`
+	_ = strings.Index(s, "a") == -1       // want `strings.Index compared with -1 .* use !strings.Contains instead`
+	_ = strings.Index(s, "a") <= -1       // want `strings.Index compared with -1 .* use !strings.Contains instead`
+	_ = bytes.Index(b, []byte("a")) == -1 // want `bytes.Index compared with -1 .* use !bytes.Contains instead`
+	_ = 0 > strings.Index(s, "a")         // want `strings.Index compared with 0 .* use !strings.Contains instead`
+}
+
+func position(s string) string {
+	// The position is extracted, so the Index call is needed.
+	if i := strings.Index(s, "="); i >= 0 {
+		return s[i+1:]
+	}
+
+	i := strings.Index(s, ":")
+	if i != -1 {
+		return s[:i]
+	}
+
+	return s
+}
+
+func notReported(s string, n int) {
+	_ = strings.Index(s, "a") > 0
+	_ = strings.Index(s, "a") == 0
+	_ = strings.Index(s, "a") >= n
+	_ = strings.Index(s, "a") != 2
+	_ = strings.IndexByte(s, 'a') >= 0
+	_ = strings.LastIndex(s, "a") >= 0
+	_ = strings.Index(s, "a") == strings.Index(s, "b")
+	_ = len(s) > strings.Index(s, "a")
+}
+
+func ignored(s string) bool {
+	//godernize:ignore=containsidx
+	return strings.Index(s, "a") >= 0
+}
@@ -0,0 +1,18 @@
+package autofix
+
+import (
+	"bytes"
+	str "strings"
+)
+
+func hasTab(s string) bool {
+	return str.Index(s, "\t") >= 0 // want `strings.Index compared with 0`
+}
+
+func missingNewline(b []byte) bool {
+	return -1 == bytes.Index(b, []byte("\n")) // want `bytes.Index compared with -1`
+}
+
+func both(s string) bool {
+	return (str.Index(s, "a")) != -1 && str.Index(s, "b") < 0 // want `strings.Index compared with -1` `strings.Index compared with 0`
+}
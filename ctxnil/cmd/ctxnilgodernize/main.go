@@ -2,11 +2,10 @@
 package main
 
 import (
-	"golang.org/x/tools/go/analysis/singlechecker"
-
 	"github.com/jaeyeom/godernize/ctxnil"
+	"github.com/jaeyeom/godernize/internal/driver"
 )
 
 func main() {
-	singlechecker.Main(ctxnil.Analyzer)
+	driver.Main(ctxnil.Analyzer)
 }
@@ -2,16 +2,26 @@
 package ctxnil
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
+	"go/format"
+	"go/printer"
 	"go/token"
 	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 
 	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/gofile"
+	"github.com/jaeyeom/godernize/internal/importutil"
+	"github.com/jaeyeom/godernize/internal/typeutil"
 )
 
 const (
@@ -19,26 +29,119 @@ const (
 	falseValue = "false"
 )
 
+// Categories of the diagnostics, set as analysis.Diagnostic.Category so that
+// aggregated output can be filtered by kind. The values are stable.
+const (
+	// CategoryAlwaysTrue marks conditions and comparisons that are always
+	// true.
+	CategoryAlwaysTrue = "ctxnil.always-true"
+	// CategoryAlwaysFalse marks conditions and comparisons that are always
+	// false.
+	CategoryAlwaysFalse = "ctxnil.always-false"
+	// CategorySimplify marks conditions that simplify to a non-constant
+	// expression.
+	CategorySimplify = "ctxnil.simplify"
+	// CategoryDeadBranch marks if statements with an unreachable then or
+	// else clause.
+	CategoryDeadBranch = "ctxnil.dead-branch"
+	// CategoryUnusedParam marks context parameters only compared to nil.
+	CategoryUnusedParam = "ctxnil.unused-param"
+	// CategoryNilAssignment marks nil assigned to a context.
+	CategoryNilAssignment = "ctxnil.nil-assignment"
+	// CategoryStructField replaces the categories above, with
+	// -struct-fields, for conditions and comparisons checking a context
+	// stored in a struct field, which should be passed as a parameter.
+	CategoryStructField = "ctxnil.struct-field"
+	// CategoryError replaces the categories above with -strict, which
+	// treats every diagnostic as an error to fix.
+	CategoryError = "ctxnil.error"
+)
+
+// structFieldAdvice ends the messages of diagnostics in CategoryStructField.
+const structFieldAdvice = "; do not store contexts in structs, pass them to the functions that need them"
+
+// defaultMaxDepth is the default of the max-depth flag. It is far beyond
+// handwritten conditions but bounds the recursion on generated code.
+const defaultMaxDepth = 1000
+
 // Doc describes what this analyzer does.
 const Doc = `check for nil comparisons with context.Context
 
 This analyzer reports nil comparisons with context.Context values and suggests
 removing them since contexts should never be nil. It performs expression
-simplification to handle complex boolean expressions and control flow.`
+simplification to handle complex boolean expressions and control flow.
+
+Contexts loaded from a sync/atomic.Value, such as
+v.Load().(context.Context), are not reported: they are nil until a context
+is stored.
+
+A kind of diagnostic can be ignored by its category, such as
+//godernize:ignore=ctxnil.unused-param. With -strict, such ignores are
+bypassed and only directives ignoring the whole analyzer apply.
+
+Generated files, marked by a "// Code generated ... DO NOT EDIT." header, are
+skipped unless -skip-generated=false.
+
+With -struct-fields, conditions and comparisons checking a context stored in a
+struct field, such as s.ctx == nil, are reported with the ctxnil.struct-field
+category and a message recommending to pass the context as a parameter.`
 
 // Analyzer is the main analyzer for context nil comparisons.
 //
 //nolint:gochecknoglobals // analyzer pattern requires global variable
-var Analyzer = &analysis.Analyzer{
-	Name:     "ctxnil",
-	Doc:      Doc,
-	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/ctxnil",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+var Analyzer = newAnalyzer(&runner{})
+
+func newAnalyzer(runner *runner) *analysis.Analyzer {
+	analyzer := &analysis.Analyzer{
+		Name:     "ctxnil",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/ctxnil",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.ctxFuncsOnly, "ctx-funcs-only", false,
+		"only inspect function declarations that have a context.Context parameter")
+	analyzer.Flags.BoolVar(&runner.annotate, "annotate", false,
+		"add a comment such as '// ctx is never nil' after the opening brace when a condition is simplified")
+	analyzer.Flags.BoolVar(&runner.defaultDisabled, "default-disabled", false,
+		"only report in functions and files with a //godernize:enable directive")
+	analyzer.Flags.BoolVar(&runner.vendorReportOnly, "vendor-report-only", false,
+		"report conditions in files under a vendor directory without suggesting fixes")
+	analyzer.Flags.IntVar(&runner.maxDepth, "max-depth", defaultMaxDepth,
+		"maximum nesting of parentheses and operators simplified in a condition; deeper conditions are left as is")
+	analyzer.Flags.BoolVar(&runner.skipGenerated, "skip-generated", true,
+		"skip files with a '// Code generated ... DO NOT EDIT.' header")
+	analyzer.Flags.BoolVar(&runner.structFields, "struct-fields", false,
+		"report nil checks of context struct fields with the ctxnil.struct-field category")
+	analyzer.Flags.BoolVar(&runner.strict, "strict", false,
+		"report every diagnostic with the ctxnil.error category and ignore only directives naming the whole analyzer")
+
+	return analyzer
+}
+
+// IsContextExpr reports whether expr is a context.Context by the rules of the
+// analyzer, which treats such values as never nil. Aliases of context.Context
+// match, and so do interfaces that embed it. Other context-aware analyzers
+// can use it to recognize contexts the same way.
+func IsContextExpr(info *types.Info, expr ast.Expr) bool {
+	return typeutil.IsContextType(info, expr)
+}
+
+type runner struct {
+	ctxFuncsOnly     bool
+	annotate         bool
+	defaultDisabled  bool
+	vendorReportOnly bool
+	strict           bool
+	skipGenerated    bool
+	structFields     bool
+	maxDepth         int
+	visits           *int // Counts the visited nodes when set, for benchmarks
 }
 
 //nolint:nilnil // analyzer pattern
-func run(pass *analysis.Pass) (any, error) {
+func (r *runner) run(pass *analysis.Pass) (any, error) {
 	if pass == nil {
 		return nil, nil
 	}
@@ -49,68 +152,284 @@ func run(pass *analysis.Pass) (any, error) {
 	}
 
 	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
 		(*ast.IfStmt)(nil),
+		(*ast.ForStmt)(nil),
+		(*ast.SwitchStmt)(nil),
+		(*ast.AssignStmt)(nil),
 		(*ast.BinaryExpr)(nil),
 	}
 
-	fileMap := buildFileMap(pass)
-	processedExprs := make(map[ast.Expr]bool) // Track processed expressions to avoid duplicates
+	fileMap := filemap.New(pass.Files)
+	generated := r.generatedFiles(pass)
+	var handled conditionStack // Conditions already reported as a whole
+	var dropped conditionStack // Branches removed by the fix of their if statement
+	report := r.reporter(pass)
+
+	// Fixes of conditions comparing a context with a zero variable would
+	// leave the variable unused, so they are dropped.
+	reportCondition := func(file *ast.File, node ast.Node, diagnostic analysis.Diagnostic, cond ast.Expr) {
+		if comparesZeroVar(pass, cond) {
+			diagnostic.SuggestedFixes = nil
+		}
+
+		if r.structFields && comparesField(pass, cond) {
+			diagnostic.Category = CategoryStructField
+			diagnostic.Message += structFieldAdvice
+		}
 
-	inspect.Preorder(nodeFilter, func(n ast.Node) {
-		pos := pass.Fset.Position(n.Pos())
-		filename := pos.Filename
-		file := fileMap[filename]
+		report(file, node, diagnostic)
+	}
+
+	visit := func(n ast.Node) {
+		if r.visits != nil {
+			*r.visits++
+		}
+
+		// Nodes come from pass.Files, never from pass.OtherFiles or
+		// pass.IgnoredFiles, but a node without a file could be checked
+		// against no ignore directive, so it is skipped.
+		file := fileMap.File(n.Pos())
+		if file == nil || generated[file] {
+			return
+		}
+
+		// The fix of the enclosing if statement deletes the node, so a fix
+		// of its own would overlap.
+		if dropped.covers(n) {
+			return
+		}
+
+		if r.defaultDisabled && !shouldEnable(file, n, "ctxnil") {
+			return
+		}
 
 		switch node := n.(type) {
+		case *ast.FuncDecl:
+			for _, diagnostic := range diagnoseUnusedParams(pass, file, node.Type, node.Body) {
+				report(file, n, diagnostic)
+			}
+		case *ast.FuncLit:
+			for _, diagnostic := range diagnoseUnusedParams(pass, file, node.Type, node.Body) {
+				report(file, n, diagnostic)
+			}
 		case *ast.IfStmt:
-			if diagnostic := diagnoseIfStmt(pass, file, node); diagnostic != nil {
-				pass.Report(*diagnostic)
-				// Mark the condition as processed to avoid duplicate reports
-				if node.Cond != nil {
-					markProcessedExpr(node.Cond, processedExprs)
-				}
+			if diagnostic, branch := diagnoseIfStmt(pass, file, node, r.annotate, r.maxDepth); diagnostic != nil {
+				reportCondition(file, n, *diagnostic, node.Cond)
+				handled.push(node.Cond)
+				dropped.push(branch)
+			}
+		case *ast.ForStmt:
+			if diagnostic := diagnoseForStmt(pass, file, node, r.annotate, r.maxDepth); diagnostic != nil {
+				reportCondition(file, n, *diagnostic, node.Cond)
+				// The init and post statements are still visited for nil
+				// assignments, only the condition is done.
+				handled.push(node.Cond)
+			}
+		case *ast.SwitchStmt:
+			if diagnostic, cond := diagnoseSwitchInit(pass, file, node); diagnostic != nil {
+				reportCondition(file, n, *diagnostic, cond)
+				handled.push(cond)
+			}
+		case *ast.AssignStmt:
+			for _, diagnostic := range diagnoseNilAssignments(pass, file, node) {
+				report(file, n, diagnostic)
 			}
 		case *ast.BinaryExpr:
-			// Only process if not already handled by an if statement
-			if !processedExprs[node] {
-				if diagnostic := diagnoseBinaryExpr(pass, file, node); diagnostic != nil {
-					pass.Report(*diagnostic)
-				}
+			// Comparisons within a reported if or for condition are
+			// rewritten by its fix already.
+			if handled.covers(node) {
+				return
+			}
+
+			if diagnostic := diagnoseBinaryExpr(pass, file, node); diagnostic != nil {
+				reportCondition(file, n, *diagnostic, node)
 			}
 		}
-	})
+	}
+
+	if r.ctxFuncsOnly {
+		inspectContextFuncs(pass, inspect, visit)
+	} else {
+		inspect.Preorder(nodeFilter, visit)
+	}
 
 	return nil, nil
 }
 
-func buildFileMap(pass *analysis.Pass) map[string]*ast.File {
-	fileMap := make(map[string]*ast.File)
+// generatedFiles returns the files of pass to skip with -skip-generated,
+// which carry the standard header of generated code.
+func (r *runner) generatedFiles(pass *analysis.Pass) map[*ast.File]bool {
+	generated := make(map[*ast.File]bool)
+
+	if !r.skipGenerated {
+		return generated
+	}
 
 	for _, file := range pass.Files {
-		pos := pass.Fset.Position(file.Pos())
-		fileMap[pos.Filename] = file
+		if gofile.IsGenerated(file) {
+			generated[file] = true
+		}
 	}
 
-	return fileMap
+	return generated
 }
 
-// markProcessedExpr recursively marks an expression and its sub-expressions as processed.
-func markProcessedExpr(expr ast.Expr, processed map[ast.Expr]bool) {
-	if expr == nil {
-		return
+// reporter returns the function reporting the diagnostics of node in file.
+// Diagnostics whose category is ignored, as in
+// //godernize:ignore=ctxnil.simplify, are dropped; with -strict, they are
+// reported with CategoryError instead. With -vendor-report-only, diagnostics
+// in vendored files lose their suggested fixes, as vendored code is not
+// edited by hand.
+func (r *runner) reporter(pass *analysis.Pass) func(*ast.File, ast.Node, analysis.Diagnostic) {
+	return func(file *ast.File, node ast.Node, diagnostic analysis.Diagnostic) {
+		if r.strict {
+			diagnostic.Category = CategoryError
+		} else if shouldIgnore(pass.Fset, file, node, diagnostic.Category) {
+			return
+		}
+
+		if r.vendorReportOnly && isVendored(pass.Fset.Position(diagnostic.Pos).Filename) {
+			diagnostic.SuggestedFixes = nil
+		}
+
+		pass.Report(diagnostic)
 	}
+}
 
-	processed[expr] = true
+// isVendored checks if filename has a vendor directory among its path
+// segments.
+func isVendored(filename string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(filepath.Dir(filename)), "/"), "vendor")
+}
 
-	switch exprType := expr.(type) {
-	case *ast.BinaryExpr:
-		markProcessedExpr(exprType.X, processed)
-		markProcessedExpr(exprType.Y, processed)
-	case *ast.UnaryExpr:
-		markProcessedExpr(exprType.X, processed)
-	case *ast.ParenExpr:
-		markProcessedExpr(exprType.X, processed)
+// inspectContextFuncs calls visit, in preorder, on function declarations that
+// have a context.Context parameter and on the function literals, if, for,
+// and switch statements, assignments, and binary expressions within them.
+// Other functions are skipped without walking their bodies.
+func inspectContextFuncs(pass *analysis.Pass, inspect *inspector.Inspector, visit func(ast.Node)) {
+	inspect.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		funcDecl, ok := n.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || !hasContextParam(pass, funcDecl.Type) {
+			return
+		}
+
+		visit(funcDecl)
+
+		ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+			switch node.(type) {
+			case *ast.FuncLit, *ast.IfStmt, *ast.ForStmt, *ast.SwitchStmt, *ast.AssignStmt, *ast.BinaryExpr:
+				visit(node)
+			}
+
+			return true
+		})
+	})
+}
+
+// hasContextParam checks if the function type has a context.Context parameter.
+func hasContextParam(pass *analysis.Pass, funcType *ast.FuncType) bool {
+	if funcType == nil || funcType.Params == nil {
+		return false
+	}
+
+	for _, field := range funcType.Params.List {
+		if IsContextExpr(pass.TypesInfo, field.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// conditionStack holds the reported conditions, or the dropped branches,
+// enclosing or following the node being visited. Nodes are visited in
+// preorder, so once a node lies past an entry, no later node lies inside it;
+// such entries are dropped, keeping the stack as deep as the nesting of
+// entries.
+type conditionStack []ast.Node
+
+func (s *conditionStack) push(node ast.Node) {
+	if node != nil {
+		*s = append(*s, node)
+	}
+}
+
+// covers checks if node lies within one of the entries.
+func (s *conditionStack) covers(node ast.Node) bool {
+	// An entry still ahead, such as an else branch seen from its then
+	// clause, is kept along with the entries below it.
+	for len(*s) > 0 && node.Pos() >= (*s)[len(*s)-1].End() {
+		*s = (*s)[:len(*s)-1]
 	}
+
+	for _, entry := range slices.Backward(*s) {
+		if entry.Pos() <= node.Pos() && node.End() <= entry.End() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diagnoseUnusedParams reports context parameters that are used only in nil
+// comparisons. Since those comparisons are constant, such a parameter is
+// effectively unused.
+func diagnoseUnusedParams(
+	pass *analysis.Pass, file *ast.File, funcType *ast.FuncType, body *ast.BlockStmt,
+) []analysis.Diagnostic {
+	if funcType == nil || funcType.Params == nil || body == nil {
+		return nil
+	}
+
+	var diagnostics []analysis.Diagnostic
+
+	for _, field := range funcType.Params.List {
+		if !IsContextExpr(pass.TypesInfo, field.Type) {
+			continue
+		}
+
+		for _, name := range field.Names {
+			obj := pass.TypesInfo.Defs[name]
+			if obj == nil || !isOnlyComparedToNil(pass, body, obj) || shouldIgnore(pass.Fset, file, name, "ctxnil") {
+				continue
+			}
+
+			diagnostics = append(diagnostics, analysis.Diagnostic{
+				Pos:      name.Pos(),
+				End:      name.End(),
+				Category: CategoryUnusedParam,
+				Message:  fmt.Sprintf("context parameter '%s' is only compared to nil and is otherwise unused", name.Name),
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// isOnlyComparedToNil checks if obj is used at least once in body and every use
+// is the context side of a nil comparison.
+func isOnlyComparedToNil(pass *analysis.Pass, body *ast.BlockStmt, obj types.Object) bool {
+	uses, nilChecks := 0, 0
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Ident:
+			if pass.TypesInfo.Uses[node] == obj {
+				uses++
+			}
+		case *ast.BinaryExpr:
+			ctxSide, _, _ := analyzeContextNilComparison(pass, node)
+			if ident, ok := ast.Unparen(ctxSide).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+				nilChecks++
+			}
+		}
+
+		return true
+	})
+
+	return uses > 0 && uses == nilChecks
 }
 
 func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExpr) *analysis.Diagnostic {
@@ -124,22 +443,27 @@ func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExp
 		return nil // Not a context nil comparison
 	}
 
-	if shouldIgnore(file, expr, "ctxnil") {
+	if shouldIgnore(pass.Fset, file, expr, "ctxnil") {
 		return nil
 	}
 
 	// Determine replacement value
-	replacement := falseValue
+	replacement, category := falseValue, CategoryAlwaysFalse
 	if !isEqual {
-		replacement = trueValue
+		replacement, category = trueValue, CategoryAlwaysTrue
 	}
 
 	message := fmt.Sprintf("context should never be nil, replace '%s' with '%s'",
-		formatExpr(expr), replacement)
+		formatExpr(pass.Fset, expr), replacement)
+	if source := contextSource(pass, file, ctxSide); source != "" {
+		message = fmt.Sprintf("%s is never nil, replace '%s' with '%s'",
+			source, formatExpr(pass.Fset, expr), replacement)
+	}
 
 	return &analysis.Diagnostic{
-		Pos:     expr.Pos(),
-		Message: message,
+		Pos:      expr.Pos(),
+		Category: category,
+		Message:  message,
 		SuggestedFixes: []analysis.SuggestedFix{{
 			Message: fmt.Sprintf("Replace with %s", replacement),
 			TextEdits: []analysis.TextEdit{{
@@ -151,110 +475,594 @@ func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExp
 	}
 }
 
-func diagnoseIfStmt(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt) *analysis.Diagnostic {
+// contextSource describes where the compared context comes from, such as
+// "context parameter 'ctx'", "context field 's.ctx'", or "context returned by
+// 'newCtx()'", or returns "" for other contexts such as local variables.
+func contextSource(pass *analysis.Pass, file *ast.File, ctx ast.Expr) string {
+	switch e := ast.Unparen(ctx).(type) {
+	case *ast.Ident:
+		if isParam(pass.TypesInfo, file, e) {
+			return fmt.Sprintf("context parameter '%s'", e.Name)
+		}
+	case *ast.SelectorExpr:
+		if isField(pass.TypesInfo, e) {
+			return fmt.Sprintf("context field '%s'", formatExpr(pass.Fset, e))
+		}
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[e.Fun]; ok && !tv.IsType() {
+			return fmt.Sprintf("context returned by '%s'", formatExpr(pass.Fset, e))
+		}
+	}
+
+	return ""
+}
+
+// isParam checks if ident refers to a parameter of a function declared in
+// file, including function literals and receivers.
+func isParam(info *types.Info, file *ast.File, ident *ast.Ident) bool {
+	obj, ok := info.Uses[ident].(*types.Var)
+	if !ok || file == nil || obj.Pos() < file.Pos() || obj.Pos() >= file.End() {
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if found || n == nil || n.Pos() > obj.Pos() || n.End() <= obj.Pos() {
+			return false
+		}
+
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			found = contains(node.Recv, obj.Pos())
+		case *ast.FuncType:
+			found = contains(node.Params, obj.Pos())
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func contains(fields *ast.FieldList, pos token.Pos) bool {
+	return fields != nil && fields.Pos() <= pos && pos < fields.End()
+}
+
+// diagnoseSwitchInit reports a switch whose init statement declares a
+// variable holding a context nil comparison, as in
+// "switch c := ctx != nil; c", naming the variable that is constant. It also
+// returns the comparison, which the fix replaces.
+func diagnoseSwitchInit(pass *analysis.Pass, file *ast.File, stmt *ast.SwitchStmt) (*analysis.Diagnostic, ast.Expr) {
+	assign, ok := stmt.Init.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil
+	}
+
+	name, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+
+	cond, ok := ast.Unparen(assign.Rhs[0]).(*ast.BinaryExpr)
+	if !ok {
+		return nil, nil
+	}
+
+	diagnostic := diagnoseBinaryExpr(pass, file, cond)
+	if diagnostic == nil {
+		return nil, nil
+	}
+
+	value := string(diagnostic.SuggestedFixes[0].TextEdits[0].NewText)
+	diagnostic.Message = fmt.Sprintf("context should never be nil, so switch variable '%s' is always %s; "+
+		"replace '%s' with '%s'", name.Name, value, formatExpr(pass.Fset, cond), value)
+
+	return diagnostic, cond
+}
+
+// diagnoseIfStmt simplifies the condition of an if statement. With a fix
+// that drops a branch of the statement, it also returns that branch.
+func diagnoseIfStmt(
+	pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt, annotate bool, maxDepth int,
+) (*analysis.Diagnostic, ast.Node) {
 	if stmt == nil || stmt.Cond == nil {
-		return nil
+		return nil, nil
 	}
 
-	if shouldIgnore(file, stmt, "ctxnil") {
-		return nil
+	if shouldIgnore(pass.Fset, file, stmt, "ctxnil") {
+		return nil, nil
 	}
 
 	// Check if condition contains context nil comparisons
-	replacement := buildReplacementCondition(pass, stmt.Cond)
+	replacement := buildReplacementCondition(pass, stmt.Cond, maxDepth)
 	if replacement == nil {
-		return nil // No context nil comparisons found
+		return nil, nil // No context nil comparisons found
 	}
 
 	// Generate appropriate fix based on replacement
-	return createConditionFix(stmt, replacement)
+	diagnostic := createConditionFix(pass, file, stmt, replacement, annotate)
+	if diagnostic == nil || len(diagnostic.SuggestedFixes) == 0 || !replacement.IsLiteral {
+		return diagnostic, nil
+	}
+
+	// An always-true condition keeps the then clause, dropping the whole
+	// else chain; an always-false one drops the then clause.
+	if replacement.NewCondition == trueValue {
+		return diagnostic, stmt.Else
+	}
+
+	return diagnostic, stmt.Body
+}
+
+// diagnoseForStmt simplifies the condition of a for statement. An always-true
+// condition is removed, turning the loop into one that only ends through
+// break or return; an always-false condition is reported without a fix since
+// the init statement may have side effects.
+func diagnoseForStmt(
+	pass *analysis.Pass, file *ast.File, stmt *ast.ForStmt, annotate bool, maxDepth int,
+) *analysis.Diagnostic {
+	if stmt == nil || stmt.Cond == nil {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, stmt, "ctxnil") {
+		return nil
+	}
+
+	replacement := buildReplacementCondition(pass, stmt.Cond, maxDepth)
+	if replacement == nil {
+		return nil
+	}
+
+	if !replacement.IsLiteral {
+		return simplifyConditionDiagnostic(pass, file, stmt.Cond, stmt.Body, replacement, annotate)
+	}
+
+	if replacement.NewCondition == falseValue {
+		return &analysis.Diagnostic{
+			Pos:      stmt.Pos(),
+			Category: CategoryAlwaysFalse,
+			Message:  "loop condition is always false, loop body never runs",
+		}
+	}
+
+	// Without init and post statements the condition is the only clause,
+	// so the space before the body goes with it: "for ctx != nil {" becomes
+	// "for {".
+	end := stmt.Cond.End()
+	if stmt.Init == nil && stmt.Post == nil {
+		end = stmt.Body.Lbrace
+	}
+
+	return &analysis.Diagnostic{
+		Pos:      stmt.Cond.Pos(),
+		Category: CategoryAlwaysTrue,
+		Message:  "loop condition is always true",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Remove loop condition",
+			TextEdits: withImportRemovals(pass, file, []analysis.TextEdit{{Pos: stmt.Cond.Pos(), End: end}}, stmt.Cond),
+		}},
+	}
+}
+
+// diagnoseNilAssignments reports nil assigned to a context, such as
+// "ctx = nil" in the post statement of a for loop. No fix is offered since
+// the right replacement, a parent context or context.Background(), depends on
+// the caller.
+func diagnoseNilAssignments(pass *analysis.Pass, file *ast.File, stmt *ast.AssignStmt) []analysis.Diagnostic {
+	if stmt == nil || stmt.Tok != token.ASSIGN || len(stmt.Lhs) != len(stmt.Rhs) {
+		return nil
+	}
+
+	var diagnostics []analysis.Diagnostic
+
+	for i, rhs := range stmt.Rhs {
+		lhs := stmt.Lhs[i]
+		if !isNilIdent(pass.TypesInfo, rhs) || !IsContextExpr(pass.TypesInfo, lhs) {
+			continue
+		}
+
+		if shouldIgnore(pass.Fset, file, stmt, "ctxnil") {
+			return nil
+		}
+
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos:      rhs.Pos(),
+			Category: CategoryNilAssignment,
+			Message: fmt.Sprintf("context should never be nil, assign a valid context such as context.Background() to '%s'",
+				formatExpr(pass.Fset, lhs)),
+		})
+	}
+
+	return diagnostics
 }
 
-// analyzeContextNilComparison checks if this binary expression compares context with nil.
+// analyzeContextNilComparison checks if this binary expression compares context with nil or with a
+// variable that is always nil.
 func analyzeContextNilComparison(pass *analysis.Pass, expr *ast.BinaryExpr) (ctxSide, nilSide ast.Expr, isEqual bool) {
 	if expr.Op != token.EQL && expr.Op != token.NEQ {
 		return nil, nil, false
 	}
 
 	// Check if one side is context and other is nil
-	leftIsCtx := isContextType(pass, expr.X)
-	rightIsCtx := isContextType(pass, expr.Y)
-	leftIsNil := isNilIdent(expr.X)
-	rightIsNil := isNilIdent(expr.Y)
+	leftIsCtx := IsContextExpr(pass.TypesInfo, expr.X)
+	rightIsCtx := IsContextExpr(pass.TypesInfo, expr.Y)
+	leftIsNil := isNilIdent(pass.TypesInfo, expr.X) || isZeroVar(pass, expr.X)
+	rightIsNil := isNilIdent(pass.TypesInfo, expr.Y) || isZeroVar(pass, expr.Y)
 
-	if leftIsCtx && rightIsNil {
+	if leftIsCtx && rightIsNil && !isAtomicLoaded(pass, expr.X) {
 		return expr.X, expr.Y, expr.Op == token.EQL
 	}
 
-	if rightIsCtx && leftIsNil {
+	if rightIsCtx && leftIsNil && !isAtomicLoaded(pass, expr.Y) {
 		return expr.Y, expr.X, expr.Op == token.EQL
 	}
 
 	return nil, nil, false
 }
 
-// isContextType checks if the expression has context.Context type.
-func isContextType(pass *analysis.Pass, expr ast.Expr) bool {
-	if expr == nil {
+// isAtomicLoaded checks if ctx is loaded from a sync/atomic.Value, either as
+// a type assertion of the result of Load or as a local variable assigned one.
+// Such a context is nil until one is stored, so comparing it with nil is
+// legitimate.
+func isAtomicLoaded(pass *analysis.Pass, ctx ast.Expr) bool {
+	if isAtomicLoad(pass.TypesInfo, ctx) {
+		return true
+	}
+
+	ident, ok := ast.Unparen(ctx).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() != pass.Pkg || obj.Parent() == obj.Pkg().Scope() {
+		return false
+	}
+
+	body := declaringBody(pass.Files, obj.Pos())
+
+	return body != nil && assignsAtomicLoad(pass.TypesInfo, body, obj)
+}
+
+// assignsAtomicLoad checks if obj is declared or assigned in body with a
+// context loaded from a sync/atomic.Value, including with the comma-ok form
+// of the type assertion.
+func assignsAtomicLoad(info *types.Info, body *ast.BlockStmt, obj types.Object) bool {
+	loaded := func(lhs, rhs []ast.Expr) bool {
+		for i, expr := range lhs {
+			ident, ok := ast.Unparen(expr).(*ast.Ident)
+			if !ok || info.ObjectOf(ident) != obj {
+				continue
+			}
+
+			switch {
+			case len(rhs) == len(lhs):
+				return isAtomicLoad(info, rhs[i])
+			case len(rhs) == 1 && i == 0:
+				return isAtomicLoad(info, rhs[0])
+			}
+		}
+
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			found = found || loaded(node.Lhs, node.Rhs)
+		case *ast.ValueSpec:
+			names := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				names[i] = name
+			}
+
+			found = found || loaded(names, node.Values)
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// isAtomicLoad checks if expr is a type assertion of the result of
+// (*sync/atomic.Value).Load, such as v.Load().(context.Context).
+func isAtomicLoad(info *types.Info, expr ast.Expr) bool {
+	assert, ok := ast.Unparen(expr).(*ast.TypeAssertExpr)
+	if !ok || assert.Type == nil {
+		return false
+	}
+
+	call, ok := ast.Unparen(assert.X).(*ast.CallExpr)
+	if !ok {
 		return false
 	}
 
-	typ := pass.TypesInfo.TypeOf(expr)
-	if typ == nil {
-		return false
-	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+
+	return ok && fn.FullName() == "(*sync/atomic.Value).Load"
+}
+
+// isNilIdent checks if expression is the predeclared nil, not a variable
+// that shadows it.
+func isNilIdent(info *types.Info, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident == nil || ident.Name != "nil" {
+		return false
+	}
+
+	_, isNil := info.Uses[ident].(*types.Nil)
+
+	return isNil
+}
+
+// isZeroVar checks if expr is a local interface variable declared without a
+// value, such as "var zero context.Context", that its function never assigns
+// or takes the address of, so that it is always nil.
+func isZeroVar(pass *analysis.Pass, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Pkg() != pass.Pkg || obj.Parent() == obj.Pkg().Scope() || !types.IsInterface(obj.Type()) {
+		return false
+	}
+
+	body := declaringBody(pass.Files, obj.Pos())
+	if body == nil || !isDeclaredWithoutValue(body, obj.Pos()) {
+		return false
+	}
+
+	return !isModified(pass.TypesInfo, body, obj)
+}
+
+// declaringBody returns the body of the innermost function containing pos.
+func declaringBody(files []*ast.File, pos token.Pos) *ast.BlockStmt {
+	var body *ast.BlockStmt
+
+	for _, file := range files {
+		if pos < file.Pos() || pos > file.End() {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil || pos < n.Pos() || pos >= n.End() {
+				return false
+			}
+
+			switch fn := n.(type) {
+			case *ast.FuncDecl:
+				body = fn.Body
+			case *ast.FuncLit:
+				body = fn.Body
+			}
+
+			return true
+		})
+	}
+
+	return body
+}
+
+// isDeclaredWithoutValue checks if the name at pos is declared in body by a
+// var declaration without values.
+func isDeclaredWithoutValue(body *ast.BlockStmt, pos token.Pos) bool {
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found || n == nil || pos < n.Pos() || pos >= n.End() {
+			return false
+		}
+
+		if decl, ok := n.(*ast.GenDecl); ok && decl.Tok == token.VAR {
+			for _, spec := range decl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok || len(valueSpec.Values) > 0 {
+					continue
+				}
+
+				for _, name := range valueSpec.Names {
+					found = found || name.Pos() == pos
+				}
+			}
+
+			return false
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// isModified checks if obj is assigned or has its address taken in body.
+func isModified(info *types.Info, body *ast.BlockStmt, obj types.Object) bool {
+	refers := func(expr ast.Expr) bool {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+		return ok && info.ObjectOf(ident) == obj
+	}
+
+	modified := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			modified = modified || slices.ContainsFunc(node.Lhs, refers)
+		case *ast.RangeStmt:
+			modified = modified || node.Tok == token.ASSIGN && (refers(node.Key) || refers(node.Value))
+		case *ast.UnaryExpr:
+			modified = modified || node.Op == token.AND && refers(node.X)
+		}
+
+		return !modified
+	})
+
+	return modified
+}
+
+// comparesZeroVar checks if cond compares a context with a variable that is
+// always nil.
+func comparesZeroVar(pass *analysis.Pass, cond ast.Expr) bool {
+	found := false
+
+	ast.Inspect(cond, func(n ast.Node) bool {
+		if expr, ok := n.(*ast.BinaryExpr); ok {
+			if _, nilSide, _ := analyzeContextNilComparison(pass, expr); nilSide != nil && !isNilIdent(pass.TypesInfo, nilSide) {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
 
-	// Check if type is context.Context
-	named, ok := typ.(*types.Named)
-	if !ok {
-		return false
-	}
+// comparesField checks if cond compares a context stored in a struct field
+// with nil, as in s.ctx == nil.
+func comparesField(pass *analysis.Pass, cond ast.Expr) bool {
+	found := false
 
-	obj := named.Obj()
-	if obj == nil || obj.Pkg() == nil {
-		return false
-	}
+	ast.Inspect(cond, func(n ast.Node) bool {
+		if expr, ok := n.(*ast.BinaryExpr); ok {
+			if ctxSide, _, _ := analyzeContextNilComparison(pass, expr); ctxSide != nil {
+				sel, isSel := ast.Unparen(ctxSide).(*ast.SelectorExpr)
+				found = isSel && isField(pass.TypesInfo, sel)
+			}
+		}
+
+		return !found
+	})
 
-	return obj.Pkg().Path() == "context" && obj.Name() == "Context"
+	return found
 }
 
-// isNilIdent checks if expression is the nil identifier.
-func isNilIdent(expr ast.Expr) bool {
-	ident, ok := expr.(*ast.Ident)
+// isField checks if sel selects a struct field, as s.ctx does, rather than a
+// package-level variable or a method.
+func isField(info *types.Info, sel *ast.SelectorExpr) bool {
+	selection, ok := info.Selections[sel]
 
-	return ok && ident != nil && ident.Name == "nil"
+	return ok && selection.Kind() == types.FieldVal
 }
 
 // ReplacementCondition represents a condition replacement.
 type ReplacementCondition struct {
+	// Expr is the simplified condition. It shares unchanged operands with
+	// the original condition.
+	Expr ast.Expr
+	// NewCondition is Expr rendered by go/format.
 	NewCondition string
 	IsLiteral    bool // true if the result is a literal true/false
 	Message      string
 }
 
+// newReplacement returns a replacement of the condition with expr. Literals
+// lose redundant parentheses, so that "(false)" is recognized as false.
+func newReplacement(expr ast.Expr, isLiteral bool, message string) *ReplacementCondition {
+	if isLiteral {
+		expr = ast.Unparen(expr)
+	}
+
+	return &ReplacementCondition{
+		Expr:         expr,
+		NewCondition: renderExpr(expr),
+		IsLiteral:    isLiteral,
+		Message:      message,
+	}
+}
+
+// newLiteralReplacement returns a replacement of the condition with the
+// literal true or false.
+func newLiteralReplacement(value, message string) *ReplacementCondition {
+	return newReplacement(ast.NewIdent(value), true, message)
+}
+
 // buildReplacementCondition recursively builds a replacement for conditions containing context nil comparisons.
-func buildReplacementCondition(pass *analysis.Pass, expr ast.Expr) *ReplacementCondition {
+// Conditions nested deeper than depth are left unsimplified, returning nil.
+func buildReplacementCondition(pass *analysis.Pass, expr ast.Expr, depth int) *ReplacementCondition {
+	if depth <= 0 {
+		return nil
+	}
+
 	switch e := expr.(type) {
 	case *ast.BinaryExpr:
-		return handleBinaryExpr(pass, e)
+		return handleBinaryExpr(pass, e, depth)
 	case *ast.ParenExpr:
-		inner := buildReplacementCondition(pass, e.X)
+		inner := buildReplacementCondition(pass, e.X, depth-1)
 		if inner == nil {
 			return nil
 		}
 
-		return &ReplacementCondition{
-			NewCondition: "(" + inner.NewCondition + ")",
-			IsLiteral:    inner.IsLiteral,
-			Message:      inner.Message,
+		// Parentheses are only needed to keep a binary expression together.
+		if _, ok := inner.Expr.(*ast.BinaryExpr); !ok {
+			return inner
+		}
+
+		return newReplacement(&ast.ParenExpr{X: inner.Expr}, inner.IsLiteral, inner.Message)
+	case *ast.UnaryExpr:
+		if e.Op != token.NOT {
+			return nil
 		}
+
+		return negateReplacement(buildReplacementCondition(pass, e.X, depth-1))
 	}
 
 	return nil
 }
 
+// negateReplacement returns the replacement of the negation of a condition
+// whose replacement is inner. Literals are flipped and double negations
+// cancel, so "!!(ctx != nil)" is always true.
+func negateReplacement(inner *ReplacementCondition) *ReplacementCondition {
+	if inner == nil {
+		return nil
+	}
+
+	if inner.IsLiteral {
+		value := trueValue
+		if inner.NewCondition == trueValue {
+			value = falseValue
+		}
+
+		return newLiteralReplacement(value, fmt.Sprintf("negated condition is always %s", value))
+	}
+
+	operand := inner.Expr
+	if paren, ok := operand.(*ast.ParenExpr); ok {
+		operand = paren.X
+	}
+
+	if unary, ok := operand.(*ast.UnaryExpr); ok && unary.Op == token.NOT {
+		replacement := newReplacement(unary.X, false, "")
+		replacement.Message = fmt.Sprintf("simplify to '%s'", replacement.NewCondition)
+
+		return replacement
+	}
+
+	if _, ok := operand.(*ast.BinaryExpr); ok {
+		operand = &ast.ParenExpr{X: operand}
+	}
+
+	replacement := newReplacement(&ast.UnaryExpr{Op: token.NOT, X: operand}, false, "")
+	replacement.Message = fmt.Sprintf("simplify to '%s'", replacement.NewCondition)
+
+	return replacement
+}
+
 // handleBinaryExpr handles binary expressions (==, !=, &&, ||).
-func handleBinaryExpr(pass *analysis.Pass, expr *ast.BinaryExpr) *ReplacementCondition {
+func handleBinaryExpr(pass *analysis.Pass, expr *ast.BinaryExpr, depth int) *ReplacementCondition {
 	// Check if this is a direct context nil comparison
 	if ctxSide, nilSide, isEqual := analyzeContextNilComparison(pass, expr); ctxSide != nil && nilSide != nil {
 		replacement := falseValue
@@ -262,40 +1070,36 @@ func handleBinaryExpr(pass *analysis.Pass, expr *ast.BinaryExpr) *ReplacementCon
 			replacement = trueValue
 		}
 
-		return &ReplacementCondition{
-			NewCondition: replacement,
-			IsLiteral:    true,
-			Message:      fmt.Sprintf("context nil comparison '%s' is always %s", formatExpr(expr), replacement),
-		}
+		return newLiteralReplacement(replacement,
+			fmt.Sprintf("context nil comparison '%s' is always %s", formatExpr(pass.Fset, expr), replacement))
 	}
 
 	// Handle logical operators
 	if expr.Op == token.LAND || expr.Op == token.LOR {
-		return handleLogicalExpr(pass, expr)
+		return handleLogicalExpr(pass, expr, depth)
 	}
 
 	return nil
 }
 
 // handleLogicalExpr handles && and || expressions.
-func handleLogicalExpr(pass *analysis.Pass, expr *ast.BinaryExpr) *ReplacementCondition {
-	leftReplacement := buildReplacementCondition(pass, expr.X)
-	rightReplacement := buildReplacementCondition(pass, expr.Y)
+func handleLogicalExpr(pass *analysis.Pass, expr *ast.BinaryExpr, depth int) *ReplacementCondition {
+	leftReplacement := buildReplacementCondition(pass, expr.X, depth-1)
+	rightReplacement := buildReplacementCondition(pass, expr.Y, depth-1)
 
 	// If neither side contains context comparisons, we can't help
 	if leftReplacement == nil && rightReplacement == nil {
 		return nil
 	}
 
-	leftExpr := formatExpr(expr.X)
-	rightExpr := formatExpr(expr.Y)
+	leftExpr, rightExpr := expr.X, expr.Y
 
 	if leftReplacement != nil {
-		leftExpr = leftReplacement.NewCondition
+		leftExpr = leftReplacement.Expr
 	}
 
 	if rightReplacement != nil {
-		rightExpr = rightReplacement.NewCondition
+		rightExpr = rightReplacement.Expr
 	}
 
 	// Now simplify the logical expression
@@ -307,7 +1111,7 @@ func handleLogicalExpr(pass *analysis.Pass, expr *ast.BinaryExpr) *ReplacementCo
 }
 
 // simplifyAndExpr simplifies && expressions.
-func simplifyAndExpr(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func simplifyAndExpr(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// Check for short-circuit cases first
 	if result := checkAndShortCircuit(leftExpr, rightExpr, leftRep, rightRep); result != nil {
 		return result
@@ -319,79 +1123,63 @@ func simplifyAndExpr(leftExpr, rightExpr string, leftRep, rightRep *ReplacementC
 	}
 
 	// General case where simplification occurred
-	return buildAndReplacement(leftExpr, rightExpr, leftRep, rightRep)
+	return buildLogicalReplacement(token.LAND, leftExpr, rightExpr)
 }
 
 // checkAndShortCircuit checks for short-circuit cases in && expressions.
-func checkAndShortCircuit(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func checkAndShortCircuit(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// false && X -> false (short circuit)
-	if leftRep != nil && leftRep.IsLiteral && leftExpr == falseValue {
-		return &ReplacementCondition{
-			NewCondition: falseValue,
-			IsLiteral:    true,
-			Message:      "condition is always false (left side is false)",
-		}
+	if leftRep != nil && leftRep.IsLiteral && isBoolLiteral(leftExpr, falseValue) {
+		return newLiteralReplacement(falseValue, "condition is always false (left side is false)")
 	}
 	// X && false -> false (short circuit)
-	if rightRep != nil && rightRep.IsLiteral && rightExpr == falseValue {
-		return &ReplacementCondition{
-			NewCondition: falseValue,
-			IsLiteral:    true,
-			Message:      "condition is always false (right side is false)",
-		}
+	if rightRep != nil && rightRep.IsLiteral && isBoolLiteral(rightExpr, falseValue) {
+		return newLiteralReplacement(falseValue, "condition is always false (right side is false)")
 	}
 
 	return nil
 }
 
 // checkAndSimplification checks for simplification cases in && expressions.
-func checkAndSimplification(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func checkAndSimplification(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// true && X -> X
-	if leftRep != nil && leftRep.IsLiteral && leftExpr == trueValue {
-		isRightLiteral := isLiteralExpr(rightExpr, rightRep)
-
-		return &ReplacementCondition{
-			NewCondition: rightExpr,
-			IsLiteral:    isRightLiteral,
-			Message:      fmt.Sprintf("simplify to '%s' (left side is always true)", rightExpr),
-		}
+	if leftRep != nil && leftRep.IsLiteral && isBoolLiteral(leftExpr, trueValue) {
+		return newReplacement(rightExpr, isLiteralExpr(rightExpr, rightRep),
+			fmt.Sprintf("simplify to '%s' (left side is always true)", renderExpr(rightExpr)))
 	}
 	// X && true -> X
-	if rightExpr == trueValue {
-		isLeftLiteral := isLiteralExpr(leftExpr, leftRep)
-
-		return &ReplacementCondition{
-			NewCondition: leftExpr,
-			IsLiteral:    isLeftLiteral,
-			Message:      fmt.Sprintf("simplify to '%s' (right side is always true)", leftExpr),
-		}
+	if isBoolLiteral(rightExpr, trueValue) {
+		return newReplacement(leftExpr, isLiteralExpr(leftExpr, leftRep),
+			fmt.Sprintf("simplify to '%s' (right side is always true)", renderExpr(leftExpr)))
 	}
 
 	return nil
 }
 
-// buildAndReplacement builds the replacement for general && cases.
-func buildAndReplacement(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
-	if leftRep != nil || rightRep != nil {
-		newCondition := leftExpr + " && " + rightExpr
+// buildLogicalReplacement builds the replacement for general && and || cases,
+// where one side was simplified without deciding the result.
+func buildLogicalReplacement(op token.Token, leftExpr, rightExpr ast.Expr) *ReplacementCondition {
+	replacement := newReplacement(&ast.BinaryExpr{X: leftExpr, Op: op, Y: rightExpr}, false, "")
+	replacement.Message = fmt.Sprintf("simplify to '%s'", replacement.NewCondition)
 
-		return &ReplacementCondition{
-			NewCondition: newCondition,
-			IsLiteral:    false,
-			Message:      fmt.Sprintf("simplify to '%s'", newCondition),
-		}
-	}
-
-	return nil
+	return replacement
 }
 
 // isLiteralExpr checks if an expression is a literal value.
-func isLiteralExpr(expr string, rep *ReplacementCondition) bool {
-	return rep != nil && rep.IsLiteral || expr == trueValue || expr == falseValue
+func isLiteralExpr(expr ast.Expr, rep *ReplacementCondition) bool {
+	return rep != nil && rep.IsLiteral || isBoolLiteral(expr, trueValue) || isBoolLiteral(expr, falseValue)
+}
+
+// isBoolLiteral checks if expr is written as the given literal, true or false,
+// possibly in parentheses.
+func isBoolLiteral(expr ast.Expr, value string) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && ident.Name == value
 }
 
 // simplifyOrExpr simplifies || expressions.
-func simplifyOrExpr(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func simplifyOrExpr(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// Check for short-circuit cases first
 	if result := checkOrShortCircuit(leftExpr, rightExpr, leftRep, rightRep); result != nil {
 		return result
@@ -403,190 +1191,526 @@ func simplifyOrExpr(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCo
 	}
 
 	// General case where simplification occurred
-	return buildOrReplacement(leftExpr, rightExpr, leftRep, rightRep)
+	return buildLogicalReplacement(token.LOR, leftExpr, rightExpr)
 }
 
 // checkOrShortCircuit checks for short-circuit cases in || expressions.
-func checkOrShortCircuit(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func checkOrShortCircuit(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// true || X -> true (short circuit)
-	if leftRep != nil && leftRep.IsLiteral && leftExpr == trueValue {
-		return &ReplacementCondition{
-			NewCondition: trueValue,
-			IsLiteral:    true,
-			Message:      "condition is always true (left side is true)",
-		}
+	if leftRep != nil && leftRep.IsLiteral && isBoolLiteral(leftExpr, trueValue) {
+		return newLiteralReplacement(trueValue, "condition is always true (left side is true)")
 	}
 	// X || true -> true (short circuit)
-	if rightRep != nil && rightRep.IsLiteral && rightExpr == trueValue {
-		return &ReplacementCondition{
-			NewCondition: trueValue,
-			IsLiteral:    true,
-			Message:      "condition is always true (right side is true)",
-		}
+	if rightRep != nil && rightRep.IsLiteral && isBoolLiteral(rightExpr, trueValue) {
+		return newLiteralReplacement(trueValue, "condition is always true (right side is true)")
 	}
 
 	return nil
 }
 
 // checkOrSimplification checks for simplification cases in || expressions.
-func checkOrSimplification(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
+func checkOrSimplification(leftExpr, rightExpr ast.Expr, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
 	// false || X -> X
-	if leftRep != nil && leftRep.IsLiteral && leftExpr == falseValue {
-		isRightLiteral := isLiteralExpr(rightExpr, rightRep)
-
-		return &ReplacementCondition{
-			NewCondition: rightExpr,
-			IsLiteral:    isRightLiteral,
-			Message:      fmt.Sprintf("simplify to '%s' (left side is always false)", rightExpr),
-		}
+	if leftRep != nil && leftRep.IsLiteral && isBoolLiteral(leftExpr, falseValue) {
+		return newReplacement(rightExpr, isLiteralExpr(rightExpr, rightRep),
+			fmt.Sprintf("simplify to '%s' (left side is always false)", renderExpr(rightExpr)))
 	}
 	// X || false -> X
-	if rightExpr == falseValue {
-		isLeftLiteral := isLiteralExpr(leftExpr, leftRep)
-
-		return &ReplacementCondition{
-			NewCondition: leftExpr,
-			IsLiteral:    isLeftLiteral,
-			Message:      fmt.Sprintf("simplify to '%s' (right side is always false)", leftExpr),
-		}
+	if isBoolLiteral(rightExpr, falseValue) {
+		return newReplacement(leftExpr, isLiteralExpr(leftExpr, leftRep),
+			fmt.Sprintf("simplify to '%s' (right side is always false)", renderExpr(leftExpr)))
 	}
 
 	return nil
 }
 
-// buildOrReplacement builds the replacement for general || cases.
-func buildOrReplacement(leftExpr, rightExpr string, leftRep, rightRep *ReplacementCondition) *ReplacementCondition {
-	if leftRep != nil || rightRep != nil {
-		newCondition := leftExpr + " || " + rightExpr
+// renderExpr renders a condition assembled from original and synthesized
+// nodes with go/format. The original positions are ignored by rendering
+// against an empty file set, so the result is on a single line with gofmt's
+// canonical operator spacing for the new nesting.
+func renderExpr(expr ast.Expr) string {
+	var buf bytes.Buffer
 
-		return &ReplacementCondition{
-			NewCondition: newCondition,
-			IsLiteral:    false,
-			Message:      fmt.Sprintf("simplify to '%s'", newCondition),
-		}
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return ""
 	}
 
-	return nil
+	return buf.String()
 }
 
 // createConditionFix creates a diagnostic with appropriate fix for if statement.
-func createConditionFix(stmt *ast.IfStmt, replacement *ReplacementCondition) *analysis.Diagnostic {
+// With annotate, the fix of a non-literal simplification also explains the
+// dropped nil check in a comment.
+func createConditionFix(
+	pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt, replacement *ReplacementCondition, annotate bool,
+) *analysis.Diagnostic {
 	if replacement.IsLiteral {
 		// Handle literal true/false cases
 		if replacement.NewCondition == trueValue {
-			return createTrueConditionFix(stmt)
+			return createTrueConditionFix(pass, file, stmt)
 		}
 
-		return createFalseConditionFix(stmt)
+		return createFalseConditionFix(pass, file, stmt)
 	}
 
 	// Handle non-literal simplifications
+	return simplifyConditionDiagnostic(pass, file, stmt.Cond, stmt.Body, replacement, annotate)
+}
+
+// simplifyConditionDiagnostic replaces cond with its non-literal
+// simplification. With annotate, a comment naming the contexts whose nil
+// checks were dropped is added after the opening brace of body.
+func simplifyConditionDiagnostic(
+	pass *analysis.Pass, file *ast.File, cond ast.Expr, body *ast.BlockStmt,
+	replacement *ReplacementCondition, annotate bool,
+) *analysis.Diagnostic {
+	edits := []analysis.TextEdit{{
+		Pos:     cond.Pos(),
+		End:     cond.End(),
+		NewText: []byte(replacement.NewCondition),
+	}}
+
+	if annotate {
+		if edit, ok := annotationEdit(pass, file, cond, body); ok {
+			edits = append(edits, edit)
+		}
+	}
+
 	return &analysis.Diagnostic{
-		Pos:     stmt.Cond.Pos(),
-		Message: replacement.Message,
+		Pos:      cond.Pos(),
+		Category: CategorySimplify,
+		Message:  replacement.Message,
 		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: fmt.Sprintf("Replace condition with '%s'", replacement.NewCondition),
-			TextEdits: []analysis.TextEdit{{
-				Pos:     stmt.Cond.Pos(),
-				End:     stmt.Cond.End(),
-				NewText: []byte(replacement.NewCondition),
-			}},
+			Message:   fmt.Sprintf("Replace condition with '%s'", replacement.NewCondition),
+			TextEdits: edits,
 		}},
 	}
 }
 
+// annotationEdit inserts a comment such as "// ctx is never nil" after the
+// opening brace of body. It reports false when the brace is not the last
+// token on its line, since a line comment there would swallow the code or
+// comment that follows.
+func annotationEdit(pass *analysis.Pass, file *ast.File, cond ast.Expr, body *ast.BlockStmt) (analysis.TextEdit, bool) {
+	names := nilCheckedContexts(pass, cond)
+	if len(names) == 0 || body == nil {
+		return analysis.TextEdit{}, false
+	}
+
+	line := pass.Fset.Position(body.Lbrace).Line
+
+	next := body.Rbrace
+	if len(body.List) > 0 {
+		next = body.List[0].Pos()
+	}
+
+	if pass.Fset.Position(next).Line == line {
+		return analysis.TextEdit{}, false
+	}
+
+	for _, cg := range file.Comments {
+		if cg.Pos() > body.Lbrace && pass.Fset.Position(cg.Pos()).Line == line {
+			return analysis.TextEdit{}, false
+		}
+	}
+
+	verb := "is"
+	if len(names) > 1 {
+		verb = "are"
+	}
+
+	comment := fmt.Sprintf(" // %s %s never nil", joinNames(names), verb)
+	pos := body.Lbrace + 1
+
+	return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(comment)}, true
+}
+
+// nilCheckedContexts returns the contexts compared to nil in cond, in order
+// of appearance and without duplicates.
+func nilCheckedContexts(pass *analysis.Pass, cond ast.Expr) []string {
+	var names []string
+
+	ast.Inspect(cond, func(n ast.Node) bool {
+		expr, ok := n.(*ast.BinaryExpr)
+		if !ok {
+			return true
+		}
+
+		ctxSide, _, _ := analyzeContextNilComparison(pass, expr)
+		if ctxSide == nil {
+			return true
+		}
+
+		if name := formatExpr(pass.Fset, ctxSide); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+
+		return false
+	})
+
+	return names
+}
+
+// joinNames joins names as an English list: "a", "a and b", or "a, b, and c".
+func joinNames(names []string) string {
+	switch len(names) {
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " and " + names[1]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + ", and " + names[len(names)-1]
+	}
+}
+
 // createTrueConditionFix handles if statements with always-true conditions.
-func createTrueConditionFix(stmt *ast.IfStmt) *analysis.Diagnostic {
+func createTrueConditionFix(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt) *analysis.Diagnostic {
+	message, category := "condition is always true", CategoryAlwaysTrue
 	if stmt.Else != nil {
-		return &analysis.Diagnostic{
-			Pos:     stmt.Pos(),
-			Message: "condition is always true, else clause is unreachable",
-			SuggestedFixes: []analysis.SuggestedFix{{
-				Message: "Replace with then clause",
-				TextEdits: []analysis.TextEdit{{
-					Pos:     stmt.Pos(),
-					End:     stmt.End(),
-					NewText: []byte(formatStmt(stmt.Body)),
-				}},
-			}},
-		}
+		message, category = "condition is always true, else clause is unreachable", CategoryDeadBranch
 	}
 
-	return &analysis.Diagnostic{
-		Pos:     stmt.Pos(),
-		Message: "condition is always true",
-		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: "Replace with then clause",
-			TextEdits: []analysis.TextEdit{{
-				Pos:     stmt.Pos(),
-				End:     stmt.End(),
-				NewText: []byte(formatStmt(stmt.Body)),
-			}},
-		}},
+	diagnostic := &analysis.Diagnostic{
+		Pos:      stmt.Pos(),
+		Category: category,
+		Message:  message,
 	}
+
+	if edit, ok := replaceIfStmtEdit(pass, file, stmt, stmt.Body); ok {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Replace with then clause",
+			TextEdits: withImportRemovals(pass, file, []analysis.TextEdit{edit}, stmt.Cond, stmt.Else),
+		}}
+	}
+
+	return diagnostic
 }
 
 // createFalseConditionFix handles if statements with always-false conditions.
-func createFalseConditionFix(stmt *ast.IfStmt) *analysis.Diagnostic {
+func createFalseConditionFix(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt) *analysis.Diagnostic {
 	if stmt.Else != nil {
-		return &analysis.Diagnostic{
-			Pos:     stmt.Pos(),
-			Message: "condition is always false, then clause is unreachable",
-			SuggestedFixes: []analysis.SuggestedFix{{
-				Message: "Replace with else clause",
-				TextEdits: []analysis.TextEdit{{
-					Pos:     stmt.Pos(),
-					End:     stmt.End(),
-					NewText: []byte(formatStmt(stmt.Else)),
-				}},
-			}},
+		diagnostic := &analysis.Diagnostic{
+			Pos:      stmt.Pos(),
+			Category: CategoryDeadBranch,
+			Message:  "condition is always false, then clause is unreachable",
+		}
+
+		if edit, ok := replaceIfStmtEdit(pass, file, stmt, stmt.Else); ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Replace with else clause",
+				TextEdits: withImportRemovals(pass, file, []analysis.TextEdit{edit}, stmt.Cond, stmt.Body),
+			}}
 		}
+
+		return diagnostic
 	}
 
-	return &analysis.Diagnostic{
+	diagnostic := &analysis.Diagnostic{
+		Pos:      stmt.Pos(),
+		Category: CategoryAlwaysFalse,
+		Message:  "condition is always false, remove entire if statement",
+	}
+
+	// Removing the init statement would drop its side effects.
+	if stmt.Init == nil {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "Remove if statement",
+			TextEdits: withImportRemovals(pass, file, []analysis.TextEdit{removeStmtEdit(pass.Fset, stmt)}, stmt),
+		}}
+	}
+
+	return diagnostic
+}
+
+// withImportRemovals appends to edits the deletion of the imports that are
+// only used within the removed nodes, which would otherwise be left unused.
+// Nil nodes, such as an absent else clause, are skipped.
+func withImportRemovals(
+	pass *analysis.Pass, file *ast.File, edits []analysis.TextEdit, removed ...ast.Node,
+) []analysis.TextEdit {
+	unused := importutil.OnlyUsedIn(pass.TypesInfo, file, removed...)
+	if len(unused) == 0 {
+		return edits
+	}
+
+	return append(edits, importutil.Edits(pass.Fset, file, nil, unused)...)
+}
+
+// replaceIfStmtEdit returns an edit replacing stmt with the given branch,
+// preceded by the init statement of stmt if any. It reports false if the
+// variables declared by the init statement are unused in branch, since
+// keeping them would not compile. The result is wrapped in a block when the
+// init statement declares a name that is visible or declared around stmt,
+// so that splicing it in neither redeclares nor shadows anything.
+func replaceIfStmtEdit(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt, branch ast.Stmt) (analysis.TextEdit, bool) {
+	if stmt.Init == nil {
+		return replaceStmtEdit(pass, file, stmt, branch), true
+	}
+
+	declared := declaredObjects(pass.TypesInfo, stmt.Init)
+	for _, obj := range declared {
+		if !usesObject(pass.TypesInfo, branch, obj) {
+			return analysis.TextEdit{}, false
+		}
+	}
+
+	indent := strings.Repeat("\t", pass.Fset.Position(stmt.Pos()).Column-1)
+
+	// The unwrapped declarations must not conflict with the names of the
+	// enclosing scopes, nor with those of an earlier if statement of the same
+	// block whose fix unwraps its init as well.
+	wrap := declaresBefore(pass.TypesInfo, file, stmt, declared)
+	if scope := pass.TypesInfo.Scopes[stmt]; scope != nil && scope.Parent() != nil {
+		for _, obj := range declared {
+			if _, outer := scope.Parent().LookupParent(obj.Name(), token.NoPos); outer != nil {
+				wrap = true
+			}
+		}
+	}
+
+	inner := indent
+	if wrap {
+		inner += "\t"
+	}
+
+	text := formatStmt(pass.Fset, file, stmt.Init, inner)
+	if block, ok := branch.(*ast.BlockStmt); !ok || len(block.List) > 0 {
+		text += "\n" + inner + formatStmt(pass.Fset, file, branch, inner)
+	}
+
+	if wrap {
+		text = "{\n" + inner + text + "\n" + indent + "}"
+	}
+
+	return analysis.TextEdit{
 		Pos:     stmt.Pos(),
-		Message: "condition is always false, remove entire if statement",
-		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: "Remove if statement",
-			TextEdits: []analysis.TextEdit{{
-				Pos:     stmt.Pos(),
-				End:     stmt.End(),
-				NewText: []byte(""),
-			}},
-		}},
+		End:     stmt.End(),
+		NewText: []byte(text),
+	}, true
+}
+
+// declaresBefore reports whether an if statement preceding stmt in its
+// statement list declares the name of one of objs in its init. Fixes may
+// unwrap both inits into the list, which would declare the name twice.
+func declaresBefore(info *types.Info, file *ast.File, stmt *ast.IfStmt, objs []types.Object) bool {
+	var list []ast.Stmt
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.BlockStmt:
+			if slices.Contains(node.List, ast.Stmt(stmt)) {
+				list = node.List
+			}
+		case *ast.CaseClause:
+			if slices.Contains(node.Body, ast.Stmt(stmt)) {
+				list = node.Body
+			}
+		case *ast.CommClause:
+			if slices.Contains(node.Body, ast.Stmt(stmt)) {
+				list = node.Body
+			}
+		}
+
+		return list == nil
+	})
+
+	for _, prev := range list {
+		if prev == stmt {
+			break
+		}
+
+		prevIf, ok := prev.(*ast.IfStmt)
+		if !ok || prevIf.Init == nil {
+			continue
+		}
+
+		for _, prevObj := range declaredObjects(info, prevIf.Init) {
+			if slices.ContainsFunc(objs, func(obj types.Object) bool { return obj.Name() == prevObj.Name() }) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// declaredObjects returns the variables declared by stmt.
+func declaredObjects(info *types.Info, stmt ast.Stmt) []types.Object {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE {
+		return nil
+	}
+
+	var objects []types.Object
+
+	for _, lhs := range assign.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok {
+			if obj := info.Defs[ident]; obj != nil {
+				objects = append(objects, obj)
+			}
+		}
 	}
+
+	return objects
 }
 
-func formatExpr(expr ast.Expr) string {
-	// Simple formatting - in practice you'd use go/format
-	switch exprType := expr.(type) {
-	case *ast.BinaryExpr:
-		return fmt.Sprintf("%s %s %s", formatExpr(exprType.X), exprType.Op.String(), formatExpr(exprType.Y))
-	case *ast.Ident:
-		return exprType.Name
-	default:
+// usesObject checks if node refers to obj.
+func usesObject(info *types.Info, node ast.Node, obj types.Object) bool {
+	found := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == obj {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// replaceStmtEdit returns an edit replacing stmt with the given branch. A
+// block branch is spliced in as its statement list; an empty block removes
+// stmt entirely.
+func replaceStmtEdit(pass *analysis.Pass, file *ast.File, stmt ast.Stmt, branch ast.Stmt) analysis.TextEdit {
+	if block, ok := branch.(*ast.BlockStmt); ok && len(block.List) == 0 {
+		return removeStmtEdit(pass.Fset, stmt)
+	}
+
+	indent := strings.Repeat("\t", pass.Fset.Position(stmt.Pos()).Column-1)
+
+	return analysis.TextEdit{
+		Pos:     stmt.Pos(),
+		End:     stmt.End(),
+		NewText: []byte(formatStmt(pass.Fset, file, branch, indent)),
+	}
+}
+
+// removeStmtEdit returns an edit deleting the full lines occupied by stmt.
+func removeStmtEdit(fset *token.FileSet, stmt ast.Stmt) analysis.TextEdit {
+	tokFile := fset.File(stmt.Pos())
+	if tokFile == nil {
+		return analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End()}
+	}
+
+	start := tokFile.LineStart(tokFile.Line(stmt.Pos()))
+	end := stmt.End()
+
+	if endLine := tokFile.Line(stmt.End()); endLine < tokFile.LineCount() {
+		end = tokFile.LineStart(endLine + 1)
+	}
+
+	return analysis.TextEdit{Pos: start, End: end}
+}
+
+// formatExpr renders expr with go/format.
+func formatExpr(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, expr); err != nil {
 		return "expr"
 	}
+
+	return buf.String()
 }
 
-func formatStmt(stmt ast.Stmt) string {
-	// Simple formatting - in practice you'd use go/format
-	switch stmt.(type) {
-	case *ast.BlockStmt:
-		return "{\n\t// statements\n}"
-	case *ast.IfStmt:
-		return "if condition { /* ... */ }"
-	default:
-		return "/* statement */"
+// formatStmt renders stmt with go/format, keeping the comments inside it
+// except those on its first line. A block is rendered as its statement list
+// so it can be spliced into the enclosing block. Every line after the first
+// is prefixed with indent.
+func formatStmt(fset *token.FileSet, file *ast.File, stmt ast.Stmt, indent string) string {
+	var comments []*ast.CommentGroup
+
+	if file != nil {
+		firstLine := fset.Position(stmt.Pos()).Line
+
+		for _, cg := range file.Comments {
+			if cg.Pos() > stmt.Pos() && cg.End() < stmt.End() && fset.Position(cg.Pos()).Line != firstLine {
+				comments = append(comments, cg)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, &printer.CommentedNode{Node: stmt, Comments: comments}); err != nil {
+		return ""
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+
+	if _, ok := stmt.(*ast.BlockStmt); ok && len(lines) >= 2 {
+		// Drop the braces and dedent the statement list.
+		lines = lines[1 : len(lines)-1]
+		for i, line := range lines {
+			lines[i] = strings.TrimPrefix(line, "\t")
+		}
 	}
+
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
 }
 
-func shouldIgnore(file *ast.File, node ast.Node, analyzerName string) bool {
+// shouldEnable checks the enable directives of the file and of the function
+// declaration containing node, which turn the analyzer on when it is disabled
+// by default.
+func shouldEnable(file *ast.File, node ast.Node, analyzerName string) bool {
 	if file == nil {
 		return false
 	}
 
-	return shouldIgnoreInFunction(file, node, analyzerName) || shouldIgnoreFromComment(file, node, analyzerName)
+	if enable := directive.FileEnable(file); enable != nil && enable.ShouldEnable(analyzerName) {
+		return true
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || node.Pos() < funcDecl.Pos() || node.End() > funcDecl.End() {
+			continue
+		}
+
+		if enable := directive.ParseEnable(funcDecl.Doc); enable != nil && enable.ShouldEnable(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
@@ -601,7 +1725,7 @@ func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string)
 		}
 
 		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
-			ignore := directive.ParseIgnore(funcDecl.Doc)
+			ignore := directive.FuncIgnore(file, funcDecl)
 			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
 				return true
 			}
@@ -611,7 +1735,7 @@ func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string)
 	return false
 }
 
-func shouldIgnoreFromComment(file *ast.File, node ast.Node, analyzerName string) bool {
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
 	if file == nil {
 		return false
 	}
@@ -619,12 +1743,14 @@ func shouldIgnoreFromComment(file *ast.File, node ast.Node, analyzerName string)
 	for _, cg := range file.Comments {
 		// Check if comment appears before the node and is reasonably close
 		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
-			ignore := directive.ParseIgnore(cg)
+			ignore := directive.ParseGodernize(cg)
 			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
 				return true
 			}
 		}
 	}
 
-	return false
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
 }
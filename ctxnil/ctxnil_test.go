@@ -1,14 +1,390 @@
 package ctxnil_test
 
 import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
 
 	"github.com/jaeyeom/godernize/ctxnil"
 )
 
 func TestAnalyzer(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, ctxnil.Analyzer, "a")
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "a", "pkgdoc", "ranges", "bodyignore")
+}
+
+func TestAutoFix(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, ctxnil.Analyzer, "autofix")
+}
+
+func TestCtxFuncsOnly(t *testing.T) {
+	setFlag(t, "ctx-funcs-only", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "ctxfuncs")
+}
+
+func TestAnnotate(t *testing.T) {
+	setFlag(t, "annotate", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, ctxnil.Analyzer, "annotate")
+}
+
+func TestDefaultDisabled(t *testing.T) {
+	setFlag(t, "default-disabled", "true")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "enable", "enablefile")
+}
+
+// TestMaxDepth checks that conditions nested beyond -max-depth are not
+// simplified as a whole, leaving only their comparisons reported.
+func TestMaxDepth(t *testing.T) {
+	setFlag(t, "max-depth", "4")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "maxdepth")
+}
+
+// TestDeeplyNestedCondition checks that a generated condition nested far
+// beyond the default -max-depth is handled without simplifying it.
+func TestDeeplyNestedCondition(t *testing.T) {
+	const depth = 50000
+
+	src := "package deep\n\nimport \"context\"\n\nfunc f(ctx context.Context) {\n\tif " +
+		strings.Repeat("(", depth) + "ctx != nil" + strings.Repeat(")", depth) + " {\n\t\tprintln(ctx.Err())\n\t}\n}\n"
+
+	var messages []string
+
+	pass := newPass(t, src)
+	pass.Report = func(diagnostic analysis.Diagnostic) {
+		messages = append(messages, diagnostic.Message)
+	}
+
+	if _, err := ctxnil.Analyzer.Run(pass); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []string{"context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"}
+	if !slices.Equal(messages, want) {
+		t.Errorf("got diagnostics %q, want %q", messages, want)
+	}
+}
+
+// TestZeroVarWithoutFixes checks that comparisons with zero context variables
+// are reported without fixes, which would leave the variables unused.
+func TestZeroVarWithoutFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "a")
+
+	reported := 0
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			position := result.Pass.Fset.Position(diagnostic.Pos)
+			if filepath.Base(position.Filename) != "zerovar.go" {
+				continue
+			}
+
+			reported++
+
+			if len(diagnostic.SuggestedFixes) > 0 {
+				t.Errorf("%s: %s: got a fix for a comparison with a zero variable", position, diagnostic.Message)
+			}
+		}
+	}
+
+	if reported == 0 {
+		t.Error("no diagnostics reported in zerovar.go")
+	}
+}
+
+// TestVendorReportOnly checks that conditions in vendored files are reported
+// without fixes, while other files keep theirs.
+func TestVendorReportOnly(t *testing.T) {
+	setFlag(t, "vendor-report-only", "true")
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "vendorfix", "vendorfix/vendor/example.com/dep")
+
+	for _, result := range results {
+		vendored := strings.Contains(result.Pass.Pkg.Path(), "/vendor/")
+
+		for _, diagnostic := range result.Diagnostics {
+			if hasFixes := len(diagnostic.SuggestedFixes) > 0; hasFixes == vendored {
+				t.Errorf("%s: %s: got fixes %v, want %v",
+					result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Message, hasFixes, !vendored)
+			}
+		}
+	}
+}
+
+func TestIgnoreKinds(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "kinds")
+}
+
+// TestStrict checks that -strict bypasses ignores by category, keeps ignores
+// of the whole analyzer, and reports every diagnostic as an error.
+func TestStrict(t *testing.T) {
+	setFlag(t, "strict", "true")
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "strict")
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if diagnostic.Category != ctxnil.CategoryError {
+				t.Errorf("%s: %s: got category %q, want %q",
+					result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Message, diagnostic.Category, ctxnil.CategoryError)
+			}
+		}
+	}
+}
+
+// TestStructFields checks that -struct-fields reports nil checks of context
+// struct fields with their own category, and leaves other contexts alone.
+func TestStructFields(t *testing.T) {
+	setFlag(t, "struct-fields", "true")
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "structfield")
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			field := strings.Contains(diagnostic.Message, "do not store contexts in structs")
+			if isStructField := diagnostic.Category == ctxnil.CategoryStructField; isStructField != field {
+				t.Errorf("%s: %s: got category %q",
+					result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Message, diagnostic.Category)
+			}
+		}
+	}
+}
+
+func TestSkipGenerated(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "generated")
+}
+
+func TestKeepGenerated(t *testing.T) {
+	setFlag(t, "skip-generated", "false")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxnil.Analyzer, "generatedkept")
+}
+
+// TestOtherFiles checks that files excluded by build constraints and non-Go
+// files of the package are not analyzed.
+func TestOtherFiles(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "otherfiles")
+
+	for _, result := range results {
+		if len(result.Pass.IgnoredFiles) == 0 || len(result.Pass.OtherFiles) == 0 {
+			t.Errorf("got ignored files %v and other files %v, want both",
+				result.Pass.IgnoredFiles, result.Pass.OtherFiles)
+		}
+	}
+}
+
+// TestCategories checks that every diagnostic of the existing scenarios
+// carries the stable category matching its message.
+func TestCategories(t *testing.T) {
+	// The first matching message fragment decides the category.
+	categories := []struct {
+		fragment string
+		category string
+	}{
+		{"clause is unreachable", ctxnil.CategoryDeadBranch},
+		{"is only compared to nil", ctxnil.CategoryUnusedParam},
+		{"assign a valid context", ctxnil.CategoryNilAssignment},
+		{"simplify to", ctxnil.CategorySimplify},
+		{"always true", ctxnil.CategoryAlwaysTrue},
+		{"with 'true'", ctxnil.CategoryAlwaysTrue},
+		{"always false", ctxnil.CategoryAlwaysFalse},
+		{"with 'false'", ctxnil.CategoryAlwaysFalse},
+	}
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, ctxnil.Analyzer, "a", "autofix")
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			want := ""
+
+			for _, c := range categories {
+				if strings.Contains(diagnostic.Message, c.fragment) {
+					want = c.category
+
+					break
+				}
+			}
+
+			if diagnostic.Category != want || want == "" {
+				t.Errorf("%s: %s: got category %q, want %q",
+					result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Message, diagnostic.Category, want)
+			}
+		}
+	}
+}
+
+func TestIsContextExpr(t *testing.T) {
+	t.Parallel()
+
+	pass := newPass(t, `package p
+
+import "context"
+
+type alias = context.Context
+
+type named interface {
+	context.Context
+	Name() string
+}
+
+var (
+	plain    context.Context
+	aliased  alias
+	embedded named
+	pointer  *context.Context
+	elements []context.Context
+	notCtx   error
+	value    = 1
+)
+`)
+
+	want := map[string]bool{"plain": true, "aliased": true, "embedded": true, "pointer": false, "elements": false,
+		"notCtx": false, "value": false}
+
+	checked := 0
+
+	for ident := range pass.TypesInfo.Defs {
+		expected, ok := want[ident.Name]
+		if !ok {
+			continue
+		}
+
+		checked++
+
+		if got := ctxnil.IsContextExpr(pass.TypesInfo, ident); got != expected {
+			t.Errorf("IsContextExpr(%s) = %v, want %v", ident.Name, got, expected)
+		}
+	}
+
+	if checked != len(want) {
+		t.Errorf("checked %d variables, want %d", checked, len(want))
+	}
+
+	if ctxnil.IsContextExpr(pass.TypesInfo, nil) {
+		t.Error("IsContextExpr(nil) = true, want false")
+	}
+}
+
+func BenchmarkCtxFuncsOnly(b *testing.B) {
+	pass := newBenchmarkPass(b, 1000, 5)
+
+	for _, ctxFuncsOnly := range []string{"false", "true"} {
+		b.Run("ctx-funcs-only="+ctxFuncsOnly, func(b *testing.B) {
+			var visits int
+			analyzer := ctxnil.NewCountingAnalyzer(&visits)
+			if err := analyzer.Flags.Set("ctx-funcs-only", ctxFuncsOnly); err != nil {
+				b.Fatal(err)
+			}
+
+			for range b.N {
+				if _, err := analyzer.Run(pass); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(float64(visits)/float64(b.N), "nodes/op")
+		})
+	}
+}
+
+func setFlag(tb testing.TB, name, value string) {
+	tb.Helper()
+
+	old := ctxnil.Analyzer.Flags.Lookup(name).Value.String()
+	if err := ctxnil.Analyzer.Flags.Set(name, value); err != nil {
+		tb.Fatalf("Failed to set flag %s: %v", name, err)
+	}
+
+	tb.Cleanup(func() {
+		if err := ctxnil.Analyzer.Flags.Set(name, old); err != nil {
+			tb.Errorf("Failed to restore flag %s: %v", name, err)
+		}
+	})
+}
+
+// newBenchmarkPass type-checks a synthetic package with plainFuncs functions
+// without a context parameter and ctxFuncs functions with one.
+func newBenchmarkPass(tb testing.TB, plainFuncs, ctxFuncs int) *analysis.Pass {
+	tb.Helper()
+
+	var src strings.Builder
+
+	src.WriteString("package bench\n\nimport \"context\"\n\n")
+
+	for i := range plainFuncs {
+		fmt.Fprintf(&src, "func plain%d(a, b int) bool {\n\tif a > b && b > 0 {\n\t\treturn a == b\n\t}\n\n"+
+			"\treturn a+b > 0 || a-b < 0\n}\n\n", i)
+	}
+
+	for i := range ctxFuncs {
+		fmt.Fprintf(&src, "func withCtx%d(ctx context.Context) bool {\n\treturn ctx != nil\n}\n\n", i)
+	}
+
+	return newPass(tb, src.String())
+}
+
+// newPass type-checks the source of a single file package for the analyzer.
+// Diagnostics are discarded unless Report is replaced.
+func newPass(tb testing.TB, src string) *analysis.Pass {
+	tb.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "src.go", src, parser.ParseComments)
+	if err != nil {
+		tb.Fatalf("Failed to parse: %v", err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	if err != nil {
+		tb.Fatalf("Failed to type-check: %v", err)
+	}
+
+	files := []*ast.File{file}
+
+	return &analysis.Pass{
+		Analyzer:  ctxnil.Analyzer,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       pkg,
+		TypesInfo: info,
+		ResultOf:  map[*analysis.Analyzer]any{inspect.Analyzer: inspector.New(files)},
+		Report:    func(analysis.Diagnostic) {},
+	}
 }
@@ -0,0 +1,9 @@
+package ctxnil
+
+import "golang.org/x/tools/go/analysis"
+
+// NewCountingAnalyzer returns a separate instance of the analyzer that adds
+// the number of nodes it visits to visits.
+func NewCountingAnalyzer(visits *int) *analysis.Analyzer {
+	return newAnalyzer(&runner{visits: visits})
+}
@@ -0,0 +1,63 @@
+package a
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// holder stores a context that is nil until set is called.
+type holder struct {
+	current atomic.Value
+}
+
+func (h *holder) set(ctx context.Context) {
+	h.current.Store(ctx)
+}
+
+func (h *holder) loaded() context.Context {
+	ctx, _ := h.current.Load().(context.Context)
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
+}
+
+func (h *holder) loadedChecked() bool {
+	ctx, ok := h.current.Load().(context.Context)
+
+	return ok && ctx != nil
+}
+
+func (h *holder) loadedVar() context.Context {
+	var ctx = h.current.Load().(context.Context)
+	if ctx != nil {
+		return ctx
+	}
+
+	return context.TODO()
+}
+
+func (h *holder) reloaded(ctx context.Context) context.Context {
+	ctx = h.current.Load().(context.Context)
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
+}
+
+func loadedDirectly(v *atomic.Value) bool {
+	return v.Load().(context.Context) != nil
+}
+
+func (h *holder) notLoaded(ctx context.Context) {
+	h.set(ctx)
+
+	loaded := h.loaded()
+	if loaded == nil { // want "condition is always false"
+		return
+	}
+
+	_ = ctx != nil // want "context parameter 'ctx' is never nil"
+}
@@ -0,0 +1,40 @@
+package a
+
+import "context"
+
+func contextSlice(ctxs []context.Context, i int) {
+	if ctxs[i] == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	_ = ctxs[i+1] != nil // want `context should never be nil, replace 'ctxs\[i\+1\] != nil' with 'true'`
+
+	useContext(ctxs[i])
+}
+
+func contextMap(byName map[string]context.Context, name string) {
+	if byName[name] != nil { // want "condition is always true"
+		useContext(byName[name])
+	}
+
+	_ = byName["root"] == nil // want `context should never be nil, replace 'byName\["root"\] == nil' with 'false'`
+}
+
+func contextArray(ctxs [2]context.Context) bool {
+	return ctxs[0] != nil && ctxs[1] != nil // want `replace 'ctxs\[0\] != nil' with 'true'` `replace 'ctxs\[1\] != nil' with 'true'`
+}
+
+func contextPointer(pctx *context.Context) {
+	// A pointer to a context can legitimately be nil.
+	if pctx == nil {
+		return
+	}
+
+	_ = pctx != nil
+
+	if *pctx == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	useContext(*pctx)
+}
@@ -0,0 +1,36 @@
+package a
+
+import "context"
+
+type (
+	aliasContext    = context.Context
+	embeddedContext interface {
+		context.Context
+		RequestID() string
+	}
+	contextHolder struct{ context.Context }
+)
+
+func aliasedNilCheck(ctx aliasContext) error {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return nil
+	}
+
+	return ctx.Err()
+}
+
+func embeddedNilCheck(ctx embeddedContext) string {
+	if ctx != nil { // want "condition is always true"
+		return ctx.RequestID()
+	}
+
+	return ""
+}
+
+func holderNilCheck(holder *contextHolder) error {
+	if holder == nil {
+		return nil
+	}
+
+	return holder.Err()
+}
@@ -0,0 +1,31 @@
+package a
+
+import "context"
+
+func check(b bool) bool { return b }
+
+// Each comparison is reported once: either on its own or as part of the if
+// or for condition containing it, whose fix rewrites the whole condition.
+func contradictoryConditions(ctx context.Context, ready bool) bool {
+	useContext(ctx)
+
+	if (ctx == nil) && (ctx != nil) { // want "condition is always false, remove entire if statement"
+		return true
+	}
+
+	if check(ctx == nil) && ctx != nil { // want `simplify to 'check\(ctx == nil\)' \(right side is always true\)`
+		return true
+	}
+
+	for check(ctx != nil) && ctx != nil && ready { // want `simplify to 'check\(ctx != nil\) && ready'`
+		ready = false
+	}
+
+	if func() bool { return ctx == nil }() && ctx != nil { // want `simplify to 'func\(\) bool`
+		return true
+	}
+
+	both := (ctx == nil) && (ctx != nil) // want "replace 'ctx == nil' with 'false'" "replace 'ctx != nil' with 'true'"
+
+	return both || (ctx == nil) || (ctx != nil) // want "replace 'ctx == nil' with 'false'" "replace 'ctx != nil' with 'true'"
+}
@@ -0,0 +1,51 @@
+package a
+
+import (
+	"context"
+	"time"
+)
+
+type worker struct {
+	ctx context.Context
+}
+
+// Both the loop condition and the nil assignment in the post statement are
+// reported.
+func retryUntilCleared(ctx context.Context) {
+	for attempt := 0; ctx != nil; ctx = nil { // want "loop condition is always true" "context should never be nil, assign a valid context such as context.Background\\(\\) to 'ctx'"
+		useContext(ctx)
+
+		attempt++
+	}
+}
+
+func resetInInit(ctx context.Context) {
+	for ctx = nil; ctx == nil; { // want "context should never be nil, assign a valid context such as context.Background\\(\\) to 'ctx'" "loop condition is always false, loop body never runs"
+		return
+	}
+}
+
+func loopWithDeadline(ctx context.Context, deadline time.Time) {
+	for ctx != nil && time.Now().Before(deadline) { // want "simplify to 'time.Now\\(\\).Before\\(deadline\\)' \\(left side is always true\\)"
+		useContext(ctx)
+	}
+}
+
+func clearField(w *worker, n int) {
+	for i := 0; i < n; i, w.ctx = i+1, nil { // want "context should never be nil, assign a valid context such as context.Background\\(\\) to 'w.ctx'"
+		useContext(w.ctx)
+	}
+}
+
+func unrelatedLoop(err error) {
+	for err != nil {
+		err = nil
+	}
+}
+
+func ignoredLoop(ctx context.Context) {
+	//godernize:ignore=ctxnil
+	for ; ctx != nil; ctx = nil {
+		useContext(ctx)
+	}
+}
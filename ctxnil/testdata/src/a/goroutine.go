@@ -0,0 +1,39 @@
+package a
+
+import "context"
+
+func goroutineCapture(ctx context.Context, done chan<- struct{}) {
+	go func() {
+		defer close(done)
+
+		if ctx == nil { // want "condition is always false, remove entire if statement"
+			return
+		}
+
+		useContext(ctx)
+	}()
+}
+
+func goroutineParam(ctx context.Context) {
+	go func(inner context.Context) {
+		if inner == nil || ctx == nil { // want "condition is always false, remove entire if statement"
+			return
+		}
+
+		useContext(inner)
+	}(ctx)
+}
+
+func goroutineShadowed(ctx context.Context, values []*int) {
+	for _, ctx := range values {
+		go func() {
+			if ctx == nil {
+				return
+			}
+
+			*ctx++
+		}()
+	}
+
+	useContext(ctx)
+}
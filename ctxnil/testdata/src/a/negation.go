@@ -0,0 +1,39 @@
+package a
+
+import "context"
+
+func negatedComparisons(ctx context.Context, ready bool) {
+	useContext(ctx)
+
+	if !(ctx == nil) { // want "condition is always true"
+		doSomething()
+	}
+
+	if !!(ctx != nil) { // want "condition is always true"
+		doSomething()
+	}
+
+	if !!(ctx == nil) { // want "condition is always false, remove entire if statement"
+		doSomething()
+	}
+
+	if !!!(ctx != nil) { // want "condition is always false, remove entire if statement"
+		doSomething()
+	}
+
+	if !!!(ctx == nil) { // want "condition is always true"
+		doSomething()
+	}
+
+	if !(ctx != nil && ready) { // want `simplify to '!ready'`
+		doSomething()
+	}
+
+	if !!(ctx != nil && !ready) { // want `simplify to '!ready'`
+		doSomething()
+	}
+
+	if !(ctx == nil || ready || ready) { // want `simplify to '!\(ready \|\| ready\)'`
+		doSomething()
+	}
+}
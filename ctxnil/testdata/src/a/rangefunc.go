@@ -0,0 +1,54 @@
+//go:build go1.23
+
+package a
+
+import (
+	"context"
+	"iter"
+)
+
+// contexts yields contexts through a range-over-func iterator.
+func contexts(parent context.Context) iter.Seq[context.Context] {
+	return func(yield func(context.Context) bool) {
+		yield(parent)
+	}
+}
+
+func indexedContexts(parent context.Context) iter.Seq2[int, context.Context] {
+	return func(yield func(int, context.Context) bool) {
+		yield(0, parent)
+	}
+}
+
+func rangeOverFunc(parent context.Context) {
+	for ctx := range contexts(parent) {
+		if ctx == nil { // want "condition is always false, remove entire if statement"
+			continue
+		}
+
+		useContext(ctx)
+	}
+
+	for i, ctx := range indexedContexts(parent) {
+		if ctx != nil && i > 0 { // want "simplify to 'i > 0' \\(left side is always true\\)"
+			useContext(ctx)
+		}
+	}
+
+	// A plain function literal works as an iterator too.
+	for ctx := range func(yield func(context.Context) bool) {
+		if parent != nil { // want "condition is always true"
+			yield(parent)
+		}
+	} {
+		_ = ctx == nil // want "context should never be nil, replace 'ctx == nil' with 'false'"
+	}
+}
+
+func rangeOverFuncWithoutValue(parent context.Context) {
+	for range contexts(parent) {
+		if parent == nil { // want "condition is always false, remove entire if statement"
+			return
+		}
+	}
+}
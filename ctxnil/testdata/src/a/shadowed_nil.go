@@ -0,0 +1,26 @@
+package a
+
+import "context"
+
+func shadowedNil(ctx context.Context) bool {
+	nil := context.Background()
+
+	return ctx == nil || nil != ctx
+}
+
+func shadowedNilParam(ctx, nil context.Context) {
+	if ctx != nil {
+		useContext(ctx)
+	}
+}
+
+func predeclaredNil(ctx context.Context) bool {
+	useContext(ctx)
+
+	{
+		nil := 0
+		_ = nil
+	}
+
+	return ctx == nil // want "context parameter 'ctx' is never nil, replace 'ctx == nil' with 'false'"
+}
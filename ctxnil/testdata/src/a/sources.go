@@ -0,0 +1,25 @@
+package a
+
+import "context"
+
+type server struct {
+	ctx context.Context
+}
+
+func newContext() context.Context {
+	return context.Background()
+}
+
+func (s *server) contextSources(ctx context.Context) {
+	_ = ctx != nil          // want `context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'`
+	_ = s.ctx == nil        // want `context field 's.ctx' is never nil, replace 's.ctx == nil' with 'false'`
+	_ = newContext() != nil // want `context returned by 'newContext\(\)' is never nil, replace 'newContext\(\) != nil' with 'true'`
+
+	local := newContext()
+	_ = local == nil // want `context should never be nil, replace 'local == nil' with 'false'`
+
+	func(inner context.Context) {
+		_ = inner == nil // want `context parameter 'inner' is never nil, replace 'inner == nil' with 'false'`
+		useContext(inner)
+	}(ctx)
+}
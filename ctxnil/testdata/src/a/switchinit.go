@@ -0,0 +1,23 @@
+package a
+
+import "context"
+
+func switchOnComparison(ctx context.Context) {
+	useContext(ctx)
+
+	switch c := ctx != nil; c { // want `context should never be nil, so switch variable 'c' is always true; replace 'ctx != nil' with 'true'`
+	case true:
+		doSomething()
+	}
+
+	switch missing := (ctx == nil); { // want `switch variable 'missing' is always false; replace 'ctx == nil' with 'false'`
+	case missing:
+		doSomething()
+	}
+
+	// Other init statements are checked like any other expression.
+	switch ok := ctx != nil && true; ok { // want `replace 'ctx != nil' with 'true'`
+	case true:
+		doSomething()
+	}
+}
@@ -0,0 +1,51 @@
+package a
+
+import "context"
+
+var packageZero context.Context
+
+func setContext(ctx *context.Context) {
+	*ctx = context.Background()
+}
+
+func zeroVar(ctx context.Context) {
+	var zero context.Context
+
+	if ctx == zero { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	_ = zero != ctx // want `context parameter 'ctx' is never nil, replace 'zero != ctx' with 'true'`
+
+	func() {
+		_ = ctx == zero // want `context parameter 'ctx' is never nil, replace 'ctx == zero' with 'false'`
+	}()
+
+	useContext(ctx)
+}
+
+func notZeroVar(ctx context.Context) {
+	var assigned context.Context
+	assigned = context.Background()
+	_ = ctx == assigned
+
+	var assignedLater context.Context
+	_ = ctx == assignedLater
+	assignedLater = ctx
+
+	var addressed context.Context
+	setContext(&addressed)
+	_ = ctx == addressed
+
+	var initialized context.Context = context.TODO()
+	_ = ctx == initialized
+
+	var ranged context.Context
+	for _, ranged = range []context.Context{ctx} {
+	}
+	_ = ctx == ranged
+
+	_ = ctx == packageZero
+
+	useContext(ctx)
+}
@@ -0,0 +1,40 @@
+package annotate
+
+import "context"
+
+func ready() bool { return true }
+
+func use(ctx context.Context) {}
+
+func simplified(ctx context.Context) {
+	if ctx != nil && // want "simplify to 'ready\\(\\)' \\(left side is always true\\)"
+		ready() {
+		use(ctx)
+	}
+}
+
+func twoContexts(ctx, parent context.Context) {
+	if ctx != nil && ready() && // want "simplify to 'ready\\(\\)' \\(right side is always true\\)"
+		parent != nil {
+		use(ctx)
+		use(parent)
+	}
+}
+
+func loop(ctx context.Context) {
+	for ctx != nil && // want "simplify to 'ready\\(\\)' \\(left side is always true\\)"
+		ready() {
+		use(ctx)
+	}
+}
+
+func trailingComment(ctx context.Context) {
+	if ctx != nil && ready() { // want "simplify to 'ready\\(\\)' \\(left side is always true\\)"
+		use(ctx)
+	}
+}
+
+func oneLine(ctx context.Context) {
+	if ctx != nil && // want "simplify to 'ready\\(\\)' \\(left side is always true\\)"
+		ready() { use(ctx) }
+}
@@ -0,0 +1,38 @@
+package autofix
+
+import (
+	"context"
+	"fmt"
+)
+
+func alwaysFalse(ctx context.Context) {
+	fmt.Println("start")
+
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		panic("nil context")
+	}
+
+	use(ctx)
+}
+
+func alwaysFalseWithElse(ctx context.Context) {
+	if ctx == nil { // want "condition is always false, then clause is unreachable"
+		panic("nil context")
+	} else {
+		// keep this comment
+		use(ctx)
+		fmt.Println("done")
+	}
+}
+
+func alwaysFalseWithElseIf(ctx context.Context, ready bool) {
+	if nil == ctx { // want "condition is always false, then clause is unreachable"
+		return
+	} else if ready {
+		use(ctx)
+	}
+}
+
+func use(ctx context.Context) {
+	_ = ctx.Err()
+}
@@ -0,0 +1,32 @@
+package autofix
+
+import (
+	"context"
+	"fmt"
+)
+
+func alwaysTrue(ctx context.Context) {
+	if ctx != nil { // want "condition is always true"
+		use(ctx)
+
+		fmt.Println("ok")
+	}
+}
+
+func alwaysTrueWithElse(ctx context.Context) {
+	if ctx != nil { // want "condition is always true, else clause is unreachable"
+		for i := range 3 {
+			fmt.Println(i)
+		}
+	} else {
+		panic("nil context")
+	}
+
+	use(ctx)
+}
+
+func alwaysTrueLiteral(ctx context.Context) {
+	if ctx != nil && true { // want "condition is always true"
+		use(ctx)
+	}
+}
@@ -0,0 +1,29 @@
+package autofix
+
+import (
+	"context"
+	"fmt"
+)
+
+func alwaysTrueWithElseIf(ctx context.Context, ready bool) {
+	if ctx != nil { // want "condition is always true, else clause is unreachable"
+		use(ctx)
+		fmt.Println("done")
+	} else if ready {
+		fmt.Println("ready")
+	} else {
+		panic("nil context")
+	}
+}
+
+func alwaysTrueWithElseIfChain(ctx context.Context, n int) {
+	if ctx != nil { // want "condition is always true, else clause is unreachable"
+		use(ctx)
+	} else if n > 1 {
+		fmt.Println("many")
+	} else if ctx == nil {
+		panic("nil context")
+	} else if n == 1 {
+		fmt.Println("one")
+	}
+}
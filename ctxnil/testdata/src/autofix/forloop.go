@@ -0,0 +1,29 @@
+package autofix
+
+import "context"
+
+func forever(ctx context.Context, work func() bool) {
+	for ctx != nil { // want "loop condition is always true"
+		use(ctx)
+
+		if !work() {
+			return
+		}
+	}
+}
+
+func foreverCounting(ctx context.Context, work func(int) bool) {
+	for i := 0; ctx != nil; i++ { // want "loop condition is always true"
+		use(ctx)
+
+		if !work(i) {
+			return
+		}
+	}
+}
+
+func simplifiedLoop(ctx context.Context, work func() bool) {
+	for ctx != nil && work() { // want "simplify to 'work\\(\\)' \\(left side is always true\\)"
+		use(ctx)
+	}
+}
@@ -0,0 +1,28 @@
+package autofix
+
+import (
+	"context"
+	"sync"
+)
+
+func goroutineCapture(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		if ctx == nil { // want "condition is always false, remove entire if statement"
+			return
+		}
+
+		use(ctx)
+	}()
+}
+
+func goroutineCaptureGuard(ctx context.Context) {
+	go func() {
+		if ctx != nil { // want "condition is always true"
+			use(ctx)
+		}
+	}()
+}
@@ -0,0 +1,77 @@
+package autofix
+
+import (
+	"context"
+	"fmt"
+)
+
+func compute() int { return 1 }
+
+func check() error { return nil }
+
+func initAlwaysTrue(ctx context.Context) {
+	if v := compute(); ctx != nil { // want "condition is always true"
+		fmt.Println(v)
+	}
+
+	use(ctx)
+}
+
+func initShadowing(ctx context.Context, v int) int {
+	if v := compute(); ctx != nil { // want "condition is always true"
+		fmt.Println(v)
+	}
+
+	use(ctx)
+
+	return v
+}
+
+func initElse(ctx context.Context) {
+	if err := check(); ctx == nil { // want "condition is always false, then clause is unreachable"
+		return
+	} else if err != nil {
+		fmt.Println(err)
+	}
+
+	use(ctx)
+}
+
+func initSideEffect(ctx context.Context) {
+	if fmt.Println("checking"); ctx != nil { // want "condition is always true"
+	}
+
+	use(ctx)
+}
+
+func initUnusedInBranch(ctx context.Context) {
+	if v := compute(); ctx != nil { // want "condition is always true, else clause is unreachable"
+		fmt.Println("ready")
+	} else {
+		fmt.Println(v)
+	}
+
+	use(ctx)
+}
+
+func initRemoved(ctx context.Context) {
+	if v := compute(); ctx == nil { // want "condition is always false, remove entire if statement"
+		fmt.Println(v)
+	}
+
+	use(ctx)
+}
+
+func initSiblings(ctx context.Context) {
+	if v := compute(); ctx != nil { // want "condition is always true"
+		fmt.Println(v)
+	}
+
+	if v := compute(); ctx == nil { // want "condition is always false, then clause is unreachable"
+		return
+	} else {
+		fmt.Println(v)
+	}
+
+	use(ctx)
+}
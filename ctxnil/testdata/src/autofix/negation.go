@@ -0,0 +1,17 @@
+package autofix
+
+import "context"
+
+func negation(ctx context.Context, ready bool) {
+	if !!(ctx != nil) { // want "condition is always true"
+		use(ctx)
+	}
+
+	if !!!(ctx != nil) { // want "condition is always false, remove entire if statement"
+		use(ctx)
+	}
+
+	if !(ctx != nil && ready) { // want `simplify to '!ready'`
+		use(ctx)
+	}
+}
@@ -0,0 +1,23 @@
+package autofix
+
+import "context"
+
+func nestedArithmetic(ctx context.Context, n, limit int, ready bool) {
+	if ctx != nil && n*2 > limit && ready { // want "simplify to 'n\\*2 > limit && ready'"
+		use(ctx)
+	}
+}
+
+func nestedParens(ctx context.Context, n int, ready, done bool) {
+	if (ctx == nil || ready) && (done || ctx != nil && n > 0) { // want "simplify to 'ready && \\(done \\|\\| n > 0\\)'"
+		use(ctx)
+	}
+}
+
+func multiLine(ctx context.Context, ready, done bool) {
+	if ctx != nil && // want "simplify to 'ready && done'"
+		ready &&
+		done {
+		use(ctx)
+	}
+}
@@ -0,0 +1,17 @@
+package autofix
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+func orphanedByThenClause(ctx context.Context, name string) error {
+	if ctx != nil { // want "condition is always true, else clause is unreachable"
+		use(ctx)
+	} else {
+		return errors.New(strings.ToUpper(name))
+	}
+
+	return nil
+}
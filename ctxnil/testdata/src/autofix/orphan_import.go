@@ -0,0 +1,23 @@
+package autofix
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+func orphanedImport(ctx context.Context) {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		log.Fatal("nil context")
+	}
+
+	fmt.Println(ctx.Err())
+}
+
+func keptImport(ctx context.Context) {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		fmt.Println("nil context")
+	}
+
+	use(ctx)
+}
@@ -0,0 +1,37 @@
+package autofix
+
+import "context"
+
+func parenAlwaysFalse(ctx context.Context) {
+	if (ctx == nil) { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	use(ctx)
+}
+
+func doubleParenAlwaysTrue(ctx context.Context) {
+	if ((ctx != nil)) { // want "condition is always true"
+		use(ctx)
+	}
+}
+
+func parenSimplify(ctx context.Context, ready bool) {
+	if (ctx != nil) && ready { // want "simplify to 'ready' \\(left side is always true\\)"
+		use(ctx)
+	}
+}
+
+func parenLiteralFalse(ctx context.Context) {
+	if ctx == nil || (false) { // want "condition is always false, remove entire if statement"
+		panic("nil context")
+	}
+
+	use(ctx)
+}
+
+func parenLiteralTrue(ctx context.Context) {
+	if (ctx != nil) && (true) { // want "condition is always true"
+		use(ctx)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build go1.23
+
+package autofix
+
+import (
+	"context"
+	"iter"
+)
+
+func rangeOverFunc(seq iter.Seq2[int, context.Context]) {
+	for i, ctx := range seq {
+		if ctx == nil { // want "condition is always false, remove entire if statement"
+			continue
+		}
+
+		if ctx != nil && i > 0 { // want "simplify to 'i > 0' \\(left side is always true\\)"
+			use(ctx)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package autofix
+
+import "context"
+
+func simplify(ctx context.Context, ready, done bool) bool {
+	if ctx != nil && ready { // want "simplify to 'ready' \\(left side is always true\\)"
+		use(ctx)
+	}
+
+	if done || ctx == nil { // want "simplify to 'done' \\(right side is always false\\)"
+		return false
+	}
+
+	result := ctx == nil // want "context parameter 'ctx' is never nil, replace 'ctx == nil' with 'false'"
+
+	return result || ctx != nil // want "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"
+}
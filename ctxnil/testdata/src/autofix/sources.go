@@ -0,0 +1,30 @@
+package autofix
+
+import "context"
+
+type holder struct {
+	ctx context.Context
+}
+
+func getCtx() context.Context {
+	return context.Background()
+}
+
+func fromCall() {
+	ok := getCtx() != nil // want `context returned by 'getCtx\(\)' is never nil, replace 'getCtx\(\) != nil' with 'true'`
+
+	if getCtx() == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	_ = ok
+}
+
+func (h *holder) fromField() {
+	if h.ctx != nil { // want "condition is always true"
+		use(h.ctx)
+	}
+
+	missing := h.ctx == nil // want `context field 'h.ctx' is never nil, replace 'h.ctx == nil' with 'false'`
+	_ = missing
+}
@@ -0,0 +1,10 @@
+package autofix
+
+import "context"
+
+func switchInit(ctx context.Context) {
+	switch c := ctx != nil; c { // want `switch variable 'c' is always true`
+	case true:
+		use(ctx)
+	}
+}
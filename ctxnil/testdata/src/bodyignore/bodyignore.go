@@ -0,0 +1,54 @@
+package bodyignore
+
+import (
+	"context"
+	"fmt"
+)
+
+// wholeFunction is ignored from its first statement to its last by the
+// directive leading its body.
+func wholeFunction(ctx context.Context) {
+	//godernize:ignore=ctxnil // legacy callers pass nil
+	if ctx == nil {
+		return
+	}
+
+	fmt.Println("step one of a long function that keeps going for a while")
+	fmt.Println("step two of a long function that keeps going for a while")
+	fmt.Println("step three of a long function that keeps going for a while")
+	fmt.Println("step four of a long function that keeps going for a while")
+
+	_ = ctx != nil
+}
+
+// statementOnly is ignored only at the statement following the directive,
+// which is not the first of its body.
+func statementOnly(ctx context.Context) {
+	fmt.Println("start")
+
+	//godernize:ignore=ctxnil
+	if ctx == nil {
+		return
+	}
+
+	fmt.Println("step one of a long function that keeps going for a while")
+	fmt.Println("step two of a long function that keeps going for a while")
+	fmt.Println("step three of a long function that keeps going for a while")
+	fmt.Println("step four of a long function that keeps going for a while")
+
+	_ = ctx != nil // want "context parameter 'ctx' is never nil"
+
+	fmt.Println(ctx.Err())
+}
+
+// otherAnalyzer leads its body with a directive for another analyzer.
+func otherAnalyzer(ctx context.Context) {
+	//godernize:ignore=oserrors
+	fmt.Println("start")
+
+	if ctx == nil { // want "condition is always false"
+		return
+	}
+
+	fmt.Println(ctx.Err())
+}
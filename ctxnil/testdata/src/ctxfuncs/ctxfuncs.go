@@ -0,0 +1,26 @@
+package ctxfuncs
+
+import "context"
+
+func withContextParam(ctx context.Context) { // want "context parameter 'ctx' is only compared to nil and is otherwise unused"
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	check := func() bool {
+		return ctx != nil // want "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"
+	}
+	_ = check
+}
+
+func withContextAmongParams(name string, ctx context.Context) bool { // want "context parameter 'ctx' is only compared to nil and is otherwise unused"
+	return ctx == nil && name == "" // want "context parameter 'ctx' is never nil, replace 'ctx == nil' with 'false'"
+}
+
+// withoutContextParam is skipped entirely when -ctx-funcs-only is set.
+func withoutContextParam() {
+	ctx := context.Background()
+	if ctx == nil {
+		return
+	}
+}
@@ -0,0 +1,42 @@
+package enable
+
+import "context"
+
+func use(ctx context.Context) {}
+
+//godernize:enable=ctxnil
+func enabled(ctx context.Context) {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	use(ctx)
+}
+
+//godernize:enable // migrated to non-nil contexts
+func enabledForAll(ctx context.Context) {
+	if ctx != nil { // want "condition is always true"
+		use(ctx)
+	}
+}
+
+//godernize:enable=oserrors
+func enabledForOther(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	use(ctx)
+}
+
+func notEnabled(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	use(ctx)
+
+	func() {
+		_ = ctx != nil
+	}()
+}
@@ -0,0 +1,15 @@
+//godernize:enable=ctxnil
+
+package enablefile
+
+import "context"
+
+func use(ctx context.Context) {}
+
+func check(ctx context.Context) {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return
+	}
+
+	use(ctx)
+}
@@ -0,0 +1,9 @@
+package generated
+
+import "context"
+
+func run(ctx context.Context) {
+	if ctx != nil { // want "condition is always true"
+		println(ctx.Err())
+	}
+}
@@ -0,0 +1,39 @@
+package kinds
+
+import "context"
+
+// Ignoring a kind of diagnostic by its category keeps the other kinds.
+//
+//godernize:ignore=ctxnil.unused-param
+func unusedParam(ctx context.Context) {
+	if ctx != nil { // want "condition is always true"
+		println("ready")
+	}
+}
+
+func simplify(ctx context.Context, ready bool) {
+	//godernize:ignore=ctxnil.simplify
+	if ctx != nil && ready {
+		println(ctx.Err())
+	}
+}
+
+func comparison(ctx context.Context) bool {
+	println(ctx)
+
+	//nolint:ctxnil.always-false // checked by the caller
+	return ctx == nil
+}
+
+func nilAssignment(ctx context.Context) {
+	//godernize:ignore=ctxnil.always-true
+	ctx = nil // want "context should never be nil, assign a valid context such as context.Background\\(\\) to 'ctx'"
+	println(ctx)
+}
+
+//godernize:ignore=ctxnil
+func ignored(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+}
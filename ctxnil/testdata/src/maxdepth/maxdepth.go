@@ -0,0 +1,23 @@
+package maxdepth
+
+import "context"
+
+func shallow(ctx context.Context) {
+	if ((ctx != nil)) { // want "condition is always true"
+		println(ctx.Err())
+	}
+}
+
+// The condition is too deep to simplify as a whole, so only the comparison
+// itself is reported.
+func deep(ctx context.Context) {
+	if ((((((ctx != nil)))))) { // want "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"
+		println(ctx.Err())
+	}
+}
+
+func deepOperands(ctx context.Context, ready bool) {
+	if ready && (ready && (ready && (ready && ctx != nil))) { // want "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"
+		println(ctx.Err())
+	}
+}
@@ -0,0 +1,11 @@
+//go:build ignore
+
+package otherfiles
+
+import "context"
+
+func ignored(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+}
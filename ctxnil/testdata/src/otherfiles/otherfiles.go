@@ -0,0 +1,12 @@
+// Package otherfiles has files that are not analyzed: a file excluded by a
+// build constraint, which is in pass.IgnoredFiles, and an assembly file,
+// which is in pass.OtherFiles.
+package otherfiles
+
+import "context"
+
+func run(ctx context.Context) {
+	if ctx != nil { // want "condition is always true"
+		println(ctx.Err())
+	}
+}
@@ -0,0 +1 @@
+// ctx == nil is not Go code.
@@ -0,0 +1,14 @@
+// Package pkgdoc checks that the package doc comment can disable ctxnil.
+//
+//godernize:ignore=ctxnil
+package pkgdoc
+
+import "context"
+
+func ignoredEverywhere(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,12 @@
+package pkgdoc
+
+import "context"
+
+// The directive in the package doc of ignored.go does not apply to this file.
+func reported(ctx context.Context) error {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return nil
+	}
+
+	return ctx.Err()
+}
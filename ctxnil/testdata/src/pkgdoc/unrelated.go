@@ -0,0 +1,12 @@
+//godernize:ignore=oserrors
+package pkgdoc
+
+import "context"
+
+func otherAnalyzer(ctx context.Context) error {
+	if ctx == nil { // want "condition is always false, remove entire if statement"
+		return nil
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,35 @@
+package ranges
+
+import "context"
+
+func nested(ctx context.Context) []bool {
+	//godernize:ignore-begin=ctxnil
+	first := ctx == nil
+	//godernize:ignore-begin
+	second := ctx != nil
+	//godernize:ignore-end=ctxnil
+	third := ctx == nil
+	//godernize:ignore-end
+	fourth := ctx != nil // want "context parameter 'ctx' is never nil, replace 'ctx != nil' with 'true'"
+
+	useContext(ctx)
+
+	return []bool{first, second, third, fourth}
+}
+
+func unclosed(ctx context.Context) bool {
+	useContext(ctx)
+
+	//godernize:ignore-begin=ctxnil,oserrors
+	return ctx == nil
+}
+
+func afterUnclosed(ctx context.Context) bool {
+	useContext(ctx)
+
+	return ctx != nil
+}
+
+func useContext(ctx context.Context) {
+	_ = ctx.Err()
+}
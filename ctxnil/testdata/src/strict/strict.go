@@ -0,0 +1,41 @@
+package strict
+
+import "context"
+
+// With -strict, ignoring a kind of diagnostic by its category has no effect.
+//
+//godernize:ignore=ctxnil.unused-param
+func unusedParam(ctx context.Context) { // want "context parameter 'ctx' is only compared to nil"
+	if ctx != nil { // want "condition is always true"
+		println("ready")
+	}
+}
+
+func simplify(ctx context.Context, ready bool) {
+	//godernize:ignore=ctxnil.simplify
+	if ctx != nil && ready { // want "simplify to 'ready'"
+		println(ctx.Err())
+	}
+}
+
+func comparison(ctx context.Context) bool {
+	println(ctx)
+
+	//nolint:ctxnil.always-false // checked by the caller
+	return ctx == nil // want "context parameter 'ctx' is never nil, replace 'ctx == nil' with 'false'"
+}
+
+func nilAssignment(ctx context.Context) {
+	//godernize:ignore=ctxnil.always-true
+	ctx = nil // want "context should never be nil, assign a valid context such as context.Background\\(\\) to 'ctx'"
+	println(ctx)
+}
+
+// Ignoring the whole analyzer still works.
+//
+//godernize:ignore=ctxnil
+func ignored(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+}
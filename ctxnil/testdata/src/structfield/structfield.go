@@ -0,0 +1,29 @@
+package structfield
+
+import (
+	"context"
+	"fmt"
+)
+
+type worker struct {
+	ctx  context.Context
+	name string
+}
+
+func (w *worker) run() {
+	if w.ctx == nil { // want `condition is always false, remove entire if statement; do not store contexts in structs, pass them to the functions that need them`
+		w.ctx = context.Background()
+	}
+
+	ready := w.ctx != nil // want `context field 'w.ctx' is never nil, replace 'w.ctx != nil' with 'true'; do not store contexts in structs`
+	fmt.Println(w.name, ready)
+}
+
+func (w *worker) check(ctx context.Context) {
+	// A parameter keeps its category and message.
+	if ctx != nil && w.name != "" { // want `simplify to 'w.name != ""' \(left side is always true\)$`
+		fmt.Println(w.name)
+	}
+
+	fmt.Println(ctx.Err())
+}
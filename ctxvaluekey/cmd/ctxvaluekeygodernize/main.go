@@ -0,0 +1,12 @@
+// Command ctxvaluekeygodernize runs the ctxvaluekey analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/ctxvaluekey"
+)
+
+func main() {
+	singlechecker.Main(ctxvaluekey.Analyzer)
+}
@@ -0,0 +1,188 @@
+// Package ctxvaluekey provides an analyzer to detect context.WithValue calls
+// with keys of built-in types.
+package ctxvaluekey
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const contextPath = "context"
+
+// Doc describes what this analyzer does.
+const Doc = `check for context.WithValue keys of built-in types
+
+This analyzer reports context.WithValue calls whose key is a constant or has a
+built-in type such as string or int:
+- context.WithValue(ctx, "user", u) -> context.WithValue(ctx, userKey{}, u)
+
+Keys of built-in types can collide with keys set by other packages. A key of
+an unexported type defined in the package, such as type userKey struct{},
+cannot. No fix is offered, since the fix requires declaring a new type.`
+
+// Analyzer is the main analyzer for context.WithValue keys.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxvaluekey",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/ctxvaluekey",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) != 3 || !isPkgFunc(pass.TypesInfo, call, contextPath, "WithValue") {
+		return nil
+	}
+
+	key := call.Args[1]
+
+	basic := builtinType(pass.TypesInfo, key)
+	if basic == nil || shouldIgnore(pass.Fset, file, call, "ctxvaluekey") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: key.Pos(),
+		End: key.End(),
+		Message: fmt.Sprintf("context.WithValue key has built-in type %s and may collide with keys of other packages, "+
+			"use a value of an unexported key type such as 'type ctxKey struct{}'", basic.Name()),
+	}
+}
+
+// builtinType returns the type of the key if it is a predeclared basic type,
+// which is also the default type of an untyped constant key, and nil
+// otherwise. Defined types such as type key string are not reported.
+func builtinType(info *types.Info, key ast.Expr) *types.Basic {
+	tv, ok := info.Types[key]
+	if !ok || tv.Type == nil {
+		return nil
+	}
+
+	basic, ok := types.Default(types.Unalias(tv.Type)).(*types.Basic)
+	if !ok || basic.Kind() == types.UntypedNil || basic.Kind() == types.Invalid {
+		return nil
+	}
+
+	return basic
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
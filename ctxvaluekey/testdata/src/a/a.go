@@ -0,0 +1,45 @@
+package a
+
+import (
+	"context"
+
+	fake "fake/context"
+)
+
+type userKey struct{}
+
+type name string
+
+type text = string
+
+const requestIDKey = "request-id"
+
+var stringKey = "user"
+
+func builtinKeys(ctx context.Context, key string, n int) {
+	_ = context.WithValue(ctx, "user", 1)        // want `context.WithValue key has built-in type string and may collide with keys of other packages, use a value of an unexported key type such as 'type ctxKey struct\{\}'`
+	_ = context.WithValue(ctx, 42, 1)            // want `context.WithValue key has built-in type int and may collide`
+	_ = context.WithValue(ctx, requestIDKey, 1)  // want `context.WithValue key has built-in type string`
+	_ = context.WithValue(ctx, stringKey, 1)     // want `context.WithValue key has built-in type string`
+	_ = context.WithValue(ctx, key, 1)           // want `context.WithValue key has built-in type string`
+	_ = context.WithValue(ctx, n, 1)             // want `context.WithValue key has built-in type int`
+	_ = context.WithValue(ctx, text("alias"), 1) // want `context.WithValue key has built-in type string`
+	_ = context.WithValue(ctx, 1.5, 1)           // want `context.WithValue key has built-in type float64`
+}
+
+func typedKeys(ctx context.Context, key any) {
+	_ = context.WithValue(ctx, userKey{}, 1)
+	_ = context.WithValue(ctx, name("user"), 1)
+	_ = context.WithValue(ctx, &userKey{}, 1)
+
+	// The dynamic type is unknown.
+	_ = context.WithValue(ctx, key, 1)
+
+	// Not the context package.
+	_ = fake.WithValue(ctx, "user", 1)
+}
+
+func ignored(ctx context.Context) {
+	//godernize:ignore=ctxvaluekey
+	_ = context.WithValue(ctx, "user", 1)
+}
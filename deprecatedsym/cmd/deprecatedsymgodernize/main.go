@@ -0,0 +1,12 @@
+// Command deprecatedsymgodernize runs the deprecatedsym analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/deprecatedsym"
+)
+
+func main() {
+	singlechecker.Main(deprecatedsym.Analyzer)
+}
@@ -0,0 +1,312 @@
+// Package deprecatedsym provides an analyzer to detect references to
+// deprecated package-level symbols listed in a registry.
+package deprecatedsym
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for references to deprecated package-level symbols
+
+This analyzer reports calls and bare references to deprecated symbols listed
+in its registry and suggests their replacements, e.g.:
+- os.SEEK_SET -> io.SeekStart
+- strings.Title -> cases.Title from golang.org/x/text/cases
+- ioutil.Discard -> io.Discard
+
+Entries marked auto-fixable get a fix that replaces the reference and updates
+imports. Downstream tools can add entries with RegisterDeprecation.`
+
+// Analyzer is the main analyzer for deprecated symbols.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "deprecatedsym",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/deprecatedsym",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.SelectorExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single import adjustment.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]fileDiagnostic)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !push || !ok || sel == nil {
+			return true
+		}
+
+		file := fileMap.File(sel.Pos())
+
+		if diagnostic, d := diagnoseSelector(pass, file, sel, callOf(sel, stack)); diagnostic != nil {
+			if _, seen := found[file]; !seen {
+				files = append(files, file)
+			}
+
+			found[file] = append(found[file], fileDiagnostic{diagnostic: *diagnostic, sel: sel, deprecation: d})
+		}
+
+		return true
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// fileDiagnostic is a diagnostic with the reference it reports.
+type fileDiagnostic struct {
+	diagnostic  analysis.Diagnostic
+	sel         *ast.SelectorExpr
+	deprecation Deprecation
+}
+
+// consolidateFixes combines the reference rewrites of all fixable diagnostics
+// in file with one adjustment of the imports: replacement packages are added,
+// and deprecated packages are removed when no reference remains outside the
+// rewritten ones. With several fixable diagnostics, only the first carries
+// the combined fix, so that applying every fix of the file does not apply the
+// same import edits twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []fileDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits      []analysis.TextEdit
+		rewrites   []ast.Node
+		add        []string
+		deprecated []string
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		rewrites = append(rewrites, f.sel)
+		add = append(add, f.deprecation.ReplacementPkgPath)
+		deprecated = append(deprecated, f.deprecation.PkgPath)
+	}
+
+	if file == nil || first < 0 {
+		return diagnostics
+	}
+
+	var remove []string
+
+	for _, pkgPath := range deprecated {
+		if !slices.Contains(add, pkgPath) && !slices.Contains(remove, pkgPath) &&
+			!importutil.UsedOutside(pass.TypesInfo, file, pkgPath, rewrites...) {
+			remove = append(remove, pkgPath)
+		}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, add, remove)...)
+
+	message := diagnostics[first].SuggestedFixes[0].Message
+	if len(rewrites) > 1 {
+		message = "Replace deprecated symbols"
+	}
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{Message: message, TextEdits: edits}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// callOf returns the call whose function is sel, or nil if sel is not called.
+func callOf(sel *ast.SelectorExpr, stack []ast.Node) *ast.CallExpr {
+	// The last element of the stack is the selector itself.
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.ParenExpr:
+			continue
+		case *ast.CallExpr:
+			if ast.Unparen(node.Fun) == sel {
+				return node
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// diagnoseSelector reports sel if it refers to a deprecated symbol, returning
+// the deprecation too. The fix of an auto-fixable symbol only rewrites the
+// reference; consolidateFixes adds the import edits.
+func diagnoseSelector(
+	pass *analysis.Pass, file *ast.File, sel *ast.SelectorExpr, call *ast.CallExpr,
+) (*analysis.Diagnostic, Deprecation) {
+	if file == nil {
+		return nil, Deprecation{}
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, Deprecation{}
+	}
+
+	pkgName, ok := pass.TypesInfo.Uses[ident].(*types.PkgName)
+	if !ok {
+		return nil, Deprecation{}
+	}
+
+	pkgPath := pkgName.Imported().Path()
+
+	d, ok := lookupDeprecation(pkgPath, sel.Sel.Name)
+	if !ok || shouldIgnore(pass.Fset, file, sel, sel.Sel.Name) {
+		return nil, Deprecation{}
+	}
+
+	// Calls are reported as a whole so the diagnostic covers the arguments.
+	var node ast.Node = sel
+	if call != nil {
+		node = call
+	}
+
+	message := fmt.Sprintf("%s.%s is deprecated, use %s.%s instead",
+		path.Base(pkgPath), d.Symbol, path.Base(d.ReplacementPkgPath), d.ReplacementSymbol)
+	if d.Note != "" {
+		message += ": " + d.Note
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     node.Pos(),
+		End:     node.End(),
+		Message: message,
+	}
+
+	if !d.AutoFixable {
+		return diagnostic, d
+	}
+
+	replacement := importutil.LocalName(file, d.ReplacementPkgPath) + "." + d.ReplacementSymbol
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace with " + replacement,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     sel.Pos(),
+			End:     sel.End(),
+			NewText: []byte(replacement),
+		}},
+	}}
+
+	return diagnostic, d
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, symbol string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, symbol) ||
+		shouldIgnoreInRange(file, node, symbol) ||
+		shouldIgnoreInFunction(file, node, symbol) ||
+		shouldIgnoreFromComment(fset, file, node, symbol)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, symbol string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && (ignore.ShouldIgnore("deprecatedsym") || ignore.ShouldIgnore(symbol))
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, symbol string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && (r.ShouldIgnore("deprecatedsym") || r.ShouldIgnore(symbol)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, symbol string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && (ignore.ShouldIgnore("deprecatedsym") || ignore.ShouldIgnore(symbol)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, symbol string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && (ignore.ShouldIgnore("deprecatedsym") || ignore.ShouldIgnore(symbol)) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && (ignore.ShouldIgnore("deprecatedsym") || ignore.ShouldIgnore(symbol))
+}
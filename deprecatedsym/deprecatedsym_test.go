@@ -0,0 +1,38 @@
+package deprecatedsym_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/deprecatedsym"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, deprecatedsym.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, deprecatedsym.Analyzer, "autofix")
+}
+
+func TestRegisterDeprecation(t *testing.T) {
+	t.Parallel()
+
+	deprecatedsym.RegisterDeprecation(deprecatedsym.Deprecation{
+		PkgPath:            "legacy",
+		Symbol:             "Old",
+		ReplacementPkgPath: "legacy",
+		ReplacementSymbol:  "New",
+		AutoFixable:        true,
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, deprecatedsym.Analyzer, "custom")
+}
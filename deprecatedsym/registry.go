@@ -0,0 +1,76 @@
+package deprecatedsym
+
+import "sync"
+
+// Deprecation describes a deprecated package-level symbol and its
+// replacement.
+type Deprecation struct {
+	// PkgPath and Symbol identify the deprecated symbol, e.g. "os" and
+	// "SEEK_SET".
+	PkgPath string
+	Symbol  string
+	// ReplacementPkgPath and ReplacementSymbol identify the replacement, e.g.
+	// "io" and "SeekStart".
+	ReplacementPkgPath string
+	ReplacementSymbol  string
+	// AutoFixable reports whether every reference can be replaced with the
+	// replacement symbol as is. Deprecations whose replacement needs a
+	// different call shape are reported without a fix.
+	AutoFixable bool
+	// Note optionally explains how to migrate when no fix is offered.
+	Note string
+}
+
+type symbolKey struct {
+	pkgPath string
+	symbol  string
+}
+
+//nolint:gochecknoglobals // registry shared with downstream tools
+var (
+	registryMu sync.RWMutex
+	registry   = map[symbolKey]Deprecation{}
+)
+
+//nolint:gochecknoinits // seeds the registry before any pass runs
+func init() {
+	for _, d := range []Deprecation{
+		{
+			PkgPath: "strings", Symbol: "Title",
+			ReplacementPkgPath: "golang.org/x/text/cases", ReplacementSymbol: "Title",
+			Note: "cases.Title(language.Und).String(s) handles Unicode word boundaries",
+		},
+		{
+			PkgPath: "bytes", Symbol: "Title",
+			ReplacementPkgPath: "golang.org/x/text/cases", ReplacementSymbol: "Title",
+			Note: "cases.Title(language.Und).Bytes(s) handles Unicode word boundaries",
+		},
+		{PkgPath: "os", Symbol: "SEEK_SET", ReplacementPkgPath: "io", ReplacementSymbol: "SeekStart", AutoFixable: true},
+		{PkgPath: "os", Symbol: "SEEK_CUR", ReplacementPkgPath: "io", ReplacementSymbol: "SeekCurrent", AutoFixable: true},
+		{PkgPath: "os", Symbol: "SEEK_END", ReplacementPkgPath: "io", ReplacementSymbol: "SeekEnd", AutoFixable: true},
+		{PkgPath: "io/ioutil", Symbol: "Discard", ReplacementPkgPath: "io", ReplacementSymbol: "Discard", AutoFixable: true},
+		{PkgPath: "io/ioutil", Symbol: "NopCloser", ReplacementPkgPath: "io", ReplacementSymbol: "NopCloser", AutoFixable: true},
+		{PkgPath: "io/ioutil", Symbol: "ReadAll", ReplacementPkgPath: "io", ReplacementSymbol: "ReadAll", AutoFixable: true},
+	} {
+		RegisterDeprecation(d)
+	}
+}
+
+// RegisterDeprecation adds d to the registry consulted by Analyzer, replacing
+// any entry for the same symbol. Downstream tools call it before running the
+// analyzer, typically from an init function.
+func RegisterDeprecation(d Deprecation) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[symbolKey{pkgPath: d.PkgPath, symbol: d.Symbol}] = d
+}
+
+func lookupDeprecation(pkgPath, symbol string) (Deprecation, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	d, ok := registry[symbolKey{pkgPath: pkgPath, symbol: symbol}]
+
+	return d, ok
+}
@@ -0,0 +1,37 @@
+package a
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+)
+
+func title(s string, b []byte) (string, []byte) {
+	return strings.Title(s), bytes.Title(b) // want `strings.Title is deprecated, use cases.Title instead: cases.Title\(language.Und\).String\(s\) handles Unicode word boundaries` `bytes.Title is deprecated, use cases.Title instead`
+}
+
+func titleValue() func(string) string {
+	return strings.Title // want `strings.Title is deprecated, use cases.Title instead`
+}
+
+func seek(f *os.File) {
+	_, _ = f.Seek(0, os.SEEK_SET) // want `os.SEEK_SET is deprecated, use io.SeekStart instead`
+	_, _ = f.Seek(0, os.SEEK_CUR) // want `os.SEEK_CUR is deprecated, use io.SeekCurrent instead`
+	_, _ = f.Seek(0, os.SEEK_END) // want `os.SEEK_END is deprecated, use io.SeekEnd instead`
+	_, _ = f.Seek(0, io.SeekStart)
+}
+
+func notDeprecated(s string) string {
+	return strings.ToTitle(s)
+}
+
+//godernize:ignore=Title
+func ignoredBySymbol(s string) string {
+	return strings.Title(s)
+}
+
+//godernize:ignore=deprecatedsym
+func ignoredByAnalyzer(f *os.File) {
+	_, _ = f.Seek(0, os.SEEK_END)
+}
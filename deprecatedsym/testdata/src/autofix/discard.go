@@ -0,0 +1,22 @@
+package autofix
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+func drain(r io.Reader) error {
+	_, err := io.Copy(ioutil.Discard, r) // want "ioutil.Discard is deprecated, use io.Discard instead"
+
+	return err
+}
+
+func quiet() {
+	log.SetOutput(ioutil.Discard) // want "ioutil.Discard is deprecated, use io.Discard instead"
+}
+
+var wrap = ioutil.NopCloser // want "ioutil.NopCloser is deprecated, use io.NopCloser instead"
+
+var body = wrap(strings.NewReader(""))
@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"io"
+	"os"
+)
+
+func skipToEnd(f *os.File) (int64, error) {
+	if _, err := f.Seek(0, os.SEEK_CUR); err != nil { // want "os.SEEK_CUR is deprecated, use io.SeekCurrent instead"
+		return 0, err
+	}
+
+	return f.Seek(0, os.SEEK_END) // want "os.SEEK_END is deprecated, use io.SeekEnd instead"
+}
+
+var _ io.Seeker
@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"io/ioutil"
+	"log"
+)
+
+func logToNowhere(dir string) error {
+	log.SetOutput(ioutil.Discard) // want "ioutil.Discard is deprecated, use io.Discard instead"
+
+	_, err := ioutil.ReadDir(dir)
+
+	return err
+}
@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"fmt"
+	"os"
+)
+
+type seeker interface {
+	Seek(offset int64, whence int) (int64, error)
+}
+
+func rewind(s seeker) {
+	if _, err := s.Seek(0, os.SEEK_SET); err != nil { // want "os.SEEK_SET is deprecated, use io.SeekStart instead"
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,7 @@
+package custom
+
+import "legacy"
+
+func value() int {
+	return legacy.Old() // want "legacy.Old is deprecated, use legacy.New instead"
+}
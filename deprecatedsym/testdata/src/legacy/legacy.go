@@ -0,0 +1,7 @@
+// Package legacy stands in for a package with a deprecated function.
+package legacy
+
+// Deprecated: use New.
+func Old() int { return 1 }
+
+func New() int { return 1 }
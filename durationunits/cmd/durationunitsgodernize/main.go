@@ -0,0 +1,12 @@
+// Command durationunitsgodernize runs the durationunits analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/durationunits"
+)
+
+func main() {
+	singlechecker.Main(durationunits.Analyzer)
+}
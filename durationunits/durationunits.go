@@ -0,0 +1,242 @@
+// Package durationunits provides an analyzer to detect time.Duration values
+// multiplied by a unit a second time.
+package durationunits
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for time.Duration values multiplied by a time unit
+
+Multiplying a count by a unit requires converting it first, as in
+time.Duration(n) * time.Second; the compiler rejects n * time.Second for an
+int variable n. The conversion is easily applied to the wrong value, though:
+this analyzer reports multiplications of a time unit constant such as
+time.Second by a non-constant operand that already is a time.Duration, as in
+d * time.Second, d *= time.Second or time.Duration(d) * time.Second. The
+result is scaled twice, so a timeout of 5 * time.Second becomes 5e9 seconds.
+Keep the count in an integer variable instead. The analyzer reports
+diagnostics only.`
+
+// Analyzer is the main analyzer for durations multiplied by a unit.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "durationunits",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/durationunits",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// units are the unit constants of package time.
+//
+//nolint:gochecknoglobals // static table of unit constants
+var units = map[string]bool{
+	"Nanosecond":  true,
+	"Microsecond": true,
+	"Millisecond": true,
+	"Second":      true,
+	"Minute":      true,
+	"Hour":        true,
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+		(*ast.AssignStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		file := fileMap.File(n.Pos())
+
+		var diagnostic *analysis.Diagnostic
+
+		switch node := n.(type) {
+		case *ast.BinaryExpr:
+			if node.Op == token.MUL {
+				diagnostic = diagnoseProduct(pass, file, node, node.X, node.Y)
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.MUL_ASSIGN && len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+				diagnostic = diagnoseProduct(pass, file, node, node.Lhs[0], node.Rhs[0])
+			}
+		}
+
+		if diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+// diagnoseProduct checks node multiplying x by y, either as x * y or as
+// x *= y.
+func diagnoseProduct(pass *analysis.Pass, file *ast.File, node ast.Node, x, y ast.Expr) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	for _, operands := range [][2]ast.Expr{{x, y}, {y, x}} {
+		unit, value := unitName(pass.TypesInfo, operands[0]), operands[1]
+		if unit == "" || !isScaledDuration(pass.TypesInfo, value) {
+			continue
+		}
+
+		if shouldIgnore(pass.Fset, file, node, "durationunits") {
+			return nil
+		}
+
+		return &analysis.Diagnostic{
+			Pos: node.Pos(),
+			End: node.End(),
+			Message: fmt.Sprintf("%s is already a time.Duration, so multiplying it by time.%s scales it twice; "+
+				"keep the count in an integer n and use time.Duration(n) * time.%s",
+				types.ExprString(ast.Unparen(value)), unit, unit),
+		}
+	}
+
+	return nil
+}
+
+// unitName returns the name of the time unit constant expr refers to, or "".
+func unitName(info *types.Info, expr ast.Expr) string {
+	var ident *ast.Ident
+
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return ""
+	}
+
+	obj, ok := info.Uses[ident].(*types.Const)
+	if !ok || obj.Pkg() == nil || obj.Pkg().Path() != "time" || !units[obj.Name()] {
+		return ""
+	}
+
+	return obj.Name()
+}
+
+// isScaledDuration checks if expr is a non-constant time.Duration that is not
+// a conversion of a plain number, whose unit is thus already applied.
+func isScaledDuration(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value != nil || !isDuration(tv.Type) {
+		return false
+	}
+
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !info.Types[call.Fun].IsType() {
+		return true
+	}
+
+	return isDuration(info.TypeOf(call.Args[0]))
+}
+
+// isDuration checks if typ is time.Duration, possibly through an alias.
+func isDuration(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration"
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,55 @@
+package a
+
+import (
+	"time"
+	t "time"
+)
+
+type config struct {
+	Timeout time.Duration
+	Retries int
+}
+
+func timeout() time.Duration { return time.Second }
+
+func scaledTwice(d time.Duration, cfg config) {
+	_ = d * time.Second                // want `d is already a time.Duration, so multiplying it by time.Second scales it twice`
+	_ = time.Millisecond * d           // want `d is already a time.Duration, so multiplying it by time.Millisecond scales it twice`
+	_ = cfg.Timeout * time.Minute      // want `cfg.Timeout is already a time.Duration`
+	_ = timeout() * time.Hour          // want `timeout\(\) is already a time.Duration`
+	_ = time.Duration(d) * time.Second // want `time.Duration\(d\) is already a time.Duration`
+	_ = (d) * (time.Microsecond)       // want `d is already a time.Duration, so multiplying it by time.Microsecond`
+	_ = d * t.Nanosecond               // want `d is already a time.Duration, so multiplying it by time.Nanosecond`
+
+	d *= 2
+	d *= time.Second            // want `d is already a time.Duration, so multiplying it by time.Second scales it twice`
+	time.Sleep(d * time.Second) // want `d is already a time.Duration`
+}
+
+func counts(n int, f float64, cfg config) {
+	const seconds = 5
+
+	_ = time.Duration(n) * time.Second
+	_ = time.Second * time.Duration(cfg.Retries)
+	_ = time.Duration(f*1000) * time.Millisecond
+	_ = seconds * time.Second
+	_ = 5 * time.Second * 2
+	_ = time.Duration(seconds) * time.Second
+}
+
+func otherOperations(d time.Duration) {
+	_ = d + time.Second
+	_ = d / time.Millisecond
+	_ = d * 2
+	_ = d * d
+}
+
+//godernize:ignore=durationunits
+func ignoredFunc(d time.Duration) time.Duration {
+	return d * time.Second
+}
+
+func ignoredLine(d time.Duration) time.Duration {
+	//godernize:ignore
+	return d * time.Second
+}
@@ -0,0 +1,12 @@
+// Command envparsegodernize runs the envparse analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/envparse"
+)
+
+func main() {
+	singlechecker.Main(envparse.Analyzer)
+}
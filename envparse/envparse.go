@@ -0,0 +1,226 @@
+// Package envparse provides an analyzer to detect environment variables parsed
+// with strconv while the parse error is discarded.
+package envparse
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const defaultMessage = "handle the error or use a helper that reports it"
+
+// Doc describes what this analyzer does.
+const Doc = `check for environment variables parsed with the error discarded
+
+This analyzer reports assignments such as
+
+	v, _ := strconv.Atoi(os.Getenv("PORT"))
+
+which parse the value of os.Getenv with strconv.Atoi, ParseBool, ParseInt,
+ParseUint, or ParseFloat and assign the error to the blank identifier. A
+malformed or missing value then silently becomes zero or false.
+
+The standard library has no replacement, so the analyzer reports diagnostics
+only. The advice ending the message can be replaced with -message, such as
+to point to a helper of the project.`
+
+// Analyzer is the main analyzer for discarded environment parse errors.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "envparse",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/envparse",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.StringVar(&runner.message, "message", defaultMessage,
+		"advice ending each diagnostic, such as the name of a helper parsing environment variables")
+
+	return analyzer
+}
+
+type runner struct {
+	message string
+}
+
+// parseFuncs maps the strconv functions parsing a string to the value their
+// result has when parsing fails.
+//
+//nolint:gochecknoglobals // static table of checked functions
+var parseFuncs = map[string]string{
+	"Atoi":       "0",
+	"ParseBool":  "false",
+	"ParseInt":   "0",
+	"ParseUint":  "0",
+	"ParseFloat": "0",
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		stmt, ok := n.(*ast.AssignStmt)
+		if !ok || stmt == nil {
+			return
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if diagnostic := r.diagnoseAssignStmt(pass, file, stmt); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func (r *runner) diagnoseAssignStmt(pass *analysis.Pass, file *ast.File, stmt *ast.AssignStmt) *analysis.Diagnostic {
+	if file == nil || len(stmt.Lhs) != 2 || len(stmt.Rhs) != 1 || !isBlank(stmt.Lhs[1]) {
+		return nil
+	}
+
+	call, ok := ast.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+
+	name, zero := findParseFunc(pass.TypesInfo, call)
+	if name == "" {
+		return nil
+	}
+
+	getenv, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok || !isPkgFunc(pass.TypesInfo, getenv, "os", "Getenv") || shouldIgnore(pass.Fset, file, stmt, "envparse") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("strconv.%s of os.Getenv discards the parse error, so a malformed value is silently read as %s; %s",
+			name, zero, r.message),
+	}
+}
+
+func findParseFunc(info *types.Info, call *ast.CallExpr) (string, string) {
+	for name, zero := range parseFuncs {
+		if isPkgFunc(info, call, "strconv", name) {
+			return name, zero
+		}
+	}
+
+	return "", ""
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,27 @@
+package envparse_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/envparse"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, envparse.Analyzer, "a")
+}
+
+func TestMessage(t *testing.T) {
+	if err := envparse.Analyzer.Flags.Set("message", "use config.Int or config.Bool instead"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = envparse.Analyzer.Flags.Set("message", "handle the error or use a helper that reports it")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, envparse.Analyzer, "message")
+}
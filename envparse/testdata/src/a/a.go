@@ -0,0 +1,48 @@
+package a
+
+import (
+	"os"
+	"strconv"
+)
+
+// Variable declarations are not reported.
+var debug, _ = strconv.ParseBool(os.Getenv("DEBUG"))
+
+func config() {
+	port, _ := strconv.Atoi(os.Getenv("PORT"))            // want `strconv.Atoi of os.Getenv discards the parse error, so a malformed value is silently read as 0; handle the error or use a helper that reports it`
+	verbose, _ := strconv.ParseBool(os.Getenv("VERBOSE")) // want `strconv.ParseBool of os.Getenv discards the parse error, so a malformed value is silently read as false`
+
+	var limit int64
+	limit, _ = strconv.ParseInt((os.Getenv("LIMIT")), 10, 64) // want `strconv.ParseInt of os.Getenv discards the parse error`
+
+	_, _, _ = port, verbose, limit
+}
+
+func handled() (int, error) {
+	port, err := strconv.Atoi(os.Getenv("PORT"))
+	if err != nil {
+		return 0, err
+	}
+
+	return port, nil
+}
+
+func notEnv(s string) int {
+	n, _ := strconv.Atoi(s)
+
+	return n
+}
+
+func lookup() int {
+	value, _ := os.LookupEnv("PORT")
+	n, _ := strconv.Atoi(value)
+
+	return n
+}
+
+func ignored() int {
+	//godernize:ignore=envparse
+	port, _ := strconv.Atoi(os.Getenv("PORT"))
+
+	return port
+}
@@ -0,0 +1,13 @@
+package message
+
+import (
+	"os"
+	"strconv"
+)
+
+func config() (int, bool) {
+	port, _ := strconv.Atoi(os.Getenv("PORT"))            // want `strconv.Atoi of os.Getenv discards the parse error, so a malformed value is silently read as 0; use config.Int or config.Bool instead`
+	verbose, _ := strconv.ParseBool(os.Getenv("VERBOSE")) // want `; use config.Int or config.Bool instead`
+
+	return port, verbose
+}
@@ -0,0 +1,15 @@
+// Command errasgodernize runs the erras analyzer.
+//
+// It uses multichecker, whose flags are prefixed with the analyzer name, since
+// singlechecker reserves -fix for applying fixes and would drop -erras.fix.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/jaeyeom/godernize/erras"
+)
+
+func main() {
+	multichecker.Main(erras.Analyzer)
+}
@@ -0,0 +1,357 @@
+// Package erras provides an analyzer to detect type assertions on errors,
+// which errors.As performs through wrapped errors.
+package erras
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for type assertions on errors
+
+This analyzer reports if statements checking a type assertion on a value of
+type error, which miss the asserted type once the error is wrapped, and
+suggests errors.As:
+- if e, ok := err.(*MyError); ok { ... }
+  -> var e *MyError; if errors.As(err, &e) { ... }
+
+Type switches are not reported. The rewrite moves the declaration of the
+asserted value out of the if statement, so fixes are only suggested with
+-fix, when ok is used only as the condition and the name is neither declared
+in the enclosing block nor moved there by the fix of an earlier if statement.`
+
+// Analyzer is the main analyzer for type assertions on errors.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "erras",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/erras",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.fix, "fix", false,
+		"suggest fixes declaring the asserted value before the if statement and calling errors.As")
+
+	return analyzer
+}
+
+type runner struct {
+	fix bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		stmt, ok := n.(*ast.IfStmt)
+		if !push || !ok || stmt == nil {
+			return true
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if diagnostic := r.diagnoseIfStmt(pass, file, stmt, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// errorAssertion is an if statement of the form
+// if value, ok := err.(T); ok { ... }.
+type errorAssertion struct {
+	value, ok *ast.Ident
+	assert    *ast.TypeAssertExpr
+}
+
+func (r *runner) diagnoseIfStmt(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt,
+	stack []ast.Node,
+) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	assertion := matchErrorAssertion(pass.TypesInfo, stmt)
+	if assertion == nil || shouldIgnore(pass.Fset, file, stmt, "erras") {
+		return nil
+	}
+
+	typeText := formatNode(pass.Fset, assertion.assert.Type)
+
+	diagnostic := &analysis.Diagnostic{
+		Pos: assertion.assert.Pos(),
+		End: assertion.assert.End(),
+		Message: fmt.Sprintf("type assertion on error misses wrapped errors, use errors.As with a %s target instead",
+			typeText),
+	}
+
+	if r.fix {
+		diagnostic.SuggestedFixes = suggestedFixes(pass, file, stmt, stack, assertion, typeText)
+	}
+
+	return diagnostic
+}
+
+// matchErrorAssertion returns the type assertion checked by stmt if it
+// asserts a value of type error to a type errors.As accepts as a target.
+func matchErrorAssertion(info *types.Info, stmt *ast.IfStmt) *errorAssertion {
+	init, ok := stmt.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 2 || len(init.Rhs) != 1 {
+		return nil
+	}
+
+	assert, ok := ast.Unparen(init.Rhs[0]).(*ast.TypeAssertExpr)
+	if !ok || assert.Type == nil || !isError(info.TypeOf(assert.X)) {
+		return nil
+	}
+
+	value, valueOK := init.Lhs[0].(*ast.Ident)
+	okIdent, okOK := init.Lhs[1].(*ast.Ident)
+	cond, condOK := ast.Unparen(stmt.Cond).(*ast.Ident)
+
+	if !valueOK || !okOK || !condOK || okIdent.Name == "_" || info.Uses[cond] != info.Defs[okIdent] {
+		return nil
+	}
+
+	// errors.As panics unless the target points to an error or an interface.
+	target := info.TypeOf(assert.Type)
+	if target == nil || (!types.IsInterface(target) && !types.Implements(target, errorType())) {
+		return nil
+	}
+
+	return &errorAssertion{value: value, ok: okIdent, assert: assert}
+}
+
+// suggestedFixes returns the fix declaring the asserted value before stmt, or
+// nil if the declaration cannot be moved there.
+func suggestedFixes(pass *analysis.Pass, file *ast.File, stmt *ast.IfStmt, stack []ast.Node,
+	assertion *errorAssertion, typeText string,
+) []analysis.SuggestedFix {
+	if assertion.value.Name == "_" || typeText == "" || !inStmtList(stack) ||
+		usedOutsideCond(pass.TypesInfo, stmt, assertion.ok) || declaredInBlock(pass.TypesInfo, stmt, assertion.value) ||
+		hoistedBefore(pass.TypesInfo, stack, assertion.value) {
+		return nil
+	}
+
+	errText := formatNode(pass.Fset, assertion.assert.X)
+	if errText == "" {
+		return nil
+	}
+
+	indent := strings.Repeat("\t", pass.Fset.Position(stmt.Pos()).Column-1)
+	declaration := fmt.Sprintf("var %s %s\n%s", assertion.value.Name, typeText, indent)
+	condition := fmt.Sprintf("%s.As(%s, &%s)", importutil.LocalName(file, "errors"), errText, assertion.value.Name)
+
+	edits := []analysis.TextEdit{
+		{Pos: stmt.Pos(), End: stmt.Pos(), NewText: []byte(declaration)},
+		{Pos: stmt.Init.Pos(), End: stmt.Cond.End(), NewText: []byte(condition)},
+	}
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{"errors"}, nil)...)
+
+	return []analysis.SuggestedFix{{
+		Message:   "Replace with errors.As",
+		TextEdits: edits,
+	}}
+}
+
+// inStmtList reports whether the last node of stack is a statement of a block
+// or a case clause, before which a declaration can be inserted. The if of an
+// else if or a labeled statement is not.
+func inStmtList(stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+
+	switch stack[len(stack)-2].(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+		return true
+	}
+
+	return false
+}
+
+// usedOutsideCond reports whether the ok variable of stmt is used anywhere
+// but in its condition, which the fix replaces.
+func usedOutsideCond(info *types.Info, stmt *ast.IfStmt, ok *ast.Ident) bool {
+	obj := info.Defs[ok]
+	used := false
+
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		if ident, isIdent := n.(*ast.Ident); isIdent && ident != ast.Unparen(stmt.Cond) && info.Uses[ident] == obj {
+			used = true
+		}
+
+		return !used
+	})
+
+	return used
+}
+
+// declaredInBlock reports whether the name of value is declared in the block
+// enclosing stmt or visible there, so that the moved declaration would
+// conflict with it or shadow it for the statements after stmt.
+func declaredInBlock(info *types.Info, stmt *ast.IfStmt, value *ast.Ident) bool {
+	scope := info.Scopes[stmt]
+	if scope == nil || scope.Parent() == nil {
+		return true
+	}
+
+	_, visible := scope.Parent().LookupParent(value.Name, stmt.Pos())
+
+	return scope.Parent().Lookup(value.Name) != nil || visible != nil
+}
+
+// hoistedBefore reports whether an if statement preceding the last node of
+// stack in its statement list asserts an error to a value of the same name as
+// value. The fix of that statement may declare the name in the block too,
+// which would then be declared twice.
+func hoistedBefore(info *types.Info, stack []ast.Node, value *ast.Ident) bool {
+	var list []ast.Stmt
+
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.BlockStmt:
+		list = parent.List
+	case *ast.CaseClause:
+		list = parent.Body
+	case *ast.CommClause:
+		list = parent.Body
+	}
+
+	for _, prev := range list {
+		if prev == stack[len(stack)-1] {
+			break
+		}
+
+		if prevIf, ok := prev.(*ast.IfStmt); ok {
+			if assertion := matchErrorAssertion(info, prevIf); assertion != nil && assertion.value.Name == value.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isError reports whether t is the predeclared error type.
+func isError(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func errorType() *types.Interface {
+	iface, _ := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+	return iface
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
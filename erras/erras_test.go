@@ -0,0 +1,35 @@
+package erras_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/erras"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, erras.Analyzer, "a")
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			if len(diagnostic.SuggestedFixes) > 0 {
+				t.Errorf("%s: %s: got a fix without -fix", result.Pass.Fset.Position(diagnostic.Pos), diagnostic.Message)
+			}
+		}
+	}
+}
+
+func TestAutoFix(t *testing.T) {
+	if err := erras.Analyzer.Flags.Set("fix", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = erras.Analyzer.Flags.Set("fix", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, erras.Analyzer, "autofix")
+}
@@ -0,0 +1,60 @@
+package a
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+)
+
+type MyError struct{ Code int }
+
+func (e *MyError) Error() string { return fmt.Sprint(e.Code) }
+
+func asserted(err error) int {
+	if e, ok := err.(*MyError); ok { // want `type assertion on error misses wrapped errors, use errors.As with a \*MyError target instead`
+		return e.Code
+	}
+
+	if pathErr, ok := err.(*fs.PathError); ok { // want `use errors.As with a \*fs.PathError target instead`
+		fmt.Println(pathErr.Path)
+	}
+
+	if netErr, ok := err.(net.Error); ok { // want `use errors.As with a net.Error target instead`
+		fmt.Println(netErr.Timeout())
+	}
+
+	if _, ok := err.(*MyError); ok { // want `use errors.As with a \*MyError target instead`
+		return 1
+	}
+
+	return 0
+}
+
+func notReported(value any, err error) {
+	// Not an error.
+	if s, ok := value.(fmt.Stringer); ok {
+		fmt.Println(s)
+	}
+
+	// Type switches are handled separately.
+	switch e := err.(type) {
+	case *MyError:
+		fmt.Println(e.Code)
+	}
+
+	// Not checked by the condition.
+	if e, ok := err.(*MyError); !ok {
+		fmt.Println(e)
+	}
+
+	// A plain assertion without an if statement.
+	e, ok := err.(*MyError)
+	fmt.Println(e, ok)
+}
+
+func ignored(err error) {
+	//godernize:ignore=erras
+	if e, ok := err.(*MyError); ok {
+		fmt.Println(e)
+	}
+}
@@ -0,0 +1,58 @@
+package autofix
+
+import (
+	"fmt"
+)
+
+type MyError struct{ Code int }
+
+func (e *MyError) Error() string { return fmt.Sprint(e.Code) }
+
+func fixed(err error) int {
+	if e, ok := err.(*MyError); ok { // want "type assertion on error"
+		return e.Code
+	}
+
+	for _, wrapped := range []error{err} {
+		if target, ok := wrapped.(*MyError); ok { // want "type assertion on error"
+			fmt.Println(target.Code)
+		}
+	}
+
+	return 0
+}
+
+func notFixed(err error, e *MyError) {
+	// Moving e out of the if statement would conflict with the parameter.
+	if e, ok := err.(*MyError); ok { // want "type assertion on error"
+		fmt.Println(e)
+	}
+
+	// ok is used in the body.
+	if target, ok := err.(*MyError); ok { // want "type assertion on error"
+		fmt.Println(target, ok)
+	}
+
+	// There is no statement list to declare target in.
+	if err == nil {
+		return
+	} else if target, ok := err.(*MyError); ok { // want "type assertion on error"
+		fmt.Println(target)
+	}
+
+	// Nothing to declare.
+	if _, ok := err.(*MyError); ok { // want "type assertion on error"
+		fmt.Println("mine")
+	}
+}
+
+func siblings(err, other error) {
+	if e, ok := err.(*MyError); ok { // want "type assertion on error"
+		fmt.Println(e.Code)
+	}
+
+	// The fix above declares e in the block already.
+	if e, ok := other.(*MyError); ok { // want "type assertion on error"
+		fmt.Println(e.Code)
+	}
+}
@@ -0,0 +1,12 @@
+// Command errcomparegodernize runs the errcompare analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/errcompare"
+)
+
+func main() {
+	singlechecker.Main(errcompare.Analyzer)
+}
@@ -0,0 +1,306 @@
+// Package errcompare provides an analyzer to detect errors compared with ==
+// or != against sentinel errors of other packages, which errors.Is handles.
+package errcompare
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for errors compared with == or != against sentinel errors
+
+This analyzer reports comparisons of an error with an exported error variable
+of another package, which miss the sentinel once it is wrapped, and suggests
+errors.Is:
+- err == sql.ErrNoRows -> errors.Is(err, sql.ErrNoRows)
+- err != fs.ErrNotExist -> !errors.Is(err, fs.ErrNotExist)
+
+Sentinels listed in -allow are not reported; io.EOF is allowed by default,
+since Read returns it unwrapped and documents comparing it with ==.
+Comparisons in Is methods, which implement errors.Is, are not reported. The
+fix adds the errors import when it is missing.`
+
+// defaultAllow is the default of -allow.
+const defaultAllow = "io.EOF"
+
+// Analyzer is the main analyzer for comparisons with sentinel errors.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "errcompare",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/errcompare",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.StringVar(&runner.allow, "allow", defaultAllow,
+		"comma-separated list of sentinel errors that may be compared with == and !=, "+
+			"named by package path and variable name as in io.EOF or io/fs.ErrNotExist")
+
+	return analyzer
+}
+
+type runner struct {
+	allow string
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.BinaryExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+	allowed := parseAllow(r.allow)
+
+	inspect.Nodes(nodeFilter, func(n ast.Node, push bool) bool {
+		if !push {
+			return true
+		}
+
+		if decl, ok := n.(*ast.FuncDecl); ok {
+			return !isIsMethod(pass.TypesInfo, decl)
+		}
+
+		expr, ok := n.(*ast.BinaryExpr)
+		if !ok || expr == nil {
+			return true
+		}
+
+		file := fileMap.File(expr.Pos())
+
+		if diagnostic := diagnoseBinaryExpr(pass, file, expr, allowed); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// parseAllow returns the set of sentinels in the comma-separated list allow.
+func parseAllow(allow string) map[string]bool {
+	allowed := make(map[string]bool)
+
+	for _, name := range strings.Split(allow, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			allowed[name] = true
+		}
+	}
+
+	return allowed
+}
+
+// isIsMethod reports whether decl is a method Is(error) bool, in which
+// comparing the target with == is how errors.Is matches are implemented.
+func isIsMethod(info *types.Info, decl *ast.FuncDecl) bool {
+	if decl.Recv == nil || decl.Name.Name != "Is" {
+		return false
+	}
+
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Params().Len() == 1 && isError(sig.Params().At(0).Type()) &&
+		sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool])
+}
+
+func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExpr,
+	allowed map[string]bool,
+) *analysis.Diagnostic {
+	if file == nil || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return nil
+	}
+
+	// The sentinel is usually on the right, as in err == io.EOF.
+	operand, sentinel := expr.X, sentinelVar(pass, expr.Y)
+	if sentinel == nil {
+		operand, sentinel = expr.Y, sentinelVar(pass, expr.X)
+	}
+
+	if sentinel == nil || !isError(pass.TypesInfo.TypeOf(operand)) {
+		return nil
+	}
+
+	if allowed[sentinel.Pkg().Path()+"."+sentinel.Name()] || shouldIgnore(pass.Fset, file, expr, "errcompare") {
+		return nil
+	}
+
+	// The operands become arguments, which need no parentheses.
+	operandText := formatNode(pass.Fset, ast.Unparen(operand))
+	sentinelText := formatNode(pass.Fset, ast.Unparen(operandOf(expr, operand)))
+
+	if operandText == "" || sentinelText == "" {
+		return nil
+	}
+
+	replacement := fmt.Sprintf("%s.Is(%s, %s)", importutil.LocalName(file, "errors"), operandText, sentinelText)
+	if expr.Op == token.NEQ {
+		replacement = "!" + replacement
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		NewText: []byte(replacement),
+	}}
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{"errors"}, nil)...)
+
+	return &analysis.Diagnostic{
+		Pos: expr.Pos(),
+		End: expr.End(),
+		Message: fmt.Sprintf("comparing with %s by %s misses wrapped errors, use %s instead",
+			sentinelText, expr.Op, replacement),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Replace with " + replacement,
+			TextEdits: edits,
+		}},
+	}
+}
+
+// operandOf returns the operand of expr other than operand.
+func operandOf(expr *ast.BinaryExpr, operand ast.Expr) ast.Expr {
+	if operand == expr.X {
+		return expr.Y
+	}
+
+	return expr.X
+}
+
+// sentinelVar returns the variable referenced by expr if it is an exported
+// package-level error variable of another package, as io.EOF is.
+func sentinelVar(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	var ident *ast.Ident
+
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+
+	v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Pkg() == pass.Pkg || !v.Exported() ||
+		v.Parent() != v.Pkg().Scope() || !isError(v.Type()) {
+		return nil
+	}
+
+	return v
+}
+
+// isError reports whether t is the predeclared error type.
+func isError(t types.Type) bool {
+	return t != nil && types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,34 @@
+package errcompare_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/errcompare"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errcompare.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, errcompare.Analyzer, "autofix")
+}
+
+// TestAllow checks that -allow replaces the default list, so io.EOF is
+// reported once it is not listed.
+func TestAllow(t *testing.T) {
+	if err := errcompare.Analyzer.Flags.Set("allow", "database/sql.ErrNoRows, io/fs.ErrNotExist"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = errcompare.Analyzer.Flags.Set("allow", "io.EOF")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errcompare.Analyzer, "allow")
+}
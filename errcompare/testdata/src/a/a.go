@@ -0,0 +1,55 @@
+package a
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// ErrLocal is a sentinel of this package, whose errors are not wrapped here.
+var ErrLocal = errors.New("local")
+
+func sentinels(err error) bool {
+	if err == sql.ErrNoRows { // want `comparing with sql.ErrNoRows by == misses wrapped errors, use errors.Is\(err, sql.ErrNoRows\) instead`
+		return false
+	}
+
+	if err != fs.ErrNotExist { // want `comparing with fs.ErrNotExist by != misses wrapped errors, use !errors.Is\(err, fs.ErrNotExist\) instead`
+		return true
+	}
+
+	return os.ErrClosed == err // want `comparing with os.ErrClosed by == misses wrapped errors, use errors.Is\(err, os.ErrClosed\) instead`
+}
+
+func allowed(r io.Reader, buf []byte) error {
+	for {
+		_, err := r.Read(buf)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func notReported(err, other error, value any) bool {
+	return err == ErrLocal || err == other || err == nil || value == io.ErrUnexpectedEOF
+}
+
+type notFound struct{}
+
+func (notFound) Error() string { return "not found" }
+
+// Is implements errors.Is, which compares targets with == itself.
+func (notFound) Is(target error) bool {
+	return target == fs.ErrNotExist
+}
+
+func ignored(err error) bool {
+	//godernize:ignore=errcompare
+	return err == io.ErrUnexpectedEOF
+}
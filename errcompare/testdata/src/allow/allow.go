@@ -0,0 +1,12 @@
+package allow
+
+import (
+	"database/sql"
+	"io"
+	"io/fs"
+)
+
+func compare(err error) bool {
+	return err == sql.ErrNoRows || err == fs.ErrNotExist ||
+		err == io.EOF // want `comparing with io.EOF by == misses wrapped errors`
+}
@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"database/sql"
+	"io/fs"
+)
+
+func found(err error) bool {
+	return err != sql.ErrNoRows // want "comparing with sql.ErrNoRows"
+}
+
+func missing(err error) bool {
+	return (err) == fs.ErrNotExist || fs.ErrPermission == err // want "comparing with fs.ErrNotExist" "comparing with fs.ErrPermission"
+}
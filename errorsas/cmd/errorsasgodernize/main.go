@@ -0,0 +1,12 @@
+// Command errorsasgodernize runs the errorsas analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/errorsas"
+)
+
+func main() {
+	singlechecker.Main(errorsas.Analyzer)
+}
@@ -0,0 +1,62 @@
+package a
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+)
+
+type valueError struct{}
+
+func (valueError) Error() string { return "value" }
+
+type pointerError struct{}
+
+func (*pointerError) Error() string { return "pointer" }
+
+type notError struct{}
+
+func valid(err error, target any) {
+	var ve valueError
+	_ = errors.As(err, &ve)
+
+	var pe *pointerError
+	_ = errors.As(err, &pe)
+
+	var pathErr *fs.PathError
+	_ = errors.As(err, &pathErr)
+
+	var netErr net.Error
+	_ = errors.As(err, &netErr)
+
+	var iface interface{ Timeout() bool }
+	_ = errors.As(err, &iface)
+
+	// The dynamic type of an interface target is unknown.
+	_ = errors.As(err, target)
+}
+
+func invalid(err error) {
+	var pe *pointerError
+	_ = errors.As(err, pe) // want `second argument to errors.As must be a non-nil pointer to a type implementing error or to an interface type, but has type \*pointerError; errors.As panics at run time`
+
+	var ve valueError
+	_ = errors.As(err, ve) // want `but has type valueError`
+
+	var pv pointerError
+	_ = errors.As(err, &pv) // want `but has type \*pointerError`
+
+	var ne notError
+	_ = errors.As(err, &ne) // want `but has type \*notError`
+
+	var s string
+	_ = errors.As(err, &s) // want `but has type \*string`
+
+	_ = errors.As(err, nil) // want `but has type untyped nil`
+}
+
+//godernize:ignore=errorsas
+func ignored(err error) {
+	var ve valueError
+	_ = errors.As(err, ve)
+}
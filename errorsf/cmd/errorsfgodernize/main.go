@@ -0,0 +1,12 @@
+// Command errorsfgodernize runs the errorsf analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/errorsf"
+)
+
+func main() {
+	singlechecker.Main(errorsf.Analyzer)
+}
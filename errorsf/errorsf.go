@@ -0,0 +1,325 @@
+// Package errorsf provides an analyzer to detect errors.New(fmt.Sprintf(...))
+// calls that can be replaced with fmt.Errorf.
+package errorsf
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for errors.New(fmt.Sprintf(...)) calls
+
+This analyzer reports errors.New calls whose only argument is a fmt.Sprintf
+call and suggests the equivalent fmt.Errorf:
+- errors.New(fmt.Sprintf(format, args...)) -> fmt.Errorf(format, args...)
+
+The fix removes the errors import when it is no longer used.`
+
+// Analyzer is the main analyzer for errors.New(fmt.Sprintf(...)) calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorsf",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/errorsf",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the errors import.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic := diagnoseCallExpr(pass, file, call, stack)
+		if diagnostic == nil {
+			return true
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, call: call})
+
+		return true
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the errors.New call it reports.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	call       *ast.CallExpr
+}
+
+// consolidateFixes removes the errors import in the fixes of file when the
+// replaced calls held its last references. With several fixes, only the
+// first carries the rewrites of all of them, so that applying every fix of
+// the file does not remove the import twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		// The arguments of fmt.Sprintf are kept and may refer to errors
+		// themselves.
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.call.Fun)
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	if !importutil.UsedOutside(pass.TypesInfo, file, "errors", replaced...) {
+		edits = append(edits, importutil.Edits(pass.Fset, file, nil, []string{"errors"})...)
+	}
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	sprintf := sprintfArg(pass.TypesInfo, call)
+	if sprintf == nil || shouldIgnore(pass.Fset, file, call, "errorsf") {
+		return nil
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "errors.New(fmt.Sprintf(...)) can be replaced with fmt.Errorf(...)",
+	}
+
+	// The fix of an enclosing call rewrites this one's text too, so only the
+	// outermost call gets a fix to keep edits from overlapping.
+	if hasEnclosingCandidate(pass.TypesInfo, stack) {
+		return diagnostic
+	}
+
+	args := make([]string, len(sprintf.Args))
+	for i, arg := range sprintf.Args {
+		args[i] = formatNode(pass.Fset, arg)
+		if args[i] == "" {
+			return diagnostic
+		}
+	}
+
+	ellipsis := ""
+	if sprintf.Ellipsis.IsValid() {
+		ellipsis = "..."
+	}
+
+	replacement := errorfName(sprintf) + "(" + strings.Join(args, ", ") + ellipsis + ")"
+
+	// The errors import is removed by consolidateFixes.
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace with fmt.Errorf",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			NewText: []byte(replacement),
+		}},
+	}}
+
+	return diagnostic
+}
+
+// sprintfArg returns the fmt.Sprintf call if call is errors.New(fmt.Sprintf(...)).
+func sprintfArg(info *types.Info, call *ast.CallExpr) *ast.CallExpr {
+	if len(call.Args) != 1 || !isPkgFunc(info, call, "errors", "New") {
+		return nil
+	}
+
+	sprintf, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok || len(sprintf.Args) == 0 || !isPkgFunc(info, sprintf, "fmt", "Sprintf") {
+		return nil
+	}
+
+	return sprintf
+}
+
+func hasEnclosingCandidate(info *types.Info, stack []ast.Node) bool {
+	// The last element of the stack is the call itself.
+	for i := len(stack) - 2; i >= 0; i-- {
+		if call, ok := stack[i].(*ast.CallExpr); ok && sprintfArg(info, call) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// errorfName returns the qualified name of fmt.Errorf matching how
+// fmt.Sprintf is referenced, honoring aliased and dot imports.
+func errorfName(sprintf *ast.CallExpr) string {
+	if sel, ok := ast.Unparen(sprintf.Fun).(*ast.SelectorExpr); ok {
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			return ident.Name + ".Errorf"
+		}
+	}
+
+	return "Errorf"
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
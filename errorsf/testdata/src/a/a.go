@@ -0,0 +1,42 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type formatter struct{}
+
+func (formatter) Sprintf(format string, args ...any) string { return format }
+
+func basic(name string) error {
+	return errors.New(fmt.Sprintf("unknown name %q", name)) // want `errors.New\(fmt.Sprintf\(...\)\) can be replaced with fmt.Errorf\(...\)`
+}
+
+func parenthesized(args []any) error {
+	return errors.New((fmt.Sprintf("%v %v", args...))) // want `errors.New\(fmt.Sprintf\(...\)\) can be replaced with fmt.Errorf\(...\)`
+}
+
+func shadowed(name string) error {
+	fmt := formatter{}
+
+	return errors.New(fmt.Sprintf("unknown name %q", name))
+}
+
+func notSprintf(n int) error {
+	return errors.New(strconv.Itoa(n))
+}
+
+func plain() error {
+	return errors.New("plain")
+}
+
+func sprint(n int) error {
+	return errors.New(fmt.Sprint(n))
+}
+
+//godernize:ignore=errorsf
+func ignored(name string) error {
+	return errors.New(fmt.Sprintf("unknown name %q", name))
+}
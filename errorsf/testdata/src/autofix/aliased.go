@@ -0,0 +1,11 @@
+package autofix
+
+import (
+	errs "errors"
+	f "fmt"
+	"strings"
+)
+
+func aliased(parts []string) error {
+	return errs.New(f.Sprintf(`bad path "%s"`, strings.Join(parts, "/"))) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+}
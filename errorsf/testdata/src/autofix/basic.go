@@ -0,0 +1,10 @@
+package autofix
+
+import (
+	"errors"
+	"fmt"
+)
+
+func basic(name string, line int) error {
+	return errors.New(fmt.Sprintf("%s:%d: unexpected token", name, line+1)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+}
@@ -0,0 +1,11 @@
+package autofix
+
+import . "fmt"
+
+import (
+	"errors"
+)
+
+func dotImport(n int) error {
+	return errors.New(Sprintf("bad value %d", n)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+}
@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNotFound = errors.New("not found")
+
+func keep(key string, args []any) error {
+	if key == "" {
+		return errors.New(fmt.Sprintf("empty key, args %v", args...)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+	}
+
+	return errors.New(fmt.Sprintf("key %q: %v", key, errNotFound)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+}
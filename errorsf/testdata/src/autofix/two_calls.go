@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"errors"
+	"fmt"
+)
+
+func twoCalls(key string, n int) error {
+	if n < 0 {
+		return errors.New(fmt.Sprintf("%s: negative count %d", key, n)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+	}
+
+	return errors.New(fmt.Sprintf("%s: count %d", key, n)) // want "errors.New\\(fmt.Sprintf\\(...\\)\\) can be replaced with fmt.Errorf\\(...\\)"
+}
@@ -0,0 +1,12 @@
+// Command expstdgodernize runs the expstd analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/expstd"
+)
+
+func main() {
+	singlechecker.Main(expstd.Analyzer)
+}
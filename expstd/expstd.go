@@ -0,0 +1,300 @@
+// Package expstd provides an analyzer to detect golang.org/x/exp packages that
+// have standard library replacements.
+package expstd
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for golang.org/x/exp packages that moved to the standard library
+
+This analyzer reports imports of golang.org/x/exp/slices and golang.org/x/exp/maps
+and suggests the standard slices and maps packages (Go 1.21). The import path
+is rewritten only when every function used from the package has a compatible
+standard library counterpart; otherwise the incompatible calls are listed, e.g.
+x/exp maps.Keys returns a slice while the standard maps.Keys returns an iterator.
+Files built for Go versions before 1.21 are skipped.`
+
+// Analyzer is the main analyzer for golang.org/x/exp imports.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "expstd",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/expstd",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// compatibility describes which functions of an x/exp package behave the same
+// as their standard library counterparts.
+type compatibility struct {
+	stdPath string
+	// compatible lists functions with identical signatures and behavior.
+	compatible []string
+	// cmpFuncs lists functions that are compatible only in x/exp versions
+	// whose comparison function returns an int rather than a bool.
+	cmpFuncs []string
+}
+
+//nolint:gochecknoglobals // static compatibility table
+var packages = map[string]compatibility{
+	"golang.org/x/exp/slices": {
+		stdPath: "slices",
+		compatible: []string{
+			"BinarySearch", "BinarySearchFunc", "Clip", "Clone", "Compact", "CompactFunc",
+			"Compare", "CompareFunc", "Contains", "ContainsFunc", "Delete", "DeleteFunc",
+			"Equal", "EqualFunc", "Grow", "Index", "IndexFunc", "Insert", "IsSorted",
+			"Max", "Min", "Replace", "Reverse", "Sort",
+		},
+		cmpFuncs: []string{"IsSortedFunc", "MaxFunc", "MinFunc", "SortFunc", "SortStableFunc"},
+	},
+	"golang.org/x/exp/maps": {
+		stdPath:    "maps",
+		compatible: []string{"Clone", "Copy", "DeleteFunc", "Equal", "EqualFunc"},
+	},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		file, ok := n.(*ast.File)
+		if !ok || file == nil || !supportsStd(pass, file) {
+			return
+		}
+
+		for _, spec := range file.Imports {
+			if diagnostic := diagnoseImport(pass, file, spec); diagnostic != nil {
+				pass.Report(*diagnostic)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// supportsStd reports whether the file is compiled with a Go version that has
+// the standard slices and maps packages. Files without version information are
+// assumed to be recent enough.
+func supportsStd(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.21") >= 0
+}
+
+func diagnoseImport(pass *analysis.Pass, file *ast.File, spec *ast.ImportSpec) *analysis.Diagnostic {
+	expPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return nil
+	}
+
+	compat, ok := packages[expPath]
+	if !ok || shouldIgnore(pass.Fset, file, spec, "expstd") {
+		return nil
+	}
+
+	if incompatible := incompatibleCalls(pass.TypesInfo, file, expPath, compat); len(incompatible) > 0 {
+		return &analysis.Diagnostic{
+			Pos: spec.Pos(),
+			End: spec.End(),
+			Message: fmt.Sprintf("%s can be replaced with %s, but %s incompatible with the standard library",
+				expPath, compat.stdPath, describe(incompatible)),
+		}
+	}
+
+	message := fmt.Sprintf("%s can be replaced with %s", expPath, compat.stdPath)
+
+	if importutil.Name(file, compat.stdPath) != "" {
+		// Merging two imports of the same package name requires renaming.
+		return &analysis.Diagnostic{
+			Pos:     spec.Pos(),
+			End:     spec.End(),
+			Message: message + ", which is already imported",
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     spec.Pos(),
+		End:     spec.End(),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Replace import with %q", compat.stdPath),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     spec.Path.Pos(),
+				End:     spec.Path.End(),
+				NewText: []byte(strconv.Quote(compat.stdPath)),
+			}},
+		}},
+	}
+}
+
+// incompatibleCalls returns the sorted names of functions used from expPath
+// in file that lack a compatible standard library counterpart.
+func incompatibleCalls(info *types.Info, file *ast.File, expPath string, compat compatibility) []string {
+	found := make(map[string]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		pkgName, ok := info.Uses[ident].(*types.PkgName)
+		if !ok || pkgName.Imported().Path() != expPath {
+			return true
+		}
+
+		if !isCompatible(info, sel.Sel, compat) {
+			found[ident.Name+"."+sel.Sel.Name] = true
+		}
+
+		return true
+	})
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func isCompatible(info *types.Info, sel *ast.Ident, compat compatibility) bool {
+	if slices.Contains(compat.compatible, sel.Name) {
+		return true
+	}
+
+	if !slices.Contains(compat.cmpFuncs, sel.Name) {
+		return false
+	}
+
+	fn, ok := info.Uses[sel].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Params().Len() == 0 {
+		return false
+	}
+
+	// The comparison function is always the last parameter.
+	cmpFunc, ok := sig.Params().At(sig.Params().Len() - 1).Type().Underlying().(*types.Signature)
+	if !ok || cmpFunc.Results().Len() != 1 {
+		return false
+	}
+
+	result, ok := cmpFunc.Results().At(0).Type().Underlying().(*types.Basic)
+
+	return ok && result.Kind() == types.Int
+}
+
+func describe(names []string) string {
+	verb := "is"
+	if len(names) > 1 {
+		verb = "are"
+	}
+
+	return strings.Join(names, ", ") + " " + verb
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
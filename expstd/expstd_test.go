@@ -0,0 +1,33 @@
+package expstd_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/expstd"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, expstd.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, expstd.Analyzer, "autofix")
+}
+
+// TestGoVersion checks that modules before Go 1.21, which lack the standard
+// slices and maps packages, are skipped.
+func TestGoVersion(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "src", "old"), expstd.Analyzer, "./...")
+}
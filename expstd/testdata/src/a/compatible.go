@@ -0,0 +1,13 @@
+package a
+
+import (
+	"golang.org/x/exp/maps"   // want `golang.org/x/exp/maps can be replaced with maps`
+	"golang.org/x/exp/slices" // want `golang.org/x/exp/slices can be replaced with slices`
+)
+
+func compatible(s []int, m map[string]int) bool {
+	s = slices.Insert(s, 0, 1)
+	slices.SortFunc(s, func(a, b int) int { return a - b })
+
+	return slices.Contains(s, 1) && len(maps.Clone(m)) > 0
+}
@@ -0,0 +1,10 @@
+package a
+
+import (
+	//godernize:ignore=expstd
+	xslices "golang.org/x/exp/slices"
+)
+
+func ignored(s []int) int {
+	return xslices.Index(s, 1)
+}
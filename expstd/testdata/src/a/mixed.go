@@ -0,0 +1,13 @@
+package a
+
+import (
+	"golang.org/x/exp/maps"   // want `golang.org/x/exp/maps can be replaced with maps, but maps.Clear, maps.Keys are incompatible with the standard library`
+	"golang.org/x/exp/slices" // want `golang.org/x/exp/slices can be replaced with slices, but slices.SortStableFunc is incompatible with the standard library`
+)
+
+func mixed(s []int, m map[string]int) []string {
+	slices.SortStableFunc(s, func(a, b int) bool { return a < b })
+	maps.Clear(m)
+
+	return maps.Keys(m)
+}
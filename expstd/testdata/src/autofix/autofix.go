@@ -0,0 +1,12 @@
+package autofix
+
+import (
+	"fmt"
+
+	xmaps "golang.org/x/exp/maps" // want `golang.org/x/exp/maps can be replaced with maps`
+	"golang.org/x/exp/slices"     // want `golang.org/x/exp/slices can be replaced with slices`
+)
+
+func compatible(s []int, m map[string]int) {
+	fmt.Println(slices.Contains(s, 1), xmaps.Clone(m))
+}
@@ -0,0 +1,10 @@
+// Package maps is a stub of golang.org/x/exp/maps for tests.
+package maps
+
+func Keys[M ~map[K]V, K comparable, V any](m M) []K { return nil }
+
+func Values[M ~map[K]V, K comparable, V any](m M) []V { return nil }
+
+func Clone[M ~map[K]V, K comparable, V any](m M) M { return m }
+
+func Clear[M ~map[K]V, K comparable, V any](m M) {}
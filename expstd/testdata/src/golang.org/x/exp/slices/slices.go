@@ -0,0 +1,12 @@
+// Package slices is a stub of golang.org/x/exp/slices for tests.
+package slices
+
+func Contains[E comparable](s []E, v E) bool { return false }
+
+func Index[E comparable](s []E, v E) int { return -1 }
+
+func Insert[E any](s []E, i int, v ...E) []E { return s }
+
+func SortFunc[E any](x []E, cmp func(a, b E) int) {}
+
+func SortStableFunc[E any](x []E, less func(a, b E) bool) {}
@@ -0,0 +1,4 @@
+// Package slices is a stub of golang.org/x/exp/slices for tests.
+package slices
+
+func Contains[E comparable](s []E, v E) bool { return false }
@@ -0,0 +1,7 @@
+module old
+
+go 1.20
+
+require golang.org/x/exp v0.0.0
+
+replace golang.org/x/exp => ./exp
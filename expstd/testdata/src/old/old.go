@@ -0,0 +1,8 @@
+package old
+
+import "golang.org/x/exp/slices"
+
+// The standard slices package does not exist before Go 1.21.
+func contains(s []int) bool {
+	return slices.Contains(s, 1)
+}
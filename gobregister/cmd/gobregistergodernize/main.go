@@ -0,0 +1,12 @@
+// Command gobregistergodernize runs the gobregister analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/gobregister"
+)
+
+func main() {
+	singlechecker.Main(gobregister.Analyzer)
+}
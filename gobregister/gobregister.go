@@ -0,0 +1,250 @@
+// Package gobregister provides an analyzer to detect gob encoding of
+// interface values in packages that never register concrete types.
+package gobregister
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const gobPath = "encoding/gob"
+
+// Doc describes what this analyzer does.
+const Doc = `check for gob encoding of interface values without gob.Register
+
+This analyzer reports gob.Encoder.Encode calls whose argument contains an
+interface-typed value, for example through a struct field or a pointer to an
+interface, in packages that never call gob.Register or gob.RegisterName.
+Encoding an interface value fails at run time unless its concrete type is
+registered. Registrations made by other packages are not seen, so the check
+is a heuristic. No fix is offered.`
+
+// Analyzer is the main analyzer for unregistered gob interface values.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "gobregister",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/gobregister",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	var encodes []*ast.CallExpr
+
+	registered := false
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		switch {
+		case isPkgFunc(pass.TypesInfo, call, gobPath, "Register"),
+			isPkgFunc(pass.TypesInfo, call, gobPath, "RegisterName"):
+			registered = true
+		case isEncode(pass.TypesInfo, call):
+			encodes = append(encodes, call)
+		}
+	})
+
+	if registered {
+		return nil, nil
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	for _, call := range encodes {
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseEncode(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+func diagnoseEncode(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) != 1 {
+		return nil
+	}
+
+	typ := pass.TypesInfo.TypeOf(call.Args[0])
+
+	// The dynamic type of a top-level interface value is encoded as is.
+	if typ == nil || types.IsInterface(typ) {
+		return nil
+	}
+
+	iface := findInterface(typ, make(map[types.Type]bool))
+	if iface == nil || shouldIgnore(pass.Fset, file, call, "gobregister") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("gob encoding of a value containing interface type %s fails at run time "+
+			"unless its concrete types are registered, but this package never calls gob.Register",
+			types.TypeString(iface, types.RelativeTo(pass.Pkg))),
+	}
+}
+
+// findInterface returns the first interface type reachable from typ through
+// pointers, struct fields, and element types, or nil if there is none.
+func findInterface(typ types.Type, seen map[types.Type]bool) types.Type {
+	if seen[typ] {
+		return nil
+	}
+
+	seen[typ] = true
+
+	if types.IsInterface(typ) {
+		return typ
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Pointer:
+		return findInterface(t.Elem(), seen)
+	case *types.Slice:
+		return findInterface(t.Elem(), seen)
+	case *types.Array:
+		return findInterface(t.Elem(), seen)
+	case *types.Map:
+		if iface := findInterface(t.Key(), seen); iface != nil {
+			return iface
+		}
+
+		return findInterface(t.Elem(), seen)
+	case *types.Struct:
+		for i := range t.NumFields() {
+			field := t.Field(i)
+
+			// gob ignores unexported fields.
+			if !field.Exported() {
+				continue
+			}
+
+			if iface := findInterface(field.Type(), seen); iface != nil {
+				return iface
+			}
+		}
+	}
+
+	return nil
+}
+
+// isEncode checks if call is a method call of gob.Encoder.Encode.
+func isEncode(info *types.Info, call *ast.CallExpr) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != gobPath || fn.Name() != "Encode" {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() != nil
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
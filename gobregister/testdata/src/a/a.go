@@ -0,0 +1,64 @@
+package a
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+type Shape interface{ Area() float64 }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Drawing struct {
+	Name   string
+	Shapes []Shape
+}
+
+type Labeled struct {
+	Label string
+	shape Shape
+}
+
+type Tree struct {
+	Value    int
+	Children []*Tree
+}
+
+type Attributes struct {
+	Values map[string]any
+}
+
+func encodeDrawing(w io.Writer, d Drawing) error {
+	return gob.NewEncoder(w).Encode(d) // want `gob encoding of a value containing interface type Shape fails at run time unless its concrete types are registered, but this package never calls gob.Register`
+}
+
+func encodeInterfacePointer(enc *gob.Encoder, s Shape) error {
+	return enc.Encode(&s) // want `containing interface type Shape`
+}
+
+func encodeMap(enc *gob.Encoder, attrs *Attributes) error {
+	return enc.Encode(attrs) // want `containing interface type any`
+}
+
+func encodeConcrete(enc *gob.Encoder, sq Square, tree *Tree) error {
+	if err := enc.Encode(sq); err != nil {
+		return err
+	}
+
+	return enc.Encode(tree)
+}
+
+func encodeTopLevelInterface(enc *gob.Encoder, s Shape) error {
+	return enc.Encode(s)
+}
+
+func encodeUnexportedField(enc *gob.Encoder, l Labeled) error {
+	return enc.Encode(l)
+}
+
+//godernize:ignore=gobregister
+func ignored(enc *gob.Encoder, d Drawing) error {
+	return enc.Encode(d)
+}
@@ -0,0 +1,7 @@
+package registered
+
+import "encoding/gob"
+
+func init() {
+	gob.Register(Square{})
+}
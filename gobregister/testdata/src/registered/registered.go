@@ -0,0 +1,20 @@
+package registered
+
+import (
+	"encoding/gob"
+	"io"
+)
+
+type Shape interface{ Area() float64 }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+type Drawing struct {
+	Shapes []Shape
+}
+
+func encode(w io.Writer, d Drawing) error {
+	return gob.NewEncoder(w).Encode(d)
+}
@@ -0,0 +1,316 @@
+package godernize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/jaeyeom/godernize/atomicalign"
+	"github.com/jaeyeom/godernize/base64pad"
+	"github.com/jaeyeom/godernize/bytesbuffer"
+	"github.com/jaeyeom/godernize/chmodrace"
+	"github.com/jaeyeom/godernize/clearbuiltin"
+	"github.com/jaeyeom/godernize/containsidx"
+	"github.com/jaeyeom/godernize/ctxnil"
+	"github.com/jaeyeom/godernize/ctxvaluekey"
+	"github.com/jaeyeom/godernize/deprecatedsym"
+	"github.com/jaeyeom/godernize/durationunits"
+	"github.com/jaeyeom/godernize/envparse"
+	"github.com/jaeyeom/godernize/erras"
+	"github.com/jaeyeom/godernize/errcompare"
+	"github.com/jaeyeom/godernize/errorsas"
+	"github.com/jaeyeom/godernize/errorsf"
+	"github.com/jaeyeom/godernize/expstd"
+	"github.com/jaeyeom/godernize/gobregister"
+	"github.com/jaeyeom/godernize/grpcdial"
+	"github.com/jaeyeom/godernize/grpcinsecure"
+	"github.com/jaeyeom/godernize/headervalues"
+	"github.com/jaeyeom/godernize/httpreqctx"
+	"github.com/jaeyeom/godernize/logfatal"
+	"github.com/jaeyeom/godernize/mapscollect"
+	"github.com/jaeyeom/godernize/marshalerr"
+	"github.com/jaeyeom/godernize/mathpow"
+	"github.com/jaeyeom/godernize/minmax"
+	"github.com/jaeyeom/godernize/netcontext"
+	"github.com/jaeyeom/godernize/numgoroutine"
+	"github.com/jaeyeom/godernize/oserrors"
+	"github.com/jaeyeom/godernize/panicsprint"
+	"github.com/jaeyeom/godernize/randseed"
+	"github.com/jaeyeom/godernize/rangeint"
+	"github.com/jaeyeom/godernize/readfile"
+	"github.com/jaeyeom/godernize/reflectcopy"
+	"github.com/jaeyeom/godernize/removeall"
+	"github.com/jaeyeom/godernize/replaceall"
+	"github.com/jaeyeom/godernize/sepjoin"
+	"github.com/jaeyeom/godernize/slicessortstable"
+	"github.com/jaeyeom/godernize/sortslices"
+	"github.com/jaeyeom/godernize/sprintfstr"
+	"github.com/jaeyeom/godernize/tempcleanup"
+	"github.com/jaeyeom/godernize/timeafterleak"
+	"github.com/jaeyeom/godernize/timeequal"
+	"github.com/jaeyeom/godernize/timesince"
+	"github.com/jaeyeom/godernize/ttempdir"
+	"github.com/jaeyeom/godernize/withtimeout"
+)
+
+// Result is a diagnostic reported by one of the analyzers.
+type Result struct {
+	// Analyzer is the name of the analyzer that reported the diagnostic.
+	Analyzer string
+	// Pos and End delimit the reported range. End is invalid if the
+	// analyzer reported a position only.
+	Pos token.Position
+	End token.Position
+	// Category is the optional category of the diagnostic, such as "error".
+	Category string
+	Message  string
+	// SuggestedFixes are the alternative fixes offered for the diagnostic.
+	SuggestedFixes []SuggestedFix
+}
+
+// SuggestedFix is a set of edits that resolves a diagnostic.
+type SuggestedFix struct {
+	Message string
+	Edits   []TextEdit
+}
+
+// TextEdit replaces the text between Pos and End with NewText. Pos and End
+// are equal for insertions.
+type TextEdit struct {
+	Pos     token.Position
+	End     token.Position
+	NewText string
+}
+
+// Observer receives metrics of the analyzer runs of CheckWithObserver, such
+// as to aggregate statistics over many repositories. It is called
+// sequentially once analysis has finished.
+type Observer interface {
+	// ObserveRun is called once for each analyzer and package.
+	ObserveRun(run RunMetrics)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(run RunMetrics)
+
+// ObserveRun calls f(run).
+func (f ObserverFunc) ObserveRun(run RunMetrics) {
+	f(run)
+}
+
+// RunMetrics describes the run of one analyzer on one package.
+type RunMetrics struct {
+	Analyzer string
+	// Package is the ID of the package, which tells a package and its test
+	// variant apart.
+	Package  string
+	Duration time.Duration
+	// Diagnostics counts the diagnostics by file name and then by category,
+	// such as ctxnil.CategorySimplify. Diagnostics without a category are
+	// counted under "". Unlike the results of Check, diagnostics in files
+	// shared by several packages are counted for each of them.
+	Diagnostics map[string]map[string]int
+	// Err is the error of the run, if any.
+	Err error
+}
+
+// Analyzers returns every analyzer run by godernizecheck. Opt-in analyzers
+// such as listslice and transportcfg are not included.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{
+		atomicalign.Analyzer,
+		base64pad.Analyzer,
+		bytesbuffer.Analyzer,
+		chmodrace.Analyzer,
+		clearbuiltin.Analyzer,
+		containsidx.Analyzer,
+		ctxnil.Analyzer,
+		ctxvaluekey.Analyzer,
+		deprecatedsym.Analyzer,
+		durationunits.Analyzer,
+		envparse.Analyzer,
+		erras.Analyzer,
+		errcompare.Analyzer,
+		errorsas.Analyzer,
+		errorsf.Analyzer,
+		expstd.Analyzer,
+		gobregister.Analyzer,
+		grpcdial.Analyzer,
+		grpcinsecure.Analyzer,
+		headervalues.Analyzer,
+		httpreqctx.Analyzer,
+		logfatal.Analyzer,
+		mapscollect.Analyzer,
+		marshalerr.Analyzer,
+		mathpow.Analyzer,
+		minmax.Analyzer,
+		netcontext.Analyzer,
+		numgoroutine.Analyzer,
+		oserrors.Analyzer,
+		panicsprint.Analyzer,
+		randseed.Analyzer,
+		rangeint.Analyzer,
+		readfile.Analyzer,
+		reflectcopy.Analyzer,
+		removeall.Analyzer,
+		replaceall.Analyzer,
+		sepjoin.Analyzer,
+		slicessortstable.Analyzer,
+		sortslices.Analyzer,
+		sprintfstr.Analyzer,
+		tempcleanup.Analyzer,
+		timeafterleak.Analyzer,
+		timeequal.Analyzer,
+		timesince.Analyzer,
+		ttempdir.Analyzer,
+		withtimeout.Analyzer,
+	}
+}
+
+// Check loads the packages matching patterns, including their tests, from the
+// current directory and runs every analyzer on them in a single pass.
+// Diagnostics in files shared by several packages, such as a package and its
+// test variant, are returned once.
+func Check(ctx context.Context, patterns ...string) ([]Result, error) {
+	return CheckWithObserver(ctx, nil, patterns...)
+}
+
+// CheckWithObserver is like Check and also reports the metrics of every
+// analyzer run to observer, unless it is nil. Runs are reported before the
+// error of a failed run is returned.
+func CheckWithObserver(ctx context.Context, observer Observer, patterns ...string) ([]Result, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    packages.LoadAllSyntax | packages.NeedModule,
+		Tests:   true,
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		// The loader does not wrap the cancellation error.
+		return nil, ctxErr
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	if err := loadErrors(pkgs); err != nil {
+		return nil, err
+	}
+
+	graph, err := checker.Analyze(Analyzers(), pkgs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze packages: %w", err)
+	}
+
+	type key struct {
+		analyzer string
+		pos      token.Position
+		message  string
+	}
+
+	seen := make(map[key]bool)
+
+	var results []Result
+
+	if observer != nil {
+		for _, act := range graph.Roots {
+			observer.ObserveRun(newRunMetrics(act))
+		}
+	}
+
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act, act.Err)
+		}
+
+		fset := act.Package.Fset
+
+		for _, diagnostic := range act.Diagnostics {
+			result := newResult(fset, act.Analyzer.Name, diagnostic)
+
+			k := key{result.Analyzer, result.Pos, result.Message}
+			if seen[k] {
+				continue
+			}
+
+			seen[k] = true
+
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// loadErrors joins the errors of pkgs and their dependencies.
+func loadErrors(pkgs []*packages.Package) error {
+	var errs []error
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+func newRunMetrics(act *checker.Action) RunMetrics {
+	run := RunMetrics{
+		Analyzer:    act.Analyzer.Name,
+		Package:     act.Package.ID,
+		Duration:    act.Duration,
+		Diagnostics: make(map[string]map[string]int),
+		Err:         act.Err,
+	}
+
+	for _, diagnostic := range act.Diagnostics {
+		filename := act.Package.Fset.Position(diagnostic.Pos).Filename
+		if run.Diagnostics[filename] == nil {
+			run.Diagnostics[filename] = make(map[string]int)
+		}
+
+		run.Diagnostics[filename][diagnostic.Category]++
+	}
+
+	return run
+}
+
+func newResult(fset *token.FileSet, analyzer string, diagnostic analysis.Diagnostic) Result {
+	result := Result{
+		Analyzer: analyzer,
+		Pos:      fset.Position(diagnostic.Pos),
+		End:      fset.Position(diagnostic.End),
+		Category: diagnostic.Category,
+		Message:  diagnostic.Message,
+	}
+
+	for _, fix := range diagnostic.SuggestedFixes {
+		edits := make([]TextEdit, len(fix.TextEdits))
+
+		for i, edit := range fix.TextEdits {
+			end := edit.End
+			if !end.IsValid() {
+				end = edit.Pos
+			}
+
+			edits[i] = TextEdit{
+				Pos:     fset.Position(edit.Pos),
+				End:     fset.Position(end),
+				NewText: string(edit.NewText),
+			}
+		}
+
+		result.SuggestedFixes = append(result.SuggestedFixes, SuggestedFix{Message: fix.Message, Edits: edits})
+	}
+
+	return result
+}
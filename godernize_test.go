@@ -0,0 +1,176 @@
+package godernize_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jaeyeom/godernize"
+)
+
+func TestAnalyzers(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]bool)
+
+	for _, analyzer := range godernize.Analyzers() {
+		if seen[analyzer.Name] {
+			t.Errorf("analyzer %s is listed twice", analyzer.Name)
+		}
+
+		seen[analyzer.Name] = true
+	}
+
+	for _, name := range []string{"ctxnil", "oserrors", "timesince"} {
+		if !seen[name] {
+			t.Errorf("Analyzers() does not include %s", name)
+		}
+	}
+
+	for _, name := range []string{"listslice", "transportcfg"} {
+		if seen[name] {
+			t.Errorf("Analyzers() includes the opt-in %s analyzer", name)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	results, err := godernize.Check(context.Background(), "./cmd/godernizecheck/testdata/src/mixed")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	counts := make(map[string]int)
+
+	for _, result := range results {
+		counts[result.Analyzer]++
+
+		if filepath.Base(result.Pos.Filename) != "mixed.go" || result.Pos.Line == 0 || result.Message == "" {
+			t.Errorf("result %+v has no valid position or message", result)
+		}
+	}
+
+	if len(results) != 7 || counts["ctxnil"] != 3 || counts["oserrors"] != 4 {
+		t.Errorf("got %d results by analyzer %v, want 3 ctxnil and 4 oserrors", len(results), counts)
+	}
+}
+
+// TestCheckFileIgnore checks that a plain file-level //godernize:ignore
+// suppresses every analyzer, by comparing a file carrying it with a copy of
+// the same code without it.
+func TestCheckFileIgnore(t *testing.T) {
+	t.Parallel()
+
+	results, err := godernize.Check(context.Background(), "./testdata/src/fileignore")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	reported := make(map[string]bool)
+
+	for _, result := range results {
+		if filepath.Base(result.Pos.Filename) != "reported.go" {
+			t.Errorf("%s reported %q in %s, which ignores every analyzer", result.Analyzer, result.Message, result.Pos)
+		}
+
+		reported[result.Analyzer] = true
+	}
+
+	for _, name := range []string{"ctxnil", "oserrors", "rangeint", "sortslices", "sprintfstr", "timesince"} {
+		if !reported[name] {
+			t.Errorf("%s reported nothing in reported.go, got results by %v", name, reported)
+		}
+	}
+}
+
+func TestCheckWithObserver(t *testing.T) {
+	t.Parallel()
+
+	var runs []godernize.RunMetrics
+
+	observer := godernize.ObserverFunc(func(run godernize.RunMetrics) {
+		runs = append(runs, run)
+	})
+
+	results, err := godernize.CheckWithObserver(context.Background(), observer, "./cmd/godernizecheck/testdata/src/mixed")
+	if err != nil {
+		t.Fatalf("CheckWithObserver failed: %v", err)
+	}
+
+	observed := make(map[string]bool)
+	counts := make(map[string]int)
+
+	for _, run := range runs {
+		observed[run.Analyzer] = true
+
+		if run.Package == "" || run.Duration < 0 || run.Err != nil {
+			t.Errorf("run %+v has no package, a negative duration, or an error", run)
+		}
+
+		for filename, categories := range run.Diagnostics {
+			if filepath.Base(filename) != "mixed.go" {
+				t.Errorf("%s: got diagnostics in %s, want only mixed.go", run.Analyzer, filename)
+			}
+
+			for category, n := range categories {
+				if run.Analyzer == "ctxnil" && !strings.HasPrefix(category, "ctxnil.") {
+					t.Errorf("ctxnil: got category %q, want a ctxnil category", category)
+				}
+
+				counts[run.Analyzer] += n
+			}
+		}
+	}
+
+	for _, analyzer := range godernize.Analyzers() {
+		if !observed[analyzer.Name] {
+			t.Errorf("no run of %s was observed", analyzer.Name)
+		}
+	}
+
+	if len(results) != 7 || counts["ctxnil"] != 3 || counts["oserrors"] != 4 {
+		t.Errorf("got %d results and counts %v, want 3 ctxnil and 4 oserrors", len(results), counts)
+	}
+}
+
+func TestCheckSuggestedFixes(t *testing.T) {
+	t.Parallel()
+
+	results, err := godernize.Check(context.Background(), "./timesince/testdata/src/dot")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %+v", len(results), results)
+	}
+
+	result := results[0]
+	if result.Analyzer != "timesince" || result.Pos.Line != 6 || !result.End.IsValid() {
+		t.Errorf("result = %+v, want a timesince range on line 6", result)
+	}
+
+	if len(result.SuggestedFixes) != 1 || len(result.SuggestedFixes[0].Edits) != 1 {
+		t.Fatalf("suggested fixes = %+v, want one fix with one edit", result.SuggestedFixes)
+	}
+
+	edit := result.SuggestedFixes[0].Edits[0]
+	if edit.Pos != result.Pos || edit.End != result.End || edit.NewText != "Since(start)" {
+		t.Errorf("edit = %+v, want the call replaced with Since(start)", edit)
+	}
+}
+
+func TestCheckCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := godernize.Check(ctx, "./cmd/godernizecheck/testdata/src/mixed"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Check with a canceled context returned %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,12 @@
+// Command grpcdialgodernize runs the grpcdial analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/grpcdial"
+)
+
+func main() {
+	singlechecker.Main(grpcdial.Analyzer)
+}
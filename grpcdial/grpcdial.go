@@ -0,0 +1,274 @@
+// Package grpcdial provides an analyzer to detect the deprecated grpc.Dial and
+// grpc.DialContext functions.
+package grpcdial
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const (
+	grpcPath = "google.golang.org/grpc"
+
+	// behaviorURL documents the behavioral differences between Dial and NewClient.
+	behaviorURL = "https://github.com/grpc/grpc-go/blob/master/Documentation/anti-patterns.md"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for the deprecated grpc.Dial and grpc.DialContext functions
+
+This analyzer reports calls to grpc.Dial and grpc.DialContext, which are
+deprecated in favor of grpc.NewClient. NewClient connects lazily and never
+blocks, so the migration may change behavior and no fix is offered. Calls that
+pass grpc.WithBlock, directly or through an options slice, get a dedicated
+warning because NewClient does not support that option.`
+
+// Analyzer is the main analyzer for grpc.Dial calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "grpcdial",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/grpcdial",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call, enclosingBody(stack)); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingBody returns the body of the innermost function on the stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			return node.Body
+		case *ast.FuncLit:
+			return node.Body
+		}
+	}
+
+	return nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, body *ast.BlockStmt) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	var name string
+
+	switch {
+	case isPkgFunc(pass.TypesInfo, call, grpcPath, "Dial"):
+		name = "Dial"
+	case isPkgFunc(pass.TypesInfo, call, grpcPath, "DialContext"):
+		name = "DialContext"
+	default:
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "grpcdial") {
+		return nil
+	}
+
+	if hasWithBlock(pass.TypesInfo, call, body) {
+		return &analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("grpc.%s is deprecated, use grpc.NewClient instead; "+
+				"grpc.WithBlock is not supported by NewClient, which never blocks, "+
+				"so wait for the connection state explicitly (see %s)", name, behaviorURL),
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("grpc.%s is deprecated, use grpc.NewClient instead; "+
+			"NewClient connects lazily and uses the dns resolver by default (see %s)", name, behaviorURL),
+	}
+}
+
+// hasWithBlock checks if grpc.WithBlock is passed to the dial call, either as
+// an argument or through an options slice built within body.
+func hasWithBlock(info *types.Info, call *ast.CallExpr, body *ast.BlockStmt) bool {
+	for _, arg := range call.Args {
+		if containsWithBlock(info, arg) {
+			return true
+		}
+	}
+
+	if !call.Ellipsis.IsValid() || body == nil || len(call.Args) == 0 {
+		return false
+	}
+
+	ident, ok := ast.Unparen(call.Args[len(call.Args)-1]).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	return optionsContainWithBlock(info, body, info.Uses[ident])
+}
+
+// optionsContainWithBlock checks if any assignment or declaration of the
+// options slice within body includes grpc.WithBlock.
+func optionsContainWithBlock(info *types.Info, body *ast.BlockStmt, options types.Object) bool {
+	if options == nil {
+		return false
+	}
+
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && info.ObjectOf(ident) == options && i < len(node.Rhs) {
+					found = found || containsWithBlock(info, node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if info.Defs[name] == options && i < len(node.Values) {
+					found = found || containsWithBlock(info, node.Values[i])
+				}
+			}
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func containsWithBlock(info *types.Info, expr ast.Expr) bool {
+	found := false
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isPkgFunc(info, call, grpcPath, "WithBlock") {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
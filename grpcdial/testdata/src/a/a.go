@@ -0,0 +1,44 @@
+package a
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func dial(addr string) {
+	_, _ = grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials())) // want `grpc.Dial is deprecated, use grpc.NewClient instead; NewClient connects lazily and uses the dns resolver by default \(see https://github.com/grpc/grpc-go/blob/master/Documentation/anti-patterns.md\)`
+}
+
+func dialContext(ctx context.Context, addr string) {
+	_, _ = grpc.DialContext(ctx, addr) // want `grpc.DialContext is deprecated, use grpc.NewClient instead; NewClient connects lazily`
+}
+
+func dialBlocking(ctx context.Context, addr string) {
+	_, _ = grpc.DialContext(ctx, addr, grpc.WithBlock()) // want `grpc.DialContext is deprecated, use grpc.NewClient instead; grpc.WithBlock is not supported by NewClient, which never blocks, so wait for the connection state explicitly`
+}
+
+func dialBlockingOptions(addr string, blocking bool) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if blocking {
+		opts = append(opts, grpc.WithBlock())
+	}
+
+	_, _ = grpc.Dial(addr, opts...) // want `grpc.Dial is deprecated, use grpc.NewClient instead; grpc.WithBlock is not supported by NewClient`
+}
+
+func dialOptions(addr string) {
+	var opts = []grpc.DialOption{grpc.WithUserAgent("a")}
+
+	_, _ = grpc.Dial(addr, opts...) // want `grpc.Dial is deprecated, use grpc.NewClient instead; NewClient connects lazily`
+}
+
+func newClient(addr string) {
+	_, _ = grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+//godernize:ignore=grpcdial
+func ignored(addr string) {
+	_, _ = grpc.Dial(addr)
+}
@@ -0,0 +1,28 @@
+// Package grpc is a stub of google.golang.org/grpc for tests.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+type ClientConn struct{}
+
+type DialOption interface{}
+
+func Dial(target string, opts ...DialOption) (*ClientConn, error) { return nil, nil }
+
+func DialContext(ctx context.Context, target string, opts ...DialOption) (*ClientConn, error) {
+	return nil, nil
+}
+
+func NewClient(target string, opts ...DialOption) (*ClientConn, error) { return nil, nil }
+
+func WithBlock() DialOption { return nil }
+
+func WithInsecure() DialOption { return nil }
+
+func WithTransportCredentials(creds credentials.TransportCredentials) DialOption { return nil }
+
+func WithUserAgent(s string) DialOption { return nil }
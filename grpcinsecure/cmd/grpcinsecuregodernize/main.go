@@ -0,0 +1,15 @@
+// Command grpcinsecuregodernize runs the grpcinsecure analyzer.
+//
+// It uses multichecker, whose flags are prefixed with the analyzer name, since
+// singlechecker reserves -fix for applying fixes and would drop -grpcinsecure.fix.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/jaeyeom/godernize/grpcinsecure"
+)
+
+func main() {
+	multichecker.Main(grpcinsecure.Analyzer)
+}
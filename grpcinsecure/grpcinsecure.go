@@ -0,0 +1,212 @@
+// Package grpcinsecure provides an analyzer to detect the deprecated
+// grpc.WithInsecure dial option.
+package grpcinsecure
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const (
+	grpcPath     = "google.golang.org/grpc"
+	insecurePath = "google.golang.org/grpc/credentials/insecure"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for the deprecated grpc.WithInsecure dial option
+
+This analyzer reports grpc.WithInsecure() and suggests the replacement
+grpc.WithTransportCredentials(insecure.NewCredentials()) from
+google.golang.org/grpc/credentials/insecure.
+
+Because gRPC is a third-party dependency, fixes are only offered with -fix.`
+
+// Analyzer is the main analyzer for grpc.WithInsecure calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "grpcinsecure",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/grpcinsecure",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.fix, "fix", false,
+		"offer fixes rewriting grpc.WithInsecure() to grpc.WithTransportCredentials(insecure.NewCredentials())")
+
+	return analyzer
+}
+
+type runner struct {
+	fix bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := r.diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func (r *runner) diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || !isPkgFunc(pass.TypesInfo, call, grpcPath, "WithInsecure") {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "grpcinsecure") {
+		return nil
+	}
+
+	grpcName := importutil.LocalName(file, grpcPath)
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			grpcName = ident.Name
+		}
+	}
+
+	replacement := fmt.Sprintf("%s.WithTransportCredentials(%s.NewCredentials())",
+		grpcName, importutil.LocalName(file, insecurePath))
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "grpc.WithInsecure is deprecated, use " + replacement + " instead",
+	}
+
+	if !r.fix {
+		return diagnostic
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		NewText: []byte(replacement),
+	}}
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{insecurePath}, nil)...)
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Replace with " + replacement,
+		TextEdits: edits,
+	}}
+
+	return diagnostic
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
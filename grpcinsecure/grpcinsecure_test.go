@@ -0,0 +1,27 @@
+package grpcinsecure_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/grpcinsecure"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, grpcinsecure.Analyzer, "a")
+}
+
+func TestFix(t *testing.T) {
+	if err := grpcinsecure.Analyzer.Flags.Set("fix", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = grpcinsecure.Analyzer.Flags.Set("fix", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, grpcinsecure.Analyzer, "autofix")
+}
@@ -0,0 +1,27 @@
+package a
+
+import (
+	"google.golang.org/grpc"
+	rpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func dial(addr string) {
+	_, _ = grpc.Dial(addr, grpc.WithUserAgent("a"), grpc.WithInsecure()) // want `grpc.WithInsecure is deprecated, use grpc.WithTransportCredentials\(insecure.NewCredentials\(\)\) instead`
+	_, _ = rpc.Dial(addr, rpc.WithInsecure())                            // want `grpc.WithInsecure is deprecated, use rpc.WithTransportCredentials\(insecure.NewCredentials\(\)\) instead`
+}
+
+func options() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithInsecure(), // want `grpc.WithInsecure is deprecated`
+	}
+}
+
+func modern(addr string) {
+	_, _ = grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+//godernize:ignore=grpcinsecure
+func ignored(addr string) {
+	_, _ = grpc.Dial(addr, grpc.WithInsecure())
+}
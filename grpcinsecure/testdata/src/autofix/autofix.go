@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+func dial(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	return grpc.DialContext(ctx, addr,
+		grpc.WithUserAgent("autofix"),
+		grpc.WithInsecure(), // want `grpc.WithInsecure is deprecated`
+	)
+}
@@ -0,0 +1,4 @@
+// Package credentials is a stub of google.golang.org/grpc/credentials for tests.
+package credentials
+
+type TransportCredentials interface{}
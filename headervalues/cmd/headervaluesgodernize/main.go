@@ -0,0 +1,12 @@
+// Command headervaluesgodernize runs the headervalues analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/headervalues"
+)
+
+func main() {
+	singlechecker.Main(headervalues.Analyzer)
+}
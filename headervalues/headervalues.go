@@ -0,0 +1,331 @@
+// Package headervalues provides an analyzer to detect http.Header.Get calls
+// for headers that commonly have several values.
+package headervalues
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"go/version"
+	"net/textproto"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const (
+	httpPath    = "net/http"
+	stringsPath = "strings"
+	setCookie   = "Set-Cookie"
+)
+
+// multiValueKeys are the canonical keys of headers that are commonly sent
+// several times, whose values can be joined into a comma-separated list.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var multiValueKeys = map[string]bool{
+	"Accept":             true,
+	"Accept-Encoding":    true,
+	"Accept-Language":    true,
+	"Cache-Control":      true,
+	"Forwarded":          true,
+	"Link":               true,
+	"Proxy-Authenticate": true,
+	"Vary":               true,
+	"Via":                true,
+	"Warning":            true,
+	"Www-Authenticate":   true,
+	"X-Forwarded-For":    true,
+}
+
+// Doc describes what this analyzer does.
+const Doc = `check for http.Header.Get with headers that have several values
+
+This analyzer reports http.Header.Get calls with a constant key naming a
+header that is commonly sent several times, since Get returns only the first
+value:
+- h.Get("Vary") -> strings.Join(h.Values("Vary"), ", ")
+- h.Get("Set-Cookie") -> h.Values("Set-Cookie")
+
+The fix joins the values of list headers as if they were sent in a single
+line. Cookies cannot be joined, so Set-Cookie is reported without a fix. Files
+built for Go versions before 1.14, which lack Header.Values, are skipped.`
+
+// Analyzer is the main analyzer for http.Header.Get with multi-value headers.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "headervalues",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/headervalues",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single import of strings.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]analysis.Diagnostic)
+	)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+		if file == nil || !supportsValues(pass, file) {
+			return
+		}
+
+		diagnostic := diagnoseCallExpr(pass, file, call)
+		if diagnostic == nil {
+			return
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], *diagnostic)
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// consolidateFixes adds the import of strings to the fixes in file when it is
+// missing. With several fixes, only the first carries the rewrites of all of
+// them, so that applying every fix of the file does not add the import twice;
+// the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, diagnostics []analysis.Diagnostic) []analysis.Diagnostic {
+	first := -1
+
+	var edits []analysis.TextEdit
+
+	for i, diagnostic := range diagnostics {
+		if len(diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, diagnostic.SuggestedFixes[0].TextEdits...)
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{stringsPath}, nil)...)
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 1 || !isHeaderGet(pass.TypesInfo, sel) {
+		return nil
+	}
+
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+
+	key := textproto.CanonicalMIMEHeaderKey(constant.StringVal(tv.Value))
+	if key != setCookie && !multiValueKeys[key] {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "headervalues") {
+		return nil
+	}
+
+	if key == setCookie {
+		return &analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("Header.Get returns only the first %q value, "+
+				"use Values to get every cookie", key),
+		}
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("Header.Get returns only the first %q value, "+
+			"use Values to get all of them", key),
+	}
+
+	stringsName := importutil.LocalName(file, stringsPath)
+	if !refersToStrings(pass, call, stringsName) {
+		return diagnostic
+	}
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Join all values with strings.Join",
+		TextEdits: []analysis.TextEdit{
+			{Pos: call.Pos(), End: call.Pos(), NewText: []byte(stringsName + ".Join(")},
+			{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Values")},
+			{Pos: call.End(), End: call.End(), NewText: []byte(`, ", ")`)},
+		},
+	}}
+
+	return diagnostic
+}
+
+// isHeaderGet checks if sel selects the Get method of net/http.Header.
+func isHeaderGet(info *types.Info, sel *ast.SelectorExpr) bool {
+	if sel.Sel.Name != "Get" {
+		return false
+	}
+
+	method, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || method.Pkg() == nil || method.Pkg().Path() != httpPath {
+		return false
+	}
+
+	sig, ok := method.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+
+	named, ok := types.Unalias(sig.Recv().Type()).(*types.Named)
+
+	return ok && named.Obj().Name() == "Header"
+}
+
+// refersToStrings checks if name refers to the strings package at call, or
+// to nothing when strings is not imported yet.
+func refersToStrings(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	scope := pass.Pkg.Scope().Innermost(call.Pos())
+	if scope == nil {
+		return false
+	}
+
+	_, obj := scope.LookupParent(name, call.Pos())
+	if obj == nil {
+		return true
+	}
+
+	pkgName, ok := obj.(*types.PkgName)
+
+	return ok && pkgName.Imported().Path() == stringsPath
+}
+
+// supportsValues reports whether the file is compiled with a Go version that
+// has Header.Values. Files without version information are assumed to be
+// recent enough.
+func supportsValues(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.14") >= 0
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
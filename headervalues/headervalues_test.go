@@ -0,0 +1,33 @@
+package headervalues_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/headervalues"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, headervalues.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, headervalues.Analyzer, "autofix")
+}
+
+// TestGoVersion checks that modules before Go 1.14, which lack Header.Values,
+// are skipped.
+func TestGoVersion(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "src", "old"), headervalues.Analyzer, "./...")
+}
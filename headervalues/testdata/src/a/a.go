@@ -0,0 +1,42 @@
+package a
+
+import (
+	"net/http"
+	"net/textproto"
+)
+
+type header = http.Header
+
+const varyKey = "Vary"
+
+func lists(h http.Header, r *http.Request, resp *http.Response, alias header) {
+	_ = h.Get("Vary")                   // want `Header.Get returns only the first "Vary" value, use Values to get all of them`
+	_ = r.Header.Get("accept-encoding") // want `Header.Get returns only the first "Accept-Encoding" value, use Values to get all of them`
+	_ = resp.Header.Get(varyKey)        // want `Header.Get returns only the first "Vary" value`
+	_ = alias.Get("X-Forwarded-For")    // want `Header.Get returns only the first "X-Forwarded-For" value`
+	_ = http.Header{}.Get("Link")       // want `Header.Get returns only the first "Link" value`
+}
+
+func cookies(resp *http.Response) {
+	_ = resp.Header.Get("Set-Cookie") // want `Header.Get returns only the first "Set-Cookie" value, use Values to get every cookie`
+}
+
+func untouched(h http.Header, m textproto.MIMEHeader, key string) {
+	// Single-value headers.
+	_ = h.Get("Content-Type")
+	_ = h.Get("Authorization")
+
+	// The key is not constant.
+	_ = h.Get(key)
+
+	// Already all values.
+	_ = h.Values("Vary")
+
+	// Not an http.Header.
+	_ = m.Get("Vary")
+}
+
+func ignored(h http.Header) {
+	//godernize:ignore=headervalues
+	_ = h.Get("Vary")
+}
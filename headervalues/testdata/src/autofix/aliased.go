@@ -0,0 +1,10 @@
+package autofix
+
+import (
+	"net/http"
+	str "strings"
+)
+
+func upper(h http.Header) string {
+	return str.ToUpper(h.Get("Cache-Control")) // want `Header.Get returns only the first "Cache-Control" value`
+}
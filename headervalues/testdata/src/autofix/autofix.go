@@ -0,0 +1,16 @@
+package autofix
+
+import "net/http"
+
+func vary(h http.Header) string {
+	return h.Get("Vary") // want `Header.Get returns only the first "Vary" value`
+}
+
+func forwarded(r *http.Request) (string, string) {
+	via := r.Header.Get("Via")         // want `Header.Get returns only the first "Via" value`
+	return via, r.Header.Get("Accept") // want `Header.Get returns only the first "Accept" value`
+}
+
+func shadowed(h http.Header, strings []string) string {
+	return h.Get("Vary") // want `Header.Get returns only the first "Vary" value`
+}
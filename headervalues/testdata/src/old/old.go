@@ -0,0 +1,8 @@
+package old
+
+import "net/http"
+
+// Header.Values was added in Go 1.14.
+func vary(h http.Header) string {
+	return h.Get("Vary")
+}
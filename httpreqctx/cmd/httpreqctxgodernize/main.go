@@ -0,0 +1,12 @@
+// Command httpreqctxgodernize runs the httpreqctx analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/httpreqctx"
+)
+
+func main() {
+	singlechecker.Main(httpreqctx.Analyzer)
+}
@@ -0,0 +1,298 @@
+// Package httpreqctx provides an analyzer to detect http.NewRequest calls that
+// should pass a context with http.NewRequestWithContext.
+package httpreqctx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	xtypeutil "golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+	"github.com/jaeyeom/godernize/internal/typeutil"
+)
+
+const (
+	httpPath    = "net/http"
+	contextPath = "context"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for http.NewRequest calls that do not pass a context
+
+This analyzer reports calls to http.NewRequest and suggests
+http.NewRequestWithContext so that cancellation and deadlines propagate to the
+request. When a context.Context parameter of an enclosing function is in
+scope, the fix passes it:
+- http.NewRequest(method, url, body) -> http.NewRequestWithContext(ctx, method, url, body)
+
+Otherwise the diagnostic has no fix, unless -background is set, in which case
+the fix passes context.Background().`
+
+// Analyzer is the main analyzer for http.NewRequest calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "httpreqctx",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/httpreqctx",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.background, "background", false,
+		"suggest context.Background() when no context.Context parameter is in scope")
+
+	return analyzer
+}
+
+type runner struct {
+	background bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := r.diagnoseCallExpr(pass, file, call, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func (r *runner) diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil || len(call.Args) == 0 || !isPkgFunc(pass.TypesInfo, call, httpPath, "NewRequest") {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "httpreqctx") {
+		return nil
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "http.NewRequest should be replaced with http.NewRequestWithContext to propagate a context",
+	}
+
+	ctx := contextInScope(pass, stack, call.Pos())
+
+	var addImport []string
+
+	if ctx == "" {
+		if !r.background {
+			return diagnostic
+		}
+
+		ctx = importutil.LocalName(file, contextPath) + ".Background()"
+		if importutil.Name(file, contextPath) == "" {
+			addImport = []string{contextPath}
+		}
+	}
+
+	funcName := funcIdent(call)
+	if funcName == nil {
+		return diagnostic
+	}
+
+	edits := []analysis.TextEdit{
+		{
+			Pos:     funcName.Pos(),
+			End:     funcName.End(),
+			NewText: []byte("NewRequestWithContext"),
+		},
+		{
+			Pos:     call.Args[0].Pos(),
+			End:     call.Args[0].Pos(),
+			NewText: []byte(ctx + ", "),
+		},
+	}
+	edits = append(edits, importutil.Edits(pass.Fset, file, addImport, nil)...)
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Replace with http.NewRequestWithContext passing " + ctx,
+		TextEdits: edits,
+	}}
+
+	return diagnostic
+}
+
+// contextInScope returns the name of the innermost context.Context parameter
+// of an enclosing function that is visible at pos, or "" if there is none.
+func contextInScope(pass *analysis.Pass, stack []ast.Node, pos token.Pos) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var funcType *ast.FuncType
+
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			funcType = node.Type
+		case *ast.FuncLit:
+			funcType = node.Type
+		default:
+			continue
+		}
+
+		if name := contextParam(pass, funcType, pos); name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// contextParam returns the name of a context.Context parameter declared by
+// funcType that is not shadowed at pos.
+func contextParam(pass *analysis.Pass, funcType *ast.FuncType, pos token.Pos) string {
+	if funcType.Params == nil {
+		return ""
+	}
+
+	for _, field := range funcType.Params.List {
+		if !typeutil.IsContextType(pass.TypesInfo, field.Type) {
+			continue
+		}
+
+		for _, name := range field.Names {
+			obj := pass.TypesInfo.Defs[name]
+			if obj == nil || name.Name == "_" {
+				continue
+			}
+
+			scope := pass.Pkg.Scope().Innermost(pos)
+			if scope == nil {
+				continue
+			}
+
+			if _, found := scope.LookupParent(name.Name, pos); found == obj {
+				return name.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+// funcIdent returns the NewRequest identifier of call, which is qualified
+// unless net/http is dot imported.
+func funcIdent(call *ast.CallExpr) *ast.Ident {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel
+	case *ast.Ident:
+		return fun
+	default:
+		return nil
+	}
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := xtypeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,32 @@
+package httpreqctx_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/httpreqctx"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, httpreqctx.Analyzer, "a")
+}
+
+func TestAutoFix(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, httpreqctx.Analyzer, "autofix")
+}
+
+func TestBackground(t *testing.T) {
+	if err := httpreqctx.Analyzer.Flags.Set("background", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = httpreqctx.Analyzer.Flags.Set("background", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, httpreqctx.Analyzer, "background")
+}
@@ -0,0 +1,34 @@
+package a
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+func withoutContext(url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil) // want `http.NewRequest should be replaced with http.NewRequestWithContext to propagate a context`
+}
+
+func withContext(ctx context.Context, url string, body io.Reader) (*http.Request, error) {
+	return http.NewRequest(http.MethodPost, url, body) // want `http.NewRequest should be replaced with http.NewRequestWithContext`
+}
+
+func alreadyWithContext(ctx context.Context, url string) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+}
+
+type client struct{}
+
+func (client) NewRequest(method, url string, body io.Reader) (*http.Request, error) {
+	return nil, nil
+}
+
+func method(c client, url string) {
+	_, _ = c.NewRequest(http.MethodGet, url, nil)
+}
+
+//godernize:ignore=httpreqctx
+func ignored(url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil)
+}
@@ -0,0 +1,38 @@
+package autofix
+
+import (
+	"context"
+	"net/http"
+)
+
+func param(ctx context.Context, url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+}
+
+func namedParam(reqCtx context.Context, url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+}
+
+func closure(ctx context.Context, urls []string) {
+	for _, url := range urls {
+		func() {
+			_, _ = http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+		}()
+	}
+}
+
+func innermost(ctx context.Context, url string) func(context.Context) {
+	return func(inner context.Context) {
+		_, _ = http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+	}
+}
+
+func shadowed(ctx context.Context, url string) {
+	if ctx := 1; ctx > 0 {
+		_, _ = http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+	}
+}
+
+func unnamed(_ context.Context, url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+}
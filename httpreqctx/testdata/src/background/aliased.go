@@ -0,0 +1,12 @@
+package background
+
+import (
+	stdctx "context"
+	. "net/http"
+)
+
+func dotImport(url string) (*Request, error) {
+	_ = stdctx.TODO
+
+	return NewRequest(MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+}
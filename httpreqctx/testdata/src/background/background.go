@@ -0,0 +1,9 @@
+package background
+
+import (
+	"net/http"
+)
+
+func noContext(url string) (*http.Request, error) {
+	return http.NewRequest(http.MethodGet, url, nil) // want "http.NewRequest should be replaced with http.NewRequestWithContext"
+}
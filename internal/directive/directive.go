@@ -3,6 +3,7 @@ package directive
 
 import (
 	"go/ast"
+	"go/token"
 	"slices"
 	"strings"
 )
@@ -21,27 +22,170 @@ import (
 // or multiple names
 //
 //	//godernize:ignore=IsNotExist,IsExist
+//
+// A human-readable reason may follow the names after // or #:
+//
+//	//godernize:ignore=oserrors // legacy path, removed in Q3
 type Ignore struct {
 	Names []string
+	// Reason is the justification given after the names, or "" if none.
+	Reason string
 }
 
-// ParseIgnore parse the directive from the comments.
+// ParseIgnore parse the directive from the comments. Without a godernize
+// directive, a golangci-lint style //nolint directive is used instead, see
+// ParseNolint.
 func ParseIgnore(doc *ast.CommentGroup) *Ignore {
+	if ignore := ParseGodernize(doc); ignore != nil {
+		return ignore
+	}
+
+	return ParseNolint(doc)
+}
+
+// ParseGodernize parses the //godernize:ignore directive from the comments,
+// without falling back to //nolint. Comments preceding a node are checked with
+// it, since a //nolint directive applies to lines rather than nodes, see
+// NolintIgnore.
+func ParseGodernize(doc *ast.CommentGroup) *Ignore {
+	return parse(doc, "//godernize:ignore")
+}
+
+// ParseNolint parses a golangci-lint style directive from the comments:
+//
+//	//nolint
+//	//nolint:oserrors,ctxnil // reason
+//
+// The linter names are taken as analyzer names, and godernize stands for all
+// analyzers, like a bare //nolint. Names of other linters are kept and match
+// no analyzer.
+func ParseNolint(doc *ast.CommentGroup) *Ignore {
+	if doc == nil {
+		return nil
+	}
+
+	for _, comment := range doc.List {
+		rest, found := strings.CutPrefix(strings.TrimSpace(comment.Text), "//nolint")
+		if !found {
+			continue
+		}
+
+		val, reason := cutReason(rest)
+		if val == "" {
+			return &Ignore{Reason: reason}
+		}
+
+		val, found = strings.CutPrefix(val, ":")
+		if !found {
+			continue
+		}
+
+		names := parseNames(val)
+		if slices.Contains(names, "godernize") {
+			names = nil
+		}
+
+		return &Ignore{Names: names, Reason: reason}
+	}
+
+	return nil
+}
+
+// NolintIgnore parses the //nolint directive that applies to node. As in
+// golangci-lint, a //nolint comment applies to its own line, whether it trails
+// code or not, and to the next line only when it stands alone:
+//
+//	//nolint:oserrors // applies to the next line
+//	if os.IsNotExist(err) { //nolint:oserrors // applies to this line
+//
+// The line of node is the line where it starts.
+func NolintIgnore(fset *token.FileSet, file *ast.File, node ast.Node) *Ignore {
+	if fset == nil || file == nil || node == nil {
+		return nil
+	}
+
+	tokFile := fset.File(node.Pos())
+	if tokFile == nil {
+		return nil
+	}
+
+	line := tokFile.Line(node.Pos())
+
+	for _, group := range file.Comments {
+		if tokFile.Line(group.End()) < line-1 {
+			continue
+		}
+
+		if tokFile.Line(group.Pos()) > line {
+			break
+		}
+
+		for _, comment := range group.List {
+			commentLine := tokFile.Line(comment.Pos())
+			if commentLine != line && (commentLine != line-1 || !standsAlone(tokFile, file, comment)) {
+				continue
+			}
+
+			if ignore := ParseNolint(&ast.CommentGroup{List: []*ast.Comment{comment}}); ignore != nil {
+				return ignore
+			}
+		}
+	}
+
+	return nil
+}
+
+// standsAlone reports whether no code precedes comment on its line.
+func standsAlone(tokFile *token.File, file *ast.File, comment *ast.Comment) bool {
+	line := tokFile.Line(comment.Pos())
+	alone := true
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+
+		// Only nodes starting before the comment and reaching its line can
+		// hold code preceding it.
+		if !alone || n.Pos() >= comment.Pos() || tokFile.Line(n.End()) < line {
+			return false
+		}
+
+		if tokFile.Line(n.Pos()) == line || (n.End() <= comment.Pos() && tokFile.Line(n.End()) == line) {
+			alone = false
+		}
+
+		return alone
+	})
+
+	return alone
+}
+
+// parse parses the first directive with the given prefix from the comments.
+func parse(doc *ast.CommentGroup, prefix string) *Ignore {
 	if doc == nil {
 		return nil
 	}
 
 	for _, comment := range doc.List {
 		text := strings.TrimSpace(comment.Text)
-		if text == "//godernize:ignore" {
-			return &Ignore{}
+
+		rest, found := strings.CutPrefix(text, prefix)
+		if !found {
+			continue
+		}
+
+		val, reason := cutReason(rest)
+		if val == "" {
+			return &Ignore{Reason: reason}
 		}
 
 		// parse the Names if exists
-		if val, found := strings.CutPrefix(text, "//godernize:ignore="); found {
+		if val, found := strings.CutPrefix(val, "="); found {
 			val = strings.TrimSpace(val)
 			if val == "" {
-				return &Ignore{}
+				return &Ignore{Reason: reason}
 			}
 
 			names := strings.Split(val, ",")
@@ -54,16 +198,142 @@ func ParseIgnore(doc *ast.CommentGroup) *Ignore {
 			}
 
 			if len(names) > 0 {
-				return &Ignore{Names: names}
+				return &Ignore{Names: names, Reason: reason}
 			}
 
-			return &Ignore{}
+			return &Ignore{Reason: reason}
+		}
+	}
+
+	return nil
+}
+
+// cutReason splits the text after a directive keyword at the first // or #,
+// which starts the reason. It returns the directive part and the reason, both
+// with surrounding space removed.
+func cutReason(rest string) (string, string) {
+	directive, reason := rest, ""
+
+	if i := strings.Index(rest, "//"); i >= 0 {
+		directive, reason = rest[:i], rest[i+len("//"):]
+	}
+
+	if i := strings.IndexByte(directive, '#'); i >= 0 {
+		directive, reason = rest[:i], rest[i+len("#"):]
+	}
+
+	return strings.TrimSpace(directive), strings.TrimSpace(reason)
+}
+
+// FileIgnore parses the directive that applies to the whole file. It is taken
+// from the package doc comment or, failing that, from the first comment group
+// of the file when it precedes the package clause or follows it before any
+// declaration. A comment group that documents the first declaration does not
+// count.
+func FileIgnore(file *ast.File) *Ignore {
+	for _, group := range fileComments(file) {
+		if ignore := ParseIgnore(group); ignore != nil {
+			return ignore
+		}
+	}
+
+	return nil
+}
+
+// FuncIgnore parses the directive that applies to the whole function decl of
+// file. It is taken from the doc comment of decl or, failing that, from the
+// first comment group of its body when it precedes the first statement:
+//
+//	func f(ctx context.Context) {
+//		//godernize:ignore=ctxnil // applies to all of f
+//		...
+//	}
+//
+// Directives further down the body are left to the statements they precede.
+// A //nolint directive counts only in the doc comment, since in the body it
+// applies to its own lines, see NolintIgnore.
+func FuncIgnore(file *ast.File, decl *ast.FuncDecl) *Ignore {
+	if decl == nil {
+		return nil
+	}
+
+	if ignore := ParseIgnore(decl.Doc); ignore != nil {
+		return ignore
+	}
+
+	return ParseGodernize(leadingComment(file, decl.Body))
+}
+
+// leadingComment returns the first comment group of body when it precedes the
+// first statement, or nil.
+func leadingComment(file *ast.File, body *ast.BlockStmt) *ast.CommentGroup {
+	if file == nil || body == nil {
+		return nil
+	}
+
+	end := body.Rbrace
+	if len(body.List) > 0 {
+		end = body.List[0].Pos()
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() >= end {
+			break
+		}
+
+		if group.Pos() > body.Lbrace {
+			return group
 		}
 	}
 
 	return nil
 }
 
+// fileComments returns the comment groups that may carry a file-level
+// directive, in order of precedence.
+func fileComments(file *ast.File) []*ast.CommentGroup {
+	if file == nil {
+		return nil
+	}
+
+	var groups []*ast.CommentGroup
+
+	if file.Doc != nil {
+		groups = append(groups, file.Doc)
+	}
+
+	if len(file.Comments) == 0 {
+		return groups
+	}
+
+	first := file.Comments[0]
+	if first == file.Doc {
+		return groups
+	}
+
+	if first.Pos() > file.Package && len(file.Decls) > 0 && first.End() >= declStart(file.Decls[0]) {
+		return groups
+	}
+
+	return append(groups, first)
+}
+
+// declStart returns the start of decl including its doc comment.
+func declStart(decl ast.Decl) token.Pos {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	}
+
+	return decl.Pos()
+}
+
 func (i *Ignore) hasName(name string) bool {
 	return slices.Contains(i.Names, name)
 }
@@ -82,3 +352,162 @@ func (i *Ignore) ShouldIgnore(name string) bool {
 func (i *Ignore) HasSpecificRules() bool {
 	return len(i.Names) > 0
 }
+
+// Enable is the opposite of Ignore. When analyzers are disabled by default,
+// they only report within the scope of a matching enable directive:
+//
+//	//godernize:enable
+//	//godernize:enable=ctxnil
+//	//godernize:enable=ctxnil,oserrors // rolled out in this package
+//
+// Names and reason follow the same rules as for Ignore.
+type Enable struct {
+	Names  []string
+	Reason string
+}
+
+// ParseEnable parses the enable directive from the comments.
+func ParseEnable(doc *ast.CommentGroup) *Enable {
+	if d := parse(doc, "//godernize:enable"); d != nil {
+		return &Enable{Names: d.Names, Reason: d.Reason}
+	}
+
+	return nil
+}
+
+// FileEnable parses the enable directive that applies to the whole file. It
+// is found in the same places as the directive of FileIgnore.
+func FileEnable(file *ast.File) *Enable {
+	for _, group := range fileComments(file) {
+		if enable := ParseEnable(group); enable != nil {
+			return enable
+		}
+	}
+
+	return nil
+}
+
+// ShouldEnable returns true if the name is enabled.
+func (e *Enable) ShouldEnable(name string) bool {
+	return len(e.Names) == 0 || slices.Contains(e.Names, name)
+}
+
+// RangeKind is the kind of a block-scoped ignore directive.
+type RangeKind int
+
+const (
+	// NotRange is any comment that is not a block-scoped directive.
+	NotRange RangeKind = iota
+	// RangeBegin opens an ignored block:
+	//
+	//	//godernize:ignore-begin=ctxnil
+	RangeBegin
+	// RangeEnd closes an ignored block:
+	//
+	//	//godernize:ignore-end=ctxnil
+	RangeEnd
+)
+
+// ParseRange parses a block-scoped ignore directive from a comment. The names
+// and reason follow the same rules as ParseIgnore; without names the block
+// ignores every analyzer.
+func ParseRange(comment *ast.Comment) (RangeKind, *Ignore) {
+	if comment == nil {
+		return NotRange, nil
+	}
+
+	text := strings.TrimSpace(comment.Text)
+
+	for _, form := range []struct {
+		prefix string
+		kind   RangeKind
+	}{
+		{"//godernize:ignore-begin", RangeBegin},
+		{"//godernize:ignore-end", RangeEnd},
+	} {
+		rest, found := strings.CutPrefix(text, form.prefix)
+		if !found {
+			continue
+		}
+
+		val, reason := cutReason(rest)
+		if val == "" {
+			return form.kind, &Ignore{Reason: reason}
+		}
+
+		if val, found := strings.CutPrefix(val, "="); found {
+			return form.kind, &Ignore{Names: parseNames(val), Reason: reason}
+		}
+	}
+
+	return NotRange, nil
+}
+
+func parseNames(val string) []string {
+	var names []string
+
+	for _, name := range strings.Split(val, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Range is a block of a file in which the directive applies.
+type Range struct {
+	*Ignore
+
+	Pos token.Pos // position of the begin directive
+	End token.Pos // position of the end directive, or the end of the file
+	// Closed is false if no matching end directive was found, in which case
+	// the block extends to the end of the file.
+	Closed bool
+}
+
+// Contains reports whether pos is inside the block.
+func (r Range) Contains(pos token.Pos) bool {
+	return r.Pos <= pos && pos < r.End
+}
+
+// IgnoreRanges returns the ignored blocks of file in the order of their begin
+// directives. An end directive closes the innermost open block with the same
+// names, so blocks for different analyzers may nest or overlap. End directives
+// without a matching begin are ignored, and blocks that are never closed
+// extend to the end of the file.
+func IgnoreRanges(file *ast.File) []Range {
+	if file == nil {
+		return nil
+	}
+
+	var (
+		ranges []Range
+		open   []int // indexes into ranges of the unclosed blocks
+	)
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			kind, ignore := ParseRange(comment)
+
+			switch kind {
+			case RangeBegin:
+				open = append(open, len(ranges))
+				ranges = append(ranges, Range{Ignore: ignore, Pos: comment.Pos(), End: file.FileEnd})
+			case RangeEnd:
+				for i := len(open) - 1; i >= 0; i-- {
+					r := &ranges[open[i]]
+					if slices.Equal(r.Names, ignore.Names) {
+						r.End, r.Closed = comment.Pos(), true
+						open = slices.Delete(open, i, i+1)
+
+						break
+					}
+				}
+			case NotRange:
+			}
+		}
+	}
+
+	return ranges
+}
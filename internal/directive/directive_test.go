@@ -22,6 +22,19 @@ func TestParseIgnore(t *testing.T) {
 		{"//godernize:ignore=oserrors", &directive.Ignore{Names: []string{"oserrors"}}},
 		{"//godernize:ignore=IsNotExist", &directive.Ignore{Names: []string{"IsNotExist"}}},
 		{"//godernize:ignore=oserrors,IsNotExist", &directive.Ignore{Names: []string{"oserrors", "IsNotExist"}}},
+		{
+			"//godernize:ignore=oserrors // legacy path, removed in Q3",
+			&directive.Ignore{Names: []string{"oserrors"}, Reason: "legacy path, removed in Q3"},
+		},
+		{
+			"//godernize:ignore=oserrors, IsNotExist # see a, b, and c",
+			&directive.Ignore{Names: []string{"oserrors", "IsNotExist"}, Reason: "see a, b, and c"},
+		},
+		{"//godernize:ignore=ctxnil //", &directive.Ignore{Names: []string{"ctxnil"}}},
+		{"//godernize:ignore=ctxnil #", &directive.Ignore{Names: []string{"ctxnil"}}},
+		{"//godernize:ignore // generated code", &directive.Ignore{Reason: "generated code"}},
+		{"//godernize:ignore= # nothing to check", &directive.Ignore{Reason: "nothing to check"}},
+		{"//godernize:ignored", nil},
 		{"// some other comment", nil},
 	}
 
@@ -72,6 +85,10 @@ func assertIgnoreResult(t *testing.T, expected *directive.Ignore, result *direct
 
 	if expected != nil && result != nil {
 		assertNamesMatch(t, expected.Names, result.Names, comment)
+
+		if result.Reason != expected.Reason {
+			t.Errorf("Expected reason %q for %q, got %q", expected.Reason, comment, result.Reason)
+		}
 	}
 }
 
@@ -90,3 +107,418 @@ func assertNamesMatch(t *testing.T, expectedNames, resultNames []string, comment
 		}
 	}
 }
+
+func TestParseNolint(t *testing.T) {
+	t.Parallel()
+
+	tests := []parseIgnoreTestCase{
+		{"//nolint", &directive.Ignore{}},
+		{"//nolint:oserrors", &directive.Ignore{Names: []string{"oserrors"}}},
+		{"//nolint:oserrors,ctxnil", &directive.Ignore{Names: []string{"oserrors", "ctxnil"}}},
+		{"//nolint:godernize", &directive.Ignore{}},
+		{"//nolint:errcheck,godernize // checked by the caller", &directive.Ignore{Reason: "checked by the caller"}},
+		{"//nolint:oserrors // legacy, see #12", &directive.Ignore{Names: []string{"oserrors"}, Reason: "legacy, see #12"}},
+		{"//nolint:errcheck", &directive.Ignore{Names: []string{"errcheck"}}},
+		{"//nolintx", nil},
+		{"// nolint:oserrors", nil},
+		{"// some other comment", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			t.Parallel()
+
+			commentGroup := parseCommentFromSource(t, test.comment)
+			result := directive.ParseNolint(commentGroup)
+			assertIgnoreResult(t, test.expected, result, test.comment)
+
+			// ParseIgnore falls back to the nolint directive.
+			assertIgnoreResult(t, test.expected, directive.ParseIgnore(commentGroup), test.comment)
+		})
+	}
+}
+
+func TestParseIgnorePrefersNative(t *testing.T) {
+	t.Parallel()
+
+	commentGroup := parseCommentFromSource(t, "//nolint:errcheck\n//godernize:ignore=ctxnil")
+	assertIgnoreResult(t, &directive.Ignore{Names: []string{"ctxnil"}}, directive.ParseIgnore(commentGroup), "mixed")
+}
+
+func TestParseEnable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comment  string
+		expected *directive.Enable
+	}{
+		{"//godernize:enable", &directive.Enable{}},
+		{"//godernize:enable=ctxnil", &directive.Enable{Names: []string{"ctxnil"}}},
+		{
+			"//godernize:enable=ctxnil, oserrors // rolled out, see #12",
+			&directive.Enable{Names: []string{"ctxnil", "oserrors"}, Reason: "rolled out, see #12"},
+		},
+		{"//godernize:enabled", nil},
+		{"//godernize:ignore=ctxnil", nil},
+		{"// some other comment", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			t.Parallel()
+
+			result := directive.ParseEnable(parseCommentFromSource(t, test.comment))
+
+			switch {
+			case test.expected == nil && result != nil:
+				t.Errorf("Expected nil for %q, got %+v", test.comment, result)
+			case test.expected != nil && result == nil:
+				t.Errorf("Expected %+v for %q, got nil", test.expected, test.comment)
+			case test.expected != nil:
+				assertNamesMatch(t, test.expected.Names, result.Names, test.comment)
+
+				if result.Reason != test.expected.Reason {
+					t.Errorf("Expected reason %q for %q, got %q", test.expected.Reason, test.comment, result.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestEnableShouldEnable(t *testing.T) {
+	t.Parallel()
+
+	all := &directive.Enable{}
+	if !all.ShouldEnable("ctxnil") {
+		t.Error("enable without names does not enable ctxnil")
+	}
+
+	specific := &directive.Enable{Names: []string{"ctxnil"}}
+	if !specific.ShouldEnable("ctxnil") || specific.ShouldEnable("oserrors") {
+		t.Errorf("%+v should enable ctxnil only", specific)
+	}
+}
+
+func TestFileEnable(t *testing.T) {
+	t.Parallel()
+
+	src := "//godernize:enable=ctxnil\n\npackage p\n\n//godernize:enable=oserrors\nfunc f() {}\n"
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	enable := directive.FileEnable(file)
+	if enable == nil || !enable.ShouldEnable("ctxnil") || enable.ShouldEnable("oserrors") {
+		t.Errorf("FileEnable = %+v, want ctxnil from the comment before the package clause", enable)
+	}
+}
+
+func TestFileIgnore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		src      string
+		expected *directive.Ignore
+	}{
+		{
+			name:     "package doc",
+			src:      "// Package p does things.\n//\n//godernize:ignore=oserrors\npackage p\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}},
+		},
+		{
+			name:     "before package clause",
+			src:      "//godernize:ignore\n\npackage p\n\nfunc f() {}\n",
+			expected: &directive.Ignore{},
+		},
+		{
+			name:     "after package clause",
+			src:      "package p\n\n//godernize:ignore=ctxnil\n\nimport \"os\"\n\nvar _ = os.Args\n",
+			expected: &directive.Ignore{Names: []string{"ctxnil"}},
+		},
+		{
+			name:     "without declarations",
+			src:      "package p\n\n//godernize:ignore=ctxnil\n",
+			expected: &directive.Ignore{Names: []string{"ctxnil"}},
+		},
+		{
+			name: "first declaration doc",
+			src:  "package p\n\n//godernize:ignore=oserrors\nfunc f() {}\n",
+		},
+		{
+			name: "after first declaration",
+			src:  "package p\n\nfunc f() {}\n\n//godernize:ignore=oserrors\nfunc g() {}\n",
+		},
+		{
+			name: "no directive",
+			src:  "// Package p does things.\npackage p\n\n// Other comment.\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := parser.ParseFile(token.NewFileSet(), "", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			assertIgnoreResult(t, test.expected, directive.FileIgnore(file), test.src)
+		})
+	}
+}
+
+func TestFuncIgnore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		src      string
+		expected *directive.Ignore
+	}{
+		{
+			name:     "doc comment",
+			src:      "package p\n\n//godernize:ignore=ctxnil\nfunc f() {\n\tprintln()\n}\n",
+			expected: &directive.Ignore{Names: []string{"ctxnil"}},
+		},
+		{
+			name:     "first in body",
+			src:      "package p\n\nfunc f() {\n\t//godernize:ignore=ctxnil // whole function\n\tprintln()\n}\n",
+			expected: &directive.Ignore{Names: []string{"ctxnil"}, Reason: "whole function"},
+		},
+		{
+			name:     "after opening brace",
+			src:      "package p\n\nfunc f() { //godernize:ignore\n\tprintln()\n}\n",
+			expected: &directive.Ignore{},
+		},
+		{
+			name:     "empty body",
+			src:      "package p\n\nfunc f() {\n\t//godernize:ignore=oserrors\n}\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}},
+		},
+		{
+			name:     "nolint doc comment",
+			src:      "package p\n\n//nolint:oserrors\nfunc f() {\n\tprintln()\n}\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}},
+		},
+		{
+			name: "nolint first in body",
+			src:  "package p\n\nfunc f() {\n\t//nolint:oserrors\n\tprintln()\n}\n",
+		},
+		{
+			name:     "doc comment first",
+			src:      "package p\n\n//godernize:ignore=oserrors\nfunc f() {\n\t//godernize:ignore=ctxnil\n\tprintln()\n}\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}},
+		},
+		{
+			name: "after first statement",
+			src:  "package p\n\nfunc f() {\n\tprintln()\n\t//godernize:ignore=ctxnil\n\tprintln()\n}\n",
+		},
+		{
+			name: "first comment is not a directive",
+			src:  "package p\n\nfunc f() {\n\t// Print twice.\n\tprintln()\n\t//godernize:ignore\n\tprintln()\n}\n",
+		},
+		{
+			name: "in previous function",
+			src:  "package p\n\nfunc g() {\n\t//godernize:ignore\n}\n\nfunc f() {\n\tprintln()\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := parser.ParseFile(token.NewFileSet(), "", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			var decl *ast.FuncDecl
+
+			for _, d := range file.Decls {
+				if funcDecl, ok := d.(*ast.FuncDecl); ok && funcDecl.Name.Name == "f" {
+					decl = funcDecl
+				}
+			}
+
+			assertIgnoreResult(t, test.expected, directive.FuncIgnore(file, decl), test.src)
+		})
+	}
+}
+
+func TestNolintIgnore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		src      string
+		expected *directive.Ignore
+	}{
+		{
+			name:     "on the line",
+			src:      "package p\n\nfunc f() {\n\tx := 1\n\ttarget(x) //nolint:oserrors // checked\n}\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}, Reason: "checked"},
+		},
+		{
+			name:     "alone above",
+			src:      "package p\n\nfunc f() {\n\tx := 1\n\t//nolint:oserrors\n\ttarget(x)\n}\n",
+			expected: &directive.Ignore{Names: []string{"oserrors"}},
+		},
+		{
+			name:     "last of a group above",
+			src:      "package p\n\nfunc f() {\n\t// Call the target.\n\t//nolint\n\ttarget()\n}\n",
+			expected: &directive.Ignore{},
+		},
+		{
+			name: "trailing the line above",
+			src:  "package p\n\nfunc f() {\n\tx := 1 //nolint\n\ttarget(x)\n}\n",
+		},
+		{
+			name: "trailing a closing brace above",
+			src:  "package p\n\nfunc f() {\n\tif true {\n\t} //nolint\n\ttarget()\n}\n",
+		},
+		{
+			name: "two lines above",
+			src:  "package p\n\nfunc f() {\n\t//nolint\n\n\ttarget()\n}\n",
+		},
+		{
+			name: "on the line below",
+			src:  "package p\n\nfunc f() {\n\ttarget()\n\t//nolint\n}\n",
+		},
+		{
+			name: "godernize directive",
+			src:  "package p\n\nfunc f() {\n\ttarget() //godernize:ignore\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			var target *ast.CallExpr
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "target" {
+						target = call
+					}
+				}
+
+				return true
+			})
+
+			assertIgnoreResult(t, test.expected, directive.NolintIgnore(fset, file, target), test.src)
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comment      string
+		expectedKind directive.RangeKind
+		expected     *directive.Ignore
+	}{
+		{"//godernize:ignore-begin", directive.RangeBegin, &directive.Ignore{}},
+		{"//godernize:ignore-begin=ctxnil", directive.RangeBegin, &directive.Ignore{Names: []string{"ctxnil"}}},
+		{"//godernize:ignore-end=ctxnil, oserrors", directive.RangeEnd, &directive.Ignore{Names: []string{"ctxnil", "oserrors"}}},
+		{"//godernize:ignore-end", directive.RangeEnd, &directive.Ignore{}},
+		{
+			"//godernize:ignore-begin=ctxnil // callers, like tests, pass nil",
+			directive.RangeBegin,
+			&directive.Ignore{Names: []string{"ctxnil"}, Reason: "callers, like tests, pass nil"},
+		},
+		{"//godernize:ignore-end # done", directive.RangeEnd, &directive.Ignore{Reason: "done"}},
+		{"//godernize:ignore-beginning", directive.NotRange, nil},
+		{"//godernize:ignore=ctxnil", directive.NotRange, nil},
+		{"// some other comment", directive.NotRange, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.comment, func(t *testing.T) {
+			t.Parallel()
+
+			kind, result := directive.ParseRange(&ast.Comment{Text: test.comment})
+			if kind != test.expectedKind {
+				t.Errorf("ParseRange(%q) kind = %v, want %v", test.comment, kind, test.expectedKind)
+			}
+
+			assertIgnoreResult(t, test.expected, result, test.comment)
+		})
+	}
+}
+
+func TestIgnoreRanges(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+func f() {
+	//godernize:ignore-begin=ctxnil
+	_ = 1 // line 5
+	//godernize:ignore-begin=oserrors
+	_ = 2 // line 7
+	//godernize:ignore-end=ctxnil
+	_ = 3 // line 9
+	//godernize:ignore-end=rangeint
+	//godernize:ignore-end=oserrors
+	_ = 4 // line 12
+	//godernize:ignore-begin
+	_ = 5 // line 14
+}
+`
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	ranges := directive.IgnoreRanges(file)
+	if len(ranges) != 3 {
+		t.Fatalf("got %d ranges, want 3", len(ranges))
+	}
+
+	lineStart := func(line int) token.Pos { return fset.File(file.Pos()).LineStart(line) }
+
+	tests := []struct {
+		name   string
+		line   int
+		ignore []bool // whether each range contains the line
+	}{
+		{"before", 3, []bool{false, false, false}},
+		{"outer", 5, []bool{true, false, false}},
+		{"nested", 7, []bool{true, true, false}},
+		{"outer closed", 9, []bool{false, true, false}},
+		{"both closed", 12, []bool{false, false, false}},
+		{"unclosed", 14, []bool{false, false, true}},
+	}
+
+	for _, test := range tests {
+		for i, r := range ranges {
+			if got := r.Contains(lineStart(test.line) + 1); got != test.ignore[i] {
+				t.Errorf("%s: range %d (%v) contains line %d = %v, want %v", test.name, i, r.Names, test.line, got, test.ignore[i])
+			}
+		}
+	}
+
+	if !ranges[0].Closed || !ranges[1].Closed || ranges[2].Closed {
+		t.Errorf("closed = %v, %v, %v, want true, true, false", ranges[0].Closed, ranges[1].Closed, ranges[2].Closed)
+	}
+
+	if ranges[2].End != file.FileEnd {
+		t.Errorf("unclosed range ends at %v, want the end of the file %v", ranges[2].End, file.FileEnd)
+	}
+}
@@ -0,0 +1,303 @@
+// Package driver runs analyzers as a command, sharing the command-line
+// behavior of godernizecheck and the standalone commands of the analyzers.
+package driver
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/multichecker"
+	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Exit statuses of the commands. They are those of singlechecker and
+// multichecker, so that scripts handle every command alike.
+const (
+	// ExitOK is returned when no diagnostics are reported, and always with
+	// -json, whose output holds the diagnostics.
+	ExitOK = 0
+	// ExitError is returned when packages fail to load or an analyzer fails.
+	ExitError = 1
+	// ExitUsage is returned for invalid flags.
+	ExitUsage = 2
+	// ExitDiagnostics is returned when diagnostics are reported.
+	ExitDiagnostics = 3
+)
+
+const (
+	// summaryFlag prints the number of diagnostics of each analyzer.
+	summaryFlag  = "summary"
+	summaryUsage = "print the number of diagnostics of each analyzer to stderr"
+)
+
+// Main runs analyzers on the packages named by the command-line arguments and
+// exits with one of the exit statuses above. Without -summary, it leaves the
+// command line to singlechecker for a single analyzer and to multichecker
+// otherwise. With -summary, it runs Run, which accepts the flags registered
+// on flag.CommandLine besides those of the analyzers, and rejects -fix and the
+// other flags of the checkers that it does not implement.
+func Main(analyzers ...*analysis.Analyzer) {
+	// Registered so that the checkers accept the flag and list it in -help.
+	flag.Bool(summaryFlag, false, summaryUsage)
+
+	if !isSummaryRequested(os.Args[1:]) {
+		if len(analyzers) == 1 {
+			singlechecker.Main(analyzers[0])
+		}
+
+		multichecker.Main(analyzers...)
+	}
+
+	os.Exit(Run(os.Args[1:], flag.CommandLine, os.Stdout, os.Stderr, analyzers...))
+}
+
+// isSummaryRequested checks if the boolean -summary flag is set to true before
+// the first package pattern.
+func isSummaryRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			return false
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != summaryFlag {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+
+		return !hasValue || (err == nil && enabled)
+	}
+
+	return false
+}
+
+// Run parses args, analyzes the packages matching the remaining patterns, and
+// returns the exit status. Diagnostics are printed to stderr as singlechecker
+// and multichecker do, or to stdout with -json, and are followed on stderr by
+// their number per analyzer with -summary. The flags of the analyzers are
+// named as by singlechecker for a single analyzer, such as -strict, and as by
+// multichecker otherwise, such as -ctxnil.strict. The flags of extra, which
+// may be nil, are accepted too. The other flags of singlechecker and
+// multichecker, such as -fix, -c, and the profiling flags, are not
+// implemented and make it fail with ExitUsage.
+func Run(args []string, extra *flag.FlagSet, stdout, stderr io.Writer, analyzers ...*analysis.Analyzer) int {
+	flags := flag.NewFlagSet("godernize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	jsonOutput := flags.Bool("json", false, "emit JSON output")
+	tests := flags.Bool("test", true, "indicates whether test files should be analyzed, too")
+	summary := flags.Bool(summaryFlag, false, summaryUsage)
+
+	for _, analyzer := range analyzers {
+		prefix := analyzer.Name + "."
+		if len(analyzers) == 1 {
+			prefix = ""
+		}
+
+		analyzer.Flags.VisitAll(func(f *flag.Flag) {
+			flags.Var(f.Value, prefix+f.Name, f.Usage)
+		})
+	}
+
+	if extra != nil {
+		extra.VisitAll(func(f *flag.Flag) {
+			if flags.Lookup(f.Name) == nil {
+				flags.Var(f.Value, f.Name, f.Usage)
+			}
+		})
+	}
+
+	registerUnsupportedFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+
+		return ExitUsage
+	}
+
+	if name := unsupportedFlagSet(flags); name != "" {
+		fmt.Fprintf(stderr, "-%s is not supported with -%s; run the command without -%s to use it\n",
+			name, summaryFlag, summaryFlag)
+
+		return ExitUsage
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Tests: *tests}, flags.Args()...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return ExitError
+	}
+
+	status := ExitOK
+
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, pkgErr := range pkg.Errors {
+			fmt.Fprintln(stderr, pkgErr)
+
+			status = ExitError
+		}
+	})
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return ExitError
+	}
+
+	if diagnosticsStatus := printDiagnostics(graph, *jsonOutput, stdout, stderr); diagnosticsStatus != ExitOK {
+		status = diagnosticsStatus
+	}
+
+	if *summary {
+		printSummary(stderr, analyzers, graph)
+	}
+
+	return status
+}
+
+// unsupportedFlag is a flag of singlechecker and multichecker that Run does
+// not implement. Run accepts it only to reject it with a clear error rather
+// than as an unknown flag.
+type unsupportedFlag struct {
+	boolean bool
+	value   string
+}
+
+func (f *unsupportedFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.value
+}
+
+func (f *unsupportedFlag) Set(value string) error {
+	f.value = value
+
+	return nil
+}
+
+func (f *unsupportedFlag) IsBoolFlag() bool {
+	return f.boolean
+}
+
+// registerUnsupportedFlags registers the flags of singlechecker and
+// multichecker that Run does not implement: -fix, -c, -flags, -V, and the
+// debugging and profiling flags. Flags of the same name of the analyzers take
+// precedence.
+func registerUnsupportedFlags(flags *flag.FlagSet) {
+	for name, boolean := range map[string]bool{
+		"fix":        true,
+		"flags":      true,
+		"V":          true,
+		"c":          false,
+		"debug":      false,
+		"cpuprofile": false,
+		"memprofile": false,
+		"trace":      false,
+	} {
+		if flags.Lookup(name) == nil {
+			flags.Var(&unsupportedFlag{boolean: boolean}, name, "not supported with -"+summaryFlag)
+		}
+	}
+}
+
+// unsupportedFlagSet returns the name of an unsupported flag set on the
+// command line, or "" if there is none.
+func unsupportedFlagSet(flags *flag.FlagSet) string {
+	var name string
+
+	flags.Visit(func(f *flag.Flag) {
+		if _, ok := f.Value.(*unsupportedFlag); ok && name == "" {
+			name = f.Name
+		}
+	})
+
+	return name
+}
+
+// printDiagnostics prints the diagnostics of graph and returns the exit
+// status they lead to, as singlechecker and multichecker do.
+func printDiagnostics(graph *checker.Graph, jsonOutput bool, stdout, stderr io.Writer) int {
+	if jsonOutput {
+		if err := graph.PrintJSON(stdout); err != nil {
+			return ExitError
+		}
+
+		return ExitOK
+	}
+
+	if err := graph.PrintText(stderr, -1); err != nil {
+		return ExitError
+	}
+
+	failed, reported := false, false
+
+	for act := range graph.All() {
+		failed = failed || act.Err != nil
+		reported = reported || act.IsRoot && len(act.Diagnostics) > 0
+	}
+
+	switch {
+	case failed:
+		return ExitError
+	case reported:
+		return ExitDiagnostics
+	}
+
+	return ExitOK
+}
+
+// printSummary writes the number of diagnostics of each analyzer and their
+// total. Diagnostics of files shared by several packages, such as a package
+// and its test variant, are counted once, as they are printed once.
+func printSummary(w io.Writer, analyzers []*analysis.Analyzer, graph *checker.Graph) {
+	type key struct {
+		analyzer *analysis.Analyzer
+		pos, end token.Position
+		message  string
+	}
+
+	seen := make(map[key]bool)
+	counts := make(map[*analysis.Analyzer]int)
+
+	for _, act := range graph.Roots {
+		for _, diagnostic := range act.Diagnostics {
+			fset := act.Package.Fset
+			k := key{act.Analyzer, fset.Position(diagnostic.Pos), fset.Position(diagnostic.End), diagnostic.Message}
+
+			if !seen[k] {
+				seen[k] = true
+				counts[act.Analyzer]++
+			}
+		}
+	}
+
+	for _, analyzer := range analyzers {
+		fmt.Fprintf(w, "%s: %s\n", analyzer.Name, pluralize(counts[analyzer]))
+	}
+
+	fmt.Fprintf(w, "total: %s\n", pluralize(len(seen)))
+}
+
+func pluralize(n int) string {
+	if n == 1 {
+		return "1 diagnostic"
+	}
+
+	return fmt.Sprintf("%d diagnostics", n)
+}
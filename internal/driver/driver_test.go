@@ -0,0 +1,195 @@
+package driver_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jaeyeom/godernize"
+	"github.com/jaeyeom/godernize/ctxnil"
+	"github.com/jaeyeom/godernize/internal/driver"
+	"github.com/jaeyeom/godernize/oserrors"
+)
+
+// commands are the analyzers of the commands sharing the driver.
+//
+//nolint:gochecknoglobals // shared test table
+var commands = map[string][]*analysis.Analyzer{
+	"ctxnilgodernize":   {ctxnil.Analyzer},
+	"oserrorsgodernize": {oserrors.Analyzer},
+	"godernizecheck":    godernize.Analyzers(),
+}
+
+// exitStatusTests are the situations in which every command must exit with
+// the same status.
+//
+//nolint:gochecknoglobals // shared test table
+var exitStatusTests = []struct {
+	name string
+	args []string
+	want int
+}{
+	{"clean", []string{"./testdata/src/clean"}, driver.ExitOK},
+	{"diagnostics", []string{"./testdata/src/issues"}, driver.ExitDiagnostics},
+	{"summary", []string{"-summary", "./testdata/src/issues"}, driver.ExitDiagnostics},
+	{"json", []string{"-json", "./testdata/src/issues"}, driver.ExitOK},
+	{"missing package", []string{"./testdata/src/missing"}, driver.ExitError},
+	{"unknown flag", []string{"-unknown", "./testdata/src/clean"}, driver.ExitUsage},
+	{"help", []string{"-help"}, driver.ExitOK},
+	{"summary with fix", []string{"-summary", "-fix", "./testdata/src/issues"}, driver.ExitUsage},
+	{"summary with context", []string{"-summary", "-c=1", "./testdata/src/issues"}, driver.ExitUsage},
+	{"summary with profile", []string{"-summary", "-cpuprofile=cpu.out", "./testdata/src/clean"}, driver.ExitUsage},
+}
+
+// commandEnv names the command that TestMain runs with driver.Main instead of
+// the tests, so that TestMainExitStatus can run the commands as processes.
+const commandEnv = "GODERNIZE_DRIVER_TEST_COMMAND"
+
+func TestMain(m *testing.M) {
+	if name := os.Getenv(commandEnv); name != "" {
+		driver.Main(commands[name]...)
+	}
+
+	os.Exit(m.Run())
+}
+
+// TestExitStatus checks that every command exits with the same status for
+// the same situation.
+func TestExitStatus(t *testing.T) {
+	t.Parallel()
+
+	for name, analyzers := range commands {
+		for _, test := range exitStatusTests {
+			t.Run(name+"/"+test.name, func(t *testing.T) {
+				t.Parallel()
+
+				var stdout, stderr bytes.Buffer
+				if got := driver.Run(test.args, nil, &stdout, &stderr, analyzers...); got != test.want {
+					t.Errorf("Run(%q) = %d, want %d\nstderr:\n%s", test.args, got, test.want, stderr.String())
+				}
+			})
+		}
+	}
+}
+
+// TestMainExitStatus checks that Main exits with the statuses of Run, both
+// when it leaves the command line to singlechecker or multichecker and when
+// it runs Run for -summary.
+func TestMainExitStatus(t *testing.T) {
+	t.Parallel()
+
+	// The checkers of x/tools may fail to load packages with a newer Go
+	// toolchain than they support, which Run is not affected by.
+	if status, output := runCommand(t, "oserrorsgodernize", "./testdata/src/clean"); status != driver.ExitOK {
+		t.Skipf("singlechecker cannot analyze packages with this Go toolchain:\n%s", output)
+	}
+
+	for name := range commands {
+		for _, test := range exitStatusTests {
+			t.Run(name+"/"+test.name, func(t *testing.T) {
+				t.Parallel()
+
+				if got, output := runCommand(t, name, test.args...); got != test.want {
+					t.Errorf("%s %q exited with %d, want %d\noutput:\n%s", name, test.args, got, test.want, output)
+				}
+			})
+		}
+	}
+}
+
+// runCommand runs the named command with driver.Main in a process of the test
+// binary and returns its exit status and output.
+func runCommand(t *testing.T, name string, args ...string) (int, []byte) {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), commandEnv+"="+name)
+
+	output, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), output
+	}
+
+	if err != nil {
+		t.Fatalf("Failed to run %s: %v", name, err)
+	}
+
+	return driver.ExitOK, output
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"ctxnilgodernize", []string{"ctxnil: 1 diagnostic", "total: 1 diagnostic"}},
+		{"oserrorsgodernize", []string{"oserrors: 2 diagnostics", "total: 2 diagnostics"}},
+		{"godernizecheck", []string{"ctxnil: 1 diagnostic", "oserrors: 2 diagnostics", "rangeint: 0 diagnostics", "total: 3 diagnostics"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.command, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			driver.Run([]string{"-summary", "./testdata/src/issues"}, nil, &stdout, &stderr, commands[test.command]...)
+
+			lines := strings.Split(stderr.String(), "\n")
+			for _, want := range test.want {
+				if !containsLine(lines, want) {
+					t.Errorf("stderr has no line %q:\n%s", want, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+// TestJSON checks that -json prints the diagnostics to stdout, keyed by
+// package and analyzer as singlechecker and multichecker do.
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	if got := driver.Run([]string{"-json", "-test=false", "./testdata/src/issues"}, nil, &stdout, &stderr,
+		commands["oserrorsgodernize"]...); got != driver.ExitOK {
+		t.Fatalf("Run = %d, want %d\nstderr:\n%s", got, driver.ExitOK, stderr.String())
+	}
+
+	var tree map[string]map[string][]struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &tree); err != nil {
+		t.Fatalf("Failed to decode %s: %v", stdout.String(), err)
+	}
+
+	count := 0
+	for _, byAnalyzer := range tree {
+		count += len(byAnalyzer["oserrors"])
+	}
+
+	if count != 2 {
+		t.Errorf("got %d oserrors diagnostics, want 2:\n%s", count, stdout.String())
+	}
+}
+
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+
+	return false
+}
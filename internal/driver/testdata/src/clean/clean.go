@@ -0,0 +1,11 @@
+package clean
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+)
+
+func run(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,14 @@
+package issues
+
+import (
+	"context"
+	"os"
+)
+
+func run(ctx context.Context, err error) bool {
+	if ctx == nil {
+		return false
+	}
+
+	return ctx.Err() == nil && (os.IsNotExist(err) || os.IsExist(err))
+}
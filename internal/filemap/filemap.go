@@ -0,0 +1,62 @@
+// Package filemap finds the file of a syntax node without computing its
+// position.
+package filemap
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+)
+
+// Map finds which of a package's files contains a position. Unlike a map
+// keyed by file name, it needs no token.FileSet lookup per node and is not
+// confused by //line directives.
+type Map struct {
+	files []*ast.File // sorted by FileStart
+}
+
+// New returns a Map of files.
+func New(files []*ast.File) *Map {
+	sorted := slices.Clone(files)
+	slices.SortFunc(sorted, func(a, b *ast.File) int { return int(start(a)) - int(start(b)) })
+
+	return &Map{files: sorted}
+}
+
+// File returns the file containing pos, or nil if there is none.
+func (m *Map) File(pos token.Pos) *ast.File {
+	if m == nil || !pos.IsValid() {
+		return nil
+	}
+
+	i, found := slices.BinarySearchFunc(m.files, pos, func(file *ast.File, pos token.Pos) int {
+		return int(start(file)) - int(pos)
+	})
+	if !found {
+		i--
+	}
+
+	if i < 0 || pos > end(m.files[i]) {
+		return nil
+	}
+
+	return m.files[i]
+}
+
+// start returns the start of file, falling back to the package clause for
+// files not produced by the parser.
+func start(file *ast.File) token.Pos {
+	if file.FileStart.IsValid() {
+		return file.FileStart
+	}
+
+	return file.Pos()
+}
+
+func end(file *ast.File) token.Pos {
+	if file.FileEnd.IsValid() {
+		return file.FileEnd
+	}
+
+	return file.End()
+}
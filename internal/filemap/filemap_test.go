@@ -0,0 +1,139 @@
+package filemap_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	fset, files := parseFiles(t, 5, 3)
+
+	// A //line directive makes positions report another file name.
+	lined, err := parser.ParseFile(fset, "lined.go", "package p\n\n//line other.go:1\nfunc lined() {}\n", 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	files = append(files, lined)
+
+	// Reverse the files to check that New does not rely on their order.
+	reversed := make([]*ast.File, len(files))
+	for i, file := range files {
+		reversed[len(files)-1-i] = file
+	}
+
+	m := filemap.New(reversed)
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n == nil {
+				return false
+			}
+
+			if got := m.File(n.Pos()); got != file {
+				t.Errorf("File(%s) = %v, want %s", fset.Position(n.Pos()), got, file.Name.Name)
+			}
+
+			return true
+		})
+
+		if got := m.File(file.FileEnd); got != file {
+			t.Errorf("File(end of %s) did not return the file", fset.File(file.Pos()).Name())
+		}
+	}
+
+	// fset.Base is past the last file added.
+	if got := m.File(token.Pos(fset.Base())); got != nil {
+		t.Errorf("File(position after all files) = %v, want nil", got)
+	}
+
+	if got := m.File(token.NoPos); got != nil {
+		t.Errorf("File(NoPos) = %v, want nil", got)
+	}
+
+	var nilMap *filemap.Map
+	if got := nilMap.File(files[0].Pos()); got != nil {
+		t.Errorf("nil Map File = %v, want nil", got)
+	}
+}
+
+// BenchmarkFile compares finding the file of every node of a package with
+// many files through its position and file name, as the analyzers used to,
+// with a Map.
+func BenchmarkFile(b *testing.B) {
+	fset, files := parseFiles(b, 200, 50)
+
+	var nodes []ast.Node
+
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if n != nil {
+				nodes = append(nodes, n)
+			}
+
+			return true
+		})
+	}
+
+	b.Run("position", func(b *testing.B) {
+		for range b.N {
+			byName := make(map[string]*ast.File)
+			for _, file := range files {
+				byName[fset.Position(file.Pos()).Filename] = file
+			}
+
+			for _, n := range nodes {
+				if byName[fset.Position(n.Pos()).Filename] == nil {
+					b.Fatal("file not found")
+				}
+			}
+		}
+	})
+
+	b.Run("filemap", func(b *testing.B) {
+		for range b.N {
+			m := filemap.New(files)
+
+			for _, n := range nodes {
+				if m.File(n.Pos()) == nil {
+					b.Fatal("file not found")
+				}
+			}
+		}
+	})
+}
+
+// parseFiles parses count files of funcs functions each.
+func parseFiles(tb testing.TB, count, funcs int) (*token.FileSet, []*ast.File) {
+	tb.Helper()
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, count)
+
+	for i := range count {
+		var src strings.Builder
+
+		fmt.Fprintf(&src, "package p\n\n")
+
+		for j := range funcs {
+			fmt.Fprintf(&src, "func f%d_%d(a, b int) int {\n\tif a > b {\n\t\treturn a - b\n\t}\n\n\treturn a + b\n}\n\n", i, j)
+		}
+
+		file, err := parser.ParseFile(fset, fmt.Sprintf("f%d.go", i), src.String(), 0)
+		if err != nil {
+			tb.Fatalf("Failed to parse: %v", err)
+		}
+
+		files[i] = file
+	}
+
+	return fset, files
+}
@@ -0,0 +1,129 @@
+// Package fix applies the suggested fixes of analyzer diagnostics to source
+// files.
+package fix
+
+import (
+	"cmp"
+	"fmt"
+	"go/format"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jaeyeom/godernize"
+)
+
+// Apply computes the contents of every file changed by the first suggested
+// fix of each result. Fixes are applied in source order, and a fix whose edits
+// overlap those of a fix applied before it is skipped as a whole and returned
+// with the skipped results, so that no file is left half fixed. Identical
+// edits, such as the same import added by two fixes, are applied once. The
+// files are read from disk and the new contents are formatted with gofmt.
+func Apply(results []godernize.Result) (map[string][]byte, []godernize.Result, error) {
+	sorted := slices.Clone(results)
+	slices.SortStableFunc(sorted, compareResults)
+
+	accepted := make(map[string][]godernize.TextEdit)
+
+	var skipped []godernize.Result
+
+	for _, result := range sorted {
+		if len(result.SuggestedFixes) == 0 {
+			continue
+		}
+
+		edits := result.SuggestedFixes[0].Edits
+		if overlapsAny(accepted, edits) {
+			skipped = append(skipped, result)
+
+			continue
+		}
+
+		for _, edit := range edits {
+			filename := edit.Pos.Filename
+			if !slices.Contains(accepted[filename], edit) {
+				accepted[filename] = append(accepted[filename], edit)
+			}
+		}
+	}
+
+	files := make(map[string][]byte, len(accepted))
+
+	for filename, edits := range accepted {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fixed, err := applyEdits(src, edits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		formatted, err := format.Source(fixed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: fixed source is invalid: %w", filename, err)
+		}
+
+		files[filename] = formatted
+	}
+
+	return files, skipped, nil
+}
+
+func compareResults(a, b godernize.Result) int {
+	return cmp.Or(
+		strings.Compare(a.Pos.Filename, b.Pos.Filename),
+		cmp.Compare(a.Pos.Offset, b.Pos.Offset),
+		strings.Compare(a.Analyzer, b.Analyzer),
+		strings.Compare(a.Message, b.Message),
+	)
+}
+
+// overlapsAny checks if any of edits overlaps an accepted edit of its file
+// without being identical to it.
+func overlapsAny(accepted map[string][]godernize.TextEdit, edits []godernize.TextEdit) bool {
+	for _, edit := range edits {
+		for _, other := range accepted[edit.Pos.Filename] {
+			if edit != other && overlaps(edit, other) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// overlaps checks if a and b replace some common text, or insert text at the
+// same offset, where their order would be ambiguous.
+func overlaps(a, b godernize.TextEdit) bool {
+	if a.Pos.Offset == a.End.Offset && b.Pos.Offset == b.End.Offset {
+		return a.Pos.Offset == b.Pos.Offset
+	}
+
+	return a.Pos.Offset < b.End.Offset && b.Pos.Offset < a.End.Offset
+}
+
+// applyEdits returns src with the non-overlapping edits applied.
+func applyEdits(src []byte, edits []godernize.TextEdit) ([]byte, error) {
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(a, b godernize.TextEdit) int {
+		return cmp.Or(cmp.Compare(a.Pos.Offset, b.Pos.Offset), cmp.Compare(a.End.Offset, b.End.Offset))
+	})
+
+	var out []byte
+
+	last := 0
+
+	for _, edit := range edits {
+		if edit.Pos.Offset < last || edit.End.Offset < edit.Pos.Offset || edit.End.Offset > len(src) {
+			return nil, fmt.Errorf("invalid edit at offset %d", edit.Pos.Offset)
+		}
+
+		out = append(out, src[last:edit.Pos.Offset]...)
+		out = append(out, edit.NewText...)
+		last = edit.End.Offset
+	}
+
+	return append(out, src[last:]...), nil
+}
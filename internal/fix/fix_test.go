@@ -0,0 +1,108 @@
+package fix_test
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jaeyeom/godernize"
+	"github.com/jaeyeom/godernize/internal/fix"
+)
+
+const src = `package p
+
+func f() int { return 1 + 2 }
+`
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(filename, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// edit replaces the first occurrence of old in src.
+	edit := func(old, newText string) godernize.TextEdit {
+		offset := indexOf(t, old)
+
+		return godernize.TextEdit{
+			Pos:     token.Position{Filename: filename, Offset: offset},
+			End:     token.Position{Filename: filename, Offset: offset + len(old)},
+			NewText: newText,
+		}
+	}
+
+	result := func(analyzer string, edits ...godernize.TextEdit) godernize.Result {
+		return godernize.Result{
+			Analyzer:       analyzer,
+			Pos:            edits[0].Pos,
+			SuggestedFixes: []godernize.SuggestedFix{{Edits: edits}},
+		}
+	}
+
+	afterPackage := token.Position{Filename: filename, Offset: len("package p\n")}
+	insertImport := godernize.TextEdit{Pos: afterPackage, End: afterPackage, NewText: "\nimport \"fmt\"\n"}
+
+	results := []godernize.Result{
+		result("sum", edit("1 + 2", "3")),
+		result("name", edit("f()", "g()"), insertImport),
+		result("tail", edit("1", "one"), insertImport),
+		result("other", edit("int", "any"), insertImport),
+		{Analyzer: "nofix", Pos: token.Position{Filename: filename}},
+	}
+
+	files, skipped, err := fix.Apply(results)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	want := "package p\n\nimport \"fmt\"\n\nfunc g() any { return 3 }\n"
+	if got := string(files[filename]); got != want {
+		t.Errorf("fixed source = %q, want %q", got, want)
+	}
+
+	if len(skipped) != 1 || skipped[0].Analyzer != "tail" {
+		t.Errorf("skipped = %v, want only the fix overlapping 1 + 2", skipped)
+	}
+
+	if content, _ := os.ReadFile(filename); string(content) != src {
+		t.Error("Apply modified the file on disk")
+	}
+}
+
+func TestApplyInvalidSource(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "p.go")
+	if err := os.WriteFile(filename, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	offset := indexOf(t, "1 + 2")
+	results := []godernize.Result{{
+		Analyzer: "broken",
+		SuggestedFixes: []godernize.SuggestedFix{{Edits: []godernize.TextEdit{{
+			Pos:     token.Position{Filename: filename, Offset: offset},
+			End:     token.Position{Filename: filename, Offset: offset + len("1 + 2")},
+			NewText: "1 +",
+		}}}},
+	}}
+
+	if _, _, err := fix.Apply(results); err == nil {
+		t.Error("Apply accepted a fix producing invalid source")
+	}
+}
+
+func indexOf(t *testing.T, s string) int {
+	t.Helper()
+
+	i := strings.Index(src, s)
+	if i < 0 {
+		t.Fatalf("%q not found in source", s)
+	}
+
+	return i
+}
@@ -0,0 +1,36 @@
+// Package gofile reports properties of parsed Go files shared by the
+// analyzers.
+package gofile
+
+import (
+	"go/ast"
+	"regexp"
+)
+
+// generatedHeader matches the comment marking generated code, as defined in
+// https://go.dev/s/generatedcode.
+var generatedHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// IsGenerated reports whether file carries the standard header of generated
+// code before its package clause. Unlike ast.IsGenerated, it stops at the
+// package clause instead of visiting every comment of the file, so it only
+// scans the leading comments.
+func IsGenerated(file *ast.File) bool {
+	if file == nil {
+		return false
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			return false
+		}
+
+		for _, comment := range group.List {
+			if generatedHeader.MatchString(comment.Text) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
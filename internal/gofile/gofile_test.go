@@ -0,0 +1,79 @@
+package gofile_test
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/jaeyeom/godernize/internal/gofile"
+)
+
+func TestIsGenerated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		want bool
+	}{
+		{
+			name: "header",
+			src:  "// Code generated by gen. DO NOT EDIT.\n\npackage p\n",
+			want: true,
+		},
+		{
+			name: "header in a later group",
+			src:  "//go:build linux\n\n// Code generated by gen. DO NOT EDIT.\n\n// Package p is generated.\npackage p\n",
+			want: true,
+		},
+		{
+			name: "header after package clause",
+			src:  "package p\n\n// Code generated by gen. DO NOT EDIT.\n\nvar x int\n",
+			want: false,
+		},
+		{
+			name: "header without period",
+			src:  "// Code generated by gen. DO NOT EDIT\n\npackage p\n",
+			want: false,
+		},
+		{
+			name: "header with trailing text",
+			src:  "// Code generated by gen. DO NOT EDIT. Really.\n\npackage p\n",
+			want: false,
+		},
+		{
+			name: "header in block comment",
+			src:  "/* Code generated by gen. DO NOT EDIT. */\n\npackage p\n",
+			want: false,
+		},
+		{
+			name: "handwritten",
+			src:  "// Package p is handwritten.\npackage p\n",
+			want: false,
+		},
+		{
+			name: "no comments",
+			src:  "package p\n\nvar x int\n",
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := parser.ParseFile(token.NewFileSet(), "p.go", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			if got := gofile.IsGenerated(file); got != test.want {
+				t.Errorf("IsGenerated() = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if gofile.IsGenerated(nil) {
+		t.Error("IsGenerated(nil) = true, want false")
+	}
+}
@@ -0,0 +1,417 @@
+// Package importutil computes import declaration edits that accompany
+// suggested fixes.
+package importutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Name returns the identifier under which path is imported in file, or "" if
+// path is not imported or is imported for side effects or with a dot.
+func Name(file *ast.File, importPath string) string {
+	spec := findSpec(file, importPath)
+	if spec == nil {
+		return ""
+	}
+
+	if spec.Name == nil {
+		return path.Base(importPath)
+	}
+
+	if spec.Name.Name == "_" || spec.Name.Name == "." {
+		return ""
+	}
+
+	return spec.Name.Name
+}
+
+// LocalName returns the identifier to qualify references to importPath with,
+// which is the existing import name or the default name for a new import.
+func LocalName(file *ast.File, importPath string) string {
+	if name := Name(file, importPath); name != "" {
+		return name
+	}
+
+	return path.Base(importPath)
+}
+
+// UsedOutside reports whether the package imported from importPath is
+// referenced in file anywhere outside the excluded nodes.
+func UsedOutside(info *types.Info, file *ast.File, importPath string, excluded ...ast.Node) bool {
+	if info == nil || file == nil {
+		return false
+	}
+
+	ranges := mergeRanges(excluded)
+	used := false
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if used {
+			return false
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		pkgName, ok := info.Uses[ident].(*types.PkgName)
+		if ok && pkgName.Imported().Path() == importPath && !ranges.contain(ident) {
+			used = true
+		}
+
+		return true
+	})
+
+	return used
+}
+
+// OnlyUsedIn returns the paths of the packages imported by file that are
+// referenced within nodes and nowhere else, in the order of the imports.
+// Deleting nodes leaves these imports unused.
+func OnlyUsedIn(info *types.Info, file *ast.File, nodes ...ast.Node) []string {
+	if info == nil || file == nil {
+		return nil
+	}
+
+	ranges := mergeRanges(nodes)
+	inside := make(map[string]bool)
+	outside := make(map[string]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		if pkgName, ok := info.Uses[ident].(*types.PkgName); ok {
+			if ranges.contain(ident) {
+				inside[pkgName.Imported().Path()] = true
+			} else {
+				outside[pkgName.Imported().Path()] = true
+			}
+		}
+
+		return true
+	})
+
+	var paths []string
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && inside[importPath] && !outside[importPath] && !slices.Contains(paths, importPath) {
+			paths = append(paths, importPath)
+		}
+	}
+
+	return paths
+}
+
+// posRange is the source range of a node, End exclusive.
+type posRange struct {
+	pos, end token.Pos
+}
+
+// posRanges are disjoint ranges sorted by position.
+type posRanges []posRange
+
+// mergeRanges returns the ranges of nodes, merging overlapping ones, so that
+// each lookup is a binary search even with many excluded nodes.
+func mergeRanges(nodes []ast.Node) posRanges {
+	var ranges posRanges
+
+	for _, node := range nodes {
+		if node != nil {
+			ranges = append(ranges, posRange{node.Pos(), node.End()})
+		}
+	}
+
+	slices.SortFunc(ranges, func(a, b posRange) int { return int(a.pos) - int(b.pos) })
+
+	merged := ranges[:0]
+
+	for _, r := range ranges {
+		if last := len(merged) - 1; last >= 0 && r.pos < merged[last].end {
+			merged[last].end = max(merged[last].end, r.end)
+
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+// contain checks if node lies within one of the ranges.
+func (ranges posRanges) contain(node ast.Node) bool {
+	i, _ := slices.BinarySearchFunc(ranges, node.Pos(), func(r posRange, pos token.Pos) int {
+		return int(r.pos) - int(pos)
+	})
+
+	// ranges[i-1] is the last range starting at or before node, unless
+	// ranges[i] starts exactly at node.
+	if i < len(ranges) && ranges[i].pos == node.Pos() {
+		return node.End() <= ranges[i].end
+	}
+
+	return i > 0 && node.End() <= ranges[i-1].end
+}
+
+// Edits returns the text edits that import every path in add that file does
+// not import yet and delete the imports of every path in remove.
+//
+// A removed import is rewritten in place to an added one where possible, so
+// the returned edits never overlap.
+func Edits(fset *token.FileSet, file *ast.File, add, remove []string) []analysis.TextEdit {
+	if fset == nil || file == nil {
+		return nil
+	}
+
+	var toAdd []string
+
+	for _, importPath := range add {
+		if findSpec(file, importPath) == nil && !slices.Contains(toAdd, importPath) {
+			toAdd = append(toAdd, importPath)
+		}
+	}
+
+	var toRemove []*ast.ImportSpec
+
+	for _, importPath := range remove {
+		if spec := findSpec(file, importPath); spec != nil {
+			toRemove = append(toRemove, spec)
+		}
+	}
+
+	// Reuse the slots of removed imports for added ones.
+	reused := make(map[*ast.ImportSpec]string)
+
+	var reuseEdits []analysis.TextEdit
+
+	for len(toAdd) > 0 && len(toRemove) > 0 {
+		reused[toRemove[0]] = strconv.Quote(toAdd[0])
+		reuseEdits = append(reuseEdits, analysis.TextEdit{
+			Pos:     toRemove[0].Pos(),
+			End:     toRemove[0].End(),
+			NewText: []byte(reused[toRemove[0]]),
+		})
+		toAdd, toRemove = toAdd[1:], toRemove[1:]
+	}
+
+	adds := addEdits(file, toAdd, reused)
+
+	// An import turned into a block for the remaining added imports already
+	// carries its reused path, and rewriting it again would overlap.
+	edits := slices.DeleteFunc(reuseEdits, func(edit analysis.TextEdit) bool {
+		return slices.ContainsFunc(adds, func(add analysis.TextEdit) bool { return add.Pos == edit.Pos })
+	})
+
+	edits = append(edits, deleteEdits(fset, file, toRemove)...)
+
+	return append(edits, adds...)
+}
+
+func findSpec(file *ast.File, importPath string) *ast.ImportSpec {
+	if file == nil {
+		return nil
+	}
+
+	quoted := strconv.Quote(importPath)
+
+	for _, spec := range file.Imports {
+		if spec != nil && spec.Path != nil && spec.Path.Value == quoted {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+func isStd(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+
+	return !strings.Contains(first, ".")
+}
+
+func specPath(spec ast.Spec) string {
+	importSpec, ok := spec.(*ast.ImportSpec)
+	if !ok || importSpec.Path == nil {
+		return ""
+	}
+
+	importPath, err := strconv.Unquote(importSpec.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	return importPath
+}
+
+func importDecls(file *ast.File) []*ast.GenDecl {
+	var decls []*ast.GenDecl
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			break // imports always come first
+		}
+
+		decls = append(decls, genDecl)
+	}
+
+	return decls
+}
+
+// addEdits returns the edits inserting new imports of importPaths. Imports
+// inserted at the same position share one edit, since separate insertions at
+// one position would overlap. Specs in reused are rewritten to their new
+// quoted path when turned into a block.
+func addEdits(file *ast.File, importPaths []string, reused map[*ast.ImportSpec]string) []analysis.TextEdit {
+	if len(importPaths) == 0 {
+		return nil
+	}
+
+	quoted := make([]string, len(importPaths))
+	for i, importPath := range importPaths {
+		quoted[i] = strconv.Quote(importPath)
+	}
+
+	decls := importDecls(file)
+
+	for _, decl := range decls {
+		if decl.Lparen.IsValid() && len(decl.Specs) > 0 {
+			var edits []analysis.TextEdit
+
+		paths:
+			for i, importPath := range importPaths {
+				pos := insertionPoint(decl, importPath)
+
+				for j := range edits {
+					if edits[j].Pos == pos {
+						edits[j].NewText = append(edits[j].NewText, "\n\t"+quoted[i]...)
+
+						continue paths
+					}
+				}
+
+				edits = append(edits, analysis.TextEdit{Pos: pos, End: pos, NewText: []byte("\n\t" + quoted[i])})
+			}
+
+			return edits
+		}
+	}
+
+	if len(decls) > 0 && len(decls[0].Specs) == 1 {
+		// Turn "import x" into a parenthesized block.
+		spec, _ := decls[0].Specs[0].(*ast.ImportSpec)
+		specText := spec.Path.Value
+
+		if spec.Name != nil {
+			specText = spec.Name.Name + " " + specText
+		}
+
+		if path, ok := reused[spec]; ok {
+			specText = path
+		}
+
+		return []analysis.TextEdit{{
+			Pos:     spec.Pos(),
+			End:     spec.End(),
+			NewText: []byte("(\n\t" + specText + "\n\t" + strings.Join(quoted, "\n\t") + "\n)"),
+		}}
+	}
+
+	text := "\n\nimport " + quoted[0]
+	if len(quoted) > 1 {
+		text = "\n\nimport (\n\t" + strings.Join(quoted, "\n\t") + "\n)"
+	}
+
+	return []analysis.TextEdit{{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte(text)}}
+}
+
+// insertionPoint returns the position after the last spec in decl belonging
+// to the same group (standard library or not) as importPath.
+func insertionPoint(decl *ast.GenDecl, importPath string) token.Pos {
+	var last ast.Spec
+
+	for _, spec := range decl.Specs {
+		if isStd(specPath(spec)) == isStd(importPath) {
+			last = spec
+		}
+	}
+
+	if last == nil {
+		last = decl.Specs[len(decl.Specs)-1]
+	}
+
+	if importSpec, ok := last.(*ast.ImportSpec); ok && importSpec.Comment != nil {
+		return importSpec.Comment.End()
+	}
+
+	return last.End()
+}
+
+// deleteEdits returns edits deleting the lines of the given specs, dropping
+// whole declarations whose specs are all removed.
+func deleteEdits(fset *token.FileSet, file *ast.File, specs []*ast.ImportSpec) []analysis.TextEdit {
+	if len(specs) == 0 {
+		return nil
+	}
+
+	removed := make(map[ast.Spec]bool, len(specs))
+	for _, spec := range specs {
+		removed[spec] = true
+	}
+
+	var edits []analysis.TextEdit
+
+	for _, decl := range importDecls(file) {
+		remaining := 0
+
+		for _, spec := range decl.Specs {
+			if !removed[spec] {
+				remaining++
+			}
+		}
+
+		if remaining == 0 {
+			edits = append(edits, lineEdit(fset, decl))
+
+			continue
+		}
+
+		for _, spec := range decl.Specs {
+			if removed[spec] {
+				edits = append(edits, lineEdit(fset, spec))
+			}
+		}
+	}
+
+	return edits
+}
+
+// lineEdit returns an edit deleting the full lines spanned by node.
+func lineEdit(fset *token.FileSet, node ast.Node) analysis.TextEdit {
+	tokFile := fset.File(node.Pos())
+	if tokFile == nil {
+		return analysis.TextEdit{Pos: node.Pos(), End: node.End()}
+	}
+
+	start := tokFile.LineStart(tokFile.Line(node.Pos()))
+	end := node.End()
+
+	if endLine := tokFile.Line(node.End()); endLine < tokFile.LineCount() {
+		end = tokFile.LineStart(endLine + 1)
+	}
+
+	return analysis.TextEdit{Pos: start, End: end}
+}
@@ -0,0 +1,294 @@
+package importutil_test
+
+import (
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+type editsTestCase struct {
+	name     string
+	src      string
+	add      []string
+	remove   []string
+	expected string
+}
+
+func TestEdits(t *testing.T) {
+	t.Parallel()
+
+	tests := []editsTestCase{
+		{
+			name:     "add to block",
+			src:      "package p\n\nimport (\n\t\"fmt\"\n\n\t\"golang.org/x/tools/go/analysis\"\n)\n",
+			add:      []string{"slices"},
+			expected: "package p\n\nimport (\n\t\"fmt\"\n\t\"slices\"\n\n\t\"golang.org/x/tools/go/analysis\"\n)\n",
+		},
+		{
+			name:     "add to single import",
+			src:      "package p\n\nimport \"fmt\"\n",
+			add:      []string{"errors"},
+			expected: "package p\n\nimport (\n\t\"errors\"\n\t\"fmt\"\n)\n",
+		},
+		{
+			name:     "add without imports",
+			src:      "package p\n\nvar x = 1\n",
+			add:      []string{"errors"},
+			expected: "package p\n\nimport \"errors\"\n\nvar x = 1\n",
+		},
+		{
+			name:     "add several to block",
+			src:      "package p\n\nimport (\n\t\"fmt\"\n)\n",
+			add:      []string{"maps", "slices"},
+			expected: "package p\n\nimport (\n\t\"fmt\"\n\t\"maps\"\n\t\"slices\"\n)\n",
+		},
+		{
+			name:     "add several to single import",
+			src:      "package p\n\nimport \"fmt\"\n",
+			add:      []string{"maps", "slices"},
+			expected: "package p\n\nimport (\n\t\"fmt\"\n\t\"maps\"\n\t\"slices\"\n)\n",
+		},
+		{
+			name:     "add several without imports",
+			src:      "package p\n\nvar x = 1\n",
+			add:      []string{"maps", "slices"},
+			expected: "package p\n\nimport (\n\t\"maps\"\n\t\"slices\"\n)\n\nvar x = 1\n",
+		},
+		{
+			name:     "add existing",
+			src:      "package p\n\nimport \"fmt\"\n",
+			add:      []string{"fmt"},
+			expected: "package p\n\nimport \"fmt\"\n",
+		},
+		{
+			name:     "remove from block",
+			src:      "package p\n\nimport (\n\t\"fmt\"\n\t\"sort\"\n)\n",
+			remove:   []string{"sort"},
+			expected: "package p\n\nimport (\n\t\"fmt\"\n)\n",
+		},
+		{
+			name:     "remove last import",
+			src:      "package p\n\nimport (\n\t\"errors\"\n)\n\nvar x = 1\n",
+			remove:   []string{"errors"},
+			expected: "package p\n\nvar x = 1\n",
+		},
+		{
+			name:     "replace",
+			src:      "package p\n\nimport (\n\t\"fmt\"\n\t\"sort\"\n)\n",
+			add:      []string{"slices"},
+			remove:   []string{"sort"},
+			expected: "package p\n\nimport (\n\t\"fmt\"\n\t\"slices\"\n)\n",
+		},
+		{
+			name:     "replace single import with several",
+			src:      "package p\n\nimport \"os\"\n",
+			add:      []string{"errors", "io/fs"},
+			remove:   []string{"os"},
+			expected: "package p\n\nimport (\n\t\"errors\"\n\t\"io/fs\"\n)\n",
+		},
+		{
+			name:     "replace single aliased import with several",
+			src:      "package p\n\nimport o \"os\"\n",
+			add:      []string{"errors", "io/fs"},
+			remove:   []string{"os"},
+			expected: "package p\n\nimport (\n\t\"errors\"\n\t\"io/fs\"\n)\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fset := token.NewFileSet()
+
+			file, err := parser.ParseFile(fset, "p.go", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+
+			edits := importutil.Edits(fset, file, test.add, test.remove)
+			got := applyEdits(t, fset, file, test.src, edits)
+
+			if got != test.expected {
+				t.Errorf("Edits() result mismatch\ngot:\n%s\nwant:\n%s", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	src := "package p\n\nimport (\n\t\"fmt\"\n\tstdstrings \"strings\"\n\t_ \"embed\"\n)\n"
+
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	for path, expected := range map[string]string{
+		"fmt":     "fmt",
+		"strings": "stdstrings",
+		"embed":   "",
+		"errors":  "",
+	} {
+		if got := importutil.Name(file, path); got != expected {
+			t.Errorf("Name(%q) = %q, want %q", path, got, expected)
+		}
+	}
+
+	if got := importutil.LocalName(file, "errors"); got != "errors" {
+		t.Errorf("LocalName(%q) = %q, want %q", "errors", got, "errors")
+	}
+}
+
+func TestUsedOutside(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+import "strings"
+
+func f(s string) bool {
+	return strings.HasPrefix(s, "a") || strings.HasSuffix(s, "b") || g(strings.ToUpper(s))
+}
+
+func g(s string) bool { return s != "" }
+`
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	info := &types.Info{Uses: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("Failed to type-check: %v", err)
+	}
+
+	var calls []ast.Node
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			calls = append(calls, call)
+		}
+
+		return true
+	})
+
+	// calls are HasPrefix, HasSuffix, g and ToUpper, which is nested in g.
+	hasPrefix, hasSuffix, callG := calls[0], calls[1], calls[2]
+
+	for _, tc := range []struct {
+		name     string
+		excluded []ast.Node
+		expected bool
+	}{
+		{"nothing excluded", nil, true},
+		{"some calls excluded", []ast.Node{hasSuffix, hasPrefix}, true},
+		{"all calls excluded", []ast.Node{hasSuffix, callG, hasPrefix}, false},
+		{"nested calls excluded", []ast.Node{calls[3], hasPrefix, callG, hasSuffix, nil}, false},
+	} {
+		if got := importutil.UsedOutside(info, file, "strings", tc.excluded...); got != tc.expected {
+			t.Errorf("%s: UsedOutside = %v, want %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func TestOnlyUsedIn(t *testing.T) {
+	t.Parallel()
+
+	src := `package p
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+func f(s string) {
+	if s == "" {
+		log.Fatal(strings.ToUpper(s))
+	}
+
+	fmt.Println(strings.TrimSpace(s))
+}
+`
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	info := &types.Info{Uses: make(map[*ast.Ident]types.Object)}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("Failed to type-check: %v", err)
+	}
+
+	funcDecl, ok := file.Decls[1].(*ast.FuncDecl)
+	if !ok {
+		t.Fatalf("Decls[1] is %T, want *ast.FuncDecl", file.Decls[1])
+	}
+
+	ifStmt, printStmt := funcDecl.Body.List[0], funcDecl.Body.List[1]
+
+	for _, tc := range []struct {
+		name     string
+		nodes    []ast.Node
+		expected []string
+	}{
+		{"nothing", nil, nil},
+		{"if statement", []ast.Node{ifStmt}, []string{"log"}},
+		{"print statement", []ast.Node{printStmt}, []string{"fmt"}},
+		{"both statements", []ast.Node{printStmt, ifStmt}, []string{"fmt", "log", "strings"}},
+	} {
+		if got := importutil.OnlyUsedIn(info, file, tc.nodes...); !slices.Equal(got, tc.expected) {
+			t.Errorf("%s: OnlyUsedIn = %v, want %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+func applyEdits(t *testing.T, fset *token.FileSet, file *ast.File, src string, edits []analysis.TextEdit) string {
+	t.Helper()
+
+	tokFile := fset.File(file.Pos())
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos > edits[j].Pos })
+
+	out := src
+
+	for _, edit := range edits {
+		end := edit.End
+		if !end.IsValid() {
+			end = edit.Pos
+		}
+
+		start, stop := tokFile.Offset(edit.Pos), tokFile.Offset(end)
+		out = out[:start] + string(edit.NewText) + out[stop:]
+	}
+
+	formatted, err := format.Source([]byte(out))
+	if err != nil {
+		t.Fatalf("Failed to format %q: %v", out, err)
+	}
+
+	return string(formatted)
+}
@@ -0,0 +1,264 @@
+// Package report writes analyzer diagnostics in formats consumed by CI
+// systems.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jaeyeom/godernize"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "godernize"
+	toolURI      = "https://github.com/jaeyeom/godernize"
+)
+
+// Level returns the SARIF level of a result, derived from its category:
+// "error" and "note", alone or as the last dot-separated part of an analyzer
+// category such as "ctxnil.error", are kept as is, and anything else is a
+// "warning".
+func Level(result godernize.Result) string {
+	category := result.Category
+	if i := strings.LastIndex(category, "."); i >= 0 {
+		category = category[i+1:]
+	}
+
+	switch category {
+	case "error", "note":
+		return category
+	default:
+		return "warning"
+	}
+}
+
+// WriteSARIF writes results as a SARIF 2.1.0 log with a single run. Every
+// analyzer becomes a rule, even if it reported nothing, so that consumers can
+// tell a clean result from a missing one. File paths are made relative to
+// baseDir when they are inside it.
+func WriteSARIF(w io.Writer, analyzers []*analysis.Analyzer, results []godernize.Result, baseDir string) error {
+	rules := make([]sarifRule, len(analyzers))
+	ruleIndex := make(map[string]int, len(analyzers))
+
+	for i, analyzer := range analyzers {
+		summary, _, _ := strings.Cut(analyzer.Doc, "\n")
+		rules[i] = sarifRule{
+			ID:               analyzer.Name,
+			Name:             analyzer.Name,
+			ShortDescription: sarifMessage{Text: summary},
+			FullDescription:  sarifMessage{Text: analyzer.Doc},
+			HelpURI:          analyzer.URL,
+		}
+		ruleIndex[analyzer.Name] = i
+	}
+
+	sarifResults := make([]sarifResult, 0, len(results))
+
+	for _, result := range results {
+		index, ok := ruleIndex[result.Analyzer]
+		if !ok {
+			return fmt.Errorf("result %q reported by unknown analyzer %s", result.Message, result.Analyzer)
+		}
+
+		sarifResults = append(sarifResults, sarifResult{
+			RuleID:    result.Analyzer,
+			RuleIndex: index,
+			Level:     Level(result),
+			Message:   sarifMessage{Text: result.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: physicalLocation(result.Pos, result.End, baseDir),
+			}},
+			Fixes: fixes(result.SuggestedFixes, baseDir),
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				InformationURI: toolURI,
+				Rules:          rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+
+	return nil
+}
+
+// physicalLocation converts a result range to a SARIF location. Columns are
+// byte-based, as in go/token.
+func physicalLocation(start, end token.Position, baseDir string) sarifPhysicalLocation {
+	region := &sarifRegion{
+		StartLine:   start.Line,
+		StartColumn: start.Column,
+	}
+
+	if end.IsValid() && end.Offset > start.Offset {
+		region.EndLine = end.Line
+		region.EndColumn = end.Column
+	}
+
+	return sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: artifactURI(start.Filename, baseDir)},
+		Region:           region,
+	}
+}
+
+// fixes converts suggested fixes to SARIF fixes. Replacements are expressed
+// with byte offsets so that insertions and multi-line edits are exact.
+func fixes(suggested []godernize.SuggestedFix, baseDir string) []sarifFix {
+	if len(suggested) == 0 {
+		return nil
+	}
+
+	result := make([]sarifFix, 0, len(suggested))
+
+	for _, fix := range suggested {
+		var changes []sarifArtifactChange
+
+		byURI := make(map[string]int)
+
+		for _, edit := range fix.Edits {
+			uri := artifactURI(edit.Pos.Filename, baseDir)
+
+			index, ok := byURI[uri]
+			if !ok {
+				index = len(changes)
+				byURI[uri] = index
+				changes = append(changes, sarifArtifactChange{ArtifactLocation: sarifArtifactLocation{URI: uri}})
+			}
+
+			changes[index].Replacements = append(changes[index].Replacements, sarifReplacement{
+				DeletedRegion:   sarifByteRegion{ByteOffset: edit.Pos.Offset, ByteLength: edit.End.Offset - edit.Pos.Offset},
+				InsertedContent: &sarifContent{Text: edit.NewText},
+			})
+		}
+
+		result = append(result, sarifFix{
+			Description:     sarifMessage{Text: fix.Message},
+			ArtifactChanges: changes,
+		})
+	}
+
+	return result
+}
+
+// artifactURI returns filename relative to baseDir with forward slashes, or
+// the absolute path as a file URI when it is outside baseDir.
+func artifactURI(filename, baseDir string) string {
+	if baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, filename); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	if filepath.IsAbs(filename) {
+		return "file://" + filepath.ToSlash(filename)
+	}
+
+	return filepath.ToSlash(filename)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifByteRegion `json:"deletedRegion"`
+	InsertedContent *sarifContent   `json:"insertedContent,omitempty"`
+}
+
+type sarifByteRegion struct {
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+}
+
+type sarifContent struct {
+	Text string `json:"text"`
+}
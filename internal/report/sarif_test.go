@@ -0,0 +1,206 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/jaeyeom/godernize"
+	"github.com/jaeyeom/godernize/ctxnil"
+	"github.com/jaeyeom/godernize/internal/report"
+	"github.com/jaeyeom/godernize/oserrors"
+)
+
+type sarifLog struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID      string `json:"id"`
+					HelpURI string `json:"helpUri"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine   int `json:"startLine"`
+						StartColumn int `json:"startColumn"`
+						EndLine     int `json:"endLine"`
+						EndColumn   int `json:"endColumn"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+			Fixes []struct {
+				ArtifactChanges []struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Replacements []struct {
+						DeletedRegion struct {
+							ByteOffset int `json:"byteOffset"`
+							ByteLength int `json:"byteLength"`
+						} `json:"deletedRegion"`
+						InsertedContent struct {
+							Text string `json:"text"`
+						} `json:"insertedContent"`
+					} `json:"replacements"`
+				} `json:"artifactChanges"`
+			} `json:"fixes"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	first := &analysis.Analyzer{Name: "first", Doc: "check first things\n\nMore details.", URL: "https://example.com/first"}
+	second := &analysis.Analyzer{Name: "second", Doc: "check second things"}
+	quiet := &analysis.Analyzer{Name: "quiet", Doc: "check nothing"}
+
+	at := func(line, column, offset int) token.Position {
+		return token.Position{Filename: "/src/project/pkg/a.go", Line: line, Column: column, Offset: offset}
+	}
+
+	results := []godernize.Result{
+		{
+			Analyzer: "first",
+			Pos:      at(2, 3, 22),
+			End:      at(2, 11, 30),
+			Message:  "first problem",
+			SuggestedFixes: []godernize.SuggestedFix{{
+				Message: "Fix it",
+				Edits:   []godernize.TextEdit{{Pos: at(2, 3, 22), End: at(2, 11, 30), NewText: "fixed"}},
+			}},
+		},
+		{Analyzer: "second", Pos: at(3, 6, 45), Message: "second problem", Category: "error"},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, []*analysis.Analyzer{first, second, quiet}, results, "/src/project"); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version = %q with %d runs, want 2.1.0 with one run", log.Version, len(log.Runs))
+	}
+
+	run := log.Runs[0]
+
+	if rules := run.Tool.Driver.Rules; len(rules) != 3 || rules[0].ID != "first" || rules[0].HelpURI != first.URL || rules[2].ID != "quiet" {
+		t.Errorf("rules = %+v, want first, second, and quiet", rules)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(run.Results))
+	}
+
+	result := run.Results[0]
+	location := result.Locations[0].PhysicalLocation
+
+	if result.RuleID != "first" || result.RuleIndex != 0 || result.Level != "warning" || result.Message.Text != "first problem" {
+		t.Errorf("first result = %+v", result)
+	}
+
+	if location.ArtifactLocation.URI != "pkg/a.go" {
+		t.Errorf("uri = %q, want pkg/a.go", location.ArtifactLocation.URI)
+	}
+
+	if region := location.Region; region.StartLine != 2 || region.StartColumn != 3 || region.EndLine != 2 || region.EndColumn != 11 {
+		t.Errorf("region = %+v, want 2:3-2:11", region)
+	}
+
+	if len(result.Fixes) != 1 || len(result.Fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("fixes = %+v, want one fix with one artifact change", result.Fixes)
+	}
+
+	replacement := result.Fixes[0].ArtifactChanges[0].Replacements[0]
+	if replacement.DeletedRegion.ByteOffset != 22 || replacement.DeletedRegion.ByteLength != 8 || replacement.InsertedContent.Text != "fixed" {
+		t.Errorf("replacement = %+v, want 8 bytes at 22 replaced with fixed", replacement)
+	}
+
+	if result := run.Results[1]; result.RuleIndex != 1 || result.Level != "error" || len(result.Fixes) != 0 {
+		t.Errorf("second result = %+v, want rule 1 at level error without fixes", result)
+	}
+}
+
+func TestWriteSARIFUnknownAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	results := []godernize.Result{{Analyzer: "unknown", Message: "problem"}}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, nil, results, ""); err == nil {
+		t.Error("WriteSARIF succeeded for a diagnostic of an unlisted analyzer")
+	}
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                              "warning",
+		"error":                         "error",
+		"note":                          "note",
+		"style":                         "warning",
+		"warning":                       "warning",
+		ctxnil.CategoryError:            "error",
+		ctxnil.CategoryAlwaysTrue:       "warning",
+		oserrors.CategoryDeprecatedFunc: "warning",
+		"analyzer.note":                 "note",
+		"errors":                        "warning",
+	}
+
+	for category, expected := range tests {
+		if got := report.Level(godernize.Result{Category: category}); got != expected {
+			t.Errorf("Level(category %q) = %q, want %q", category, got, expected)
+		}
+	}
+}
+
+// TestWriteSARIFStrict checks that results of ctxnil -strict, which are
+// reported with the ctxnil.error category, are errors.
+func TestWriteSARIFStrict(t *testing.T) {
+	t.Parallel()
+
+	results := []godernize.Result{{
+		Analyzer: ctxnil.Analyzer.Name,
+		Pos:      token.Position{Filename: "/src/project/a.go", Line: 4, Column: 5, Offset: 40},
+		Category: ctxnil.CategoryError,
+		Message:  "condition is always true",
+	}}
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, []*analysis.Analyzer{ctxnil.Analyzer}, results, "/src/project"); err != nil {
+		t.Fatalf("WriteSARIF failed: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if got := log.Runs[0].Results[0].Level; got != "error" {
+		t.Errorf("level of a %s result = %q, want %q", ctxnil.CategoryError, got, "error")
+	}
+}
@@ -0,0 +1,46 @@
+// Package typeutil provides type predicates shared by the analyzers.
+package typeutil
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// IsContextType reports whether expr has type context.Context. Aliases of
+// context.Context match, and so do interfaces that embed it, directly or
+// through other interfaces, since their values are contexts too.
+func IsContextType(info *types.Info, expr ast.Expr) bool {
+	if info == nil || expr == nil {
+		return false
+	}
+
+	return isContext(info.TypeOf(expr))
+}
+
+func isContext(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	typ = types.Unalias(typ)
+
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		if obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context" {
+			return true
+		}
+	}
+
+	iface, ok := typ.Underlying().(*types.Interface)
+	if !ok {
+		return false
+	}
+
+	for i := range iface.NumEmbeddeds() {
+		if isContext(iface.EmbeddedType(i)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package typeutil_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/jaeyeom/godernize/internal/typeutil"
+)
+
+const fixtureSrc = `package p
+
+import (
+	"context"
+	stdctx "context"
+)
+
+type (
+	Alias      = context.Context
+	AliasAlias = Alias
+	Embedded   interface{ context.Context }
+	Nested     interface {
+		Embedded
+		Name() string
+	}
+	Named    context.Context
+	Wrapper  struct{ context.Context }
+	Unrelated interface{ Done() <-chan struct{} }
+)
+
+var (
+	plain      context.Context
+	renamed    stdctx.Context
+	alias      Alias
+	aliasAlias AliasAlias
+	embedded   Embedded
+	nested     Nested
+	named      Named
+	literal    interface{ context.Context }
+	pointer    *context.Context
+	wrapper    Wrapper
+	unrelated  Unrelated
+	str        string
+)
+`
+
+// loadFixture type-checks fixtureSrc and returns its types.Info together with
+// the identifiers declared by its var block.
+func loadFixture(t *testing.T) (*types.Info, map[string]*ast.Ident) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "p.go", fixtureSrc, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+		t.Fatalf("Failed to type-check fixture: %v", err)
+	}
+
+	vars := make(map[string]*ast.Ident)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.ValueSpec); ok {
+			for _, name := range spec.Names {
+				vars[name.Name] = name
+			}
+		}
+
+		return true
+	})
+
+	return info, vars
+}
+
+func TestIsContextType(t *testing.T) {
+	t.Parallel()
+
+	info, vars := loadFixture(t)
+
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"plain", true},
+		{"renamed", true},
+		{"alias", true},
+		{"aliasAlias", true},
+		{"embedded", true},
+		{"nested", true},
+		{"named", false},
+		{"literal", true},
+		{"pointer", false},
+		{"wrapper", false},
+		{"unrelated", false},
+		{"str", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ident, ok := vars[tt.name]
+			if !ok {
+				t.Fatalf("Fixture has no variable %q", tt.name)
+			}
+
+			if got := typeutil.IsContextType(info, ident); got != tt.expected {
+				t.Errorf("IsContextType(%s) = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsContextTypeNil(t *testing.T) {
+	t.Parallel()
+
+	info, vars := loadFixture(t)
+
+	if typeutil.IsContextType(nil, vars["plain"]) {
+		t.Error("IsContextType(nil info) = true, want false")
+	}
+
+	if typeutil.IsContextType(info, nil) {
+		t.Error("IsContextType(nil expr) = true, want false")
+	}
+
+	if typeutil.IsContextType(info, ast.NewIdent("undeclared")) {
+		t.Error("IsContextType(untyped expr) = true, want false")
+	}
+}
@@ -0,0 +1,12 @@
+// Command listslicegodernize runs the listslice analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/listslice"
+)
+
+func main() {
+	singlechecker.Main(listslice.Analyzer)
+}
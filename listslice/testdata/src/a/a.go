@@ -0,0 +1,15 @@
+package a
+
+import (
+	"container/list" // want `container/list is rarely needed; consider a \[\]T with the slices package instead`
+	"fmt"
+)
+
+func queue() {
+	l := list.New()
+	l.PushBack(1)
+
+	for e := l.Front(); e != nil; e = e.Next() {
+		fmt.Println(e.Value)
+	}
+}
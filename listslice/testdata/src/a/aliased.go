@@ -0,0 +1,7 @@
+package a
+
+import linked "container/list" // want `container/list is rarely needed`
+
+func aliased() *linked.List {
+	return linked.New()
+}
@@ -0,0 +1,10 @@
+package a
+
+import (
+	//godernize:ignore=listslice
+	"container/list"
+)
+
+func lru() *list.List {
+	return list.New()
+}
@@ -0,0 +1,7 @@
+package a
+
+import "container/ring"
+
+func circular() *ring.Ring {
+	return ring.New(3)
+}
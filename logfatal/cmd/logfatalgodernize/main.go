@@ -0,0 +1,12 @@
+// Command logfatalgodernize runs the logfatal analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/logfatal"
+)
+
+func main() {
+	singlechecker.Main(logfatal.Analyzer)
+}
@@ -0,0 +1,231 @@
+// Package logfatal provides an analyzer to detect log.Fatal and log.Panic
+// calls in library packages.
+package logfatal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for log.Fatal and log.Panic calls in library packages
+
+This analyzer reports calls of the log.Fatal and log.Panic families, including
+the methods of *log.Logger, outside main packages and test files. log.Fatal
+exits the process through os.Exit, skipping deferred calls, and log.Panic
+panics; neither lets the caller handle the failure. Return an error instead.
+
+Packages listed in -allow are not reported. The analyzer reports diagnostics
+only.`
+
+// Analyzer is the main analyzer for log.Fatal and log.Panic calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "logfatal",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/logfatal",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.StringVar(&runner.allow, "allow", "",
+		"comma-separated list of package paths where log.Fatal and log.Panic are allowed; "+
+			"a path ending in /... also allows its subpackages")
+
+	return analyzer
+}
+
+type runner struct {
+	allow string
+}
+
+// exitingFuncs are the functions of package log, and methods of *log.Logger,
+// that exit or panic.
+//
+//nolint:gochecknoglobals // static table of reported functions
+var exitingFuncs = map[string]bool{
+	"Fatal":   true,
+	"Fatalf":  true,
+	"Fatalln": true,
+	"Panic":   true,
+	"Panicf":  true,
+	"Panicln": true,
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil || pass.Pkg == nil {
+		return nil, nil
+	}
+
+	if pass.Pkg.Name() == "main" || isAllowed(pass.Pkg.Path(), r.allow) {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+		if file == nil || strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			return
+		}
+
+		if diagnostic := diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+// isAllowed checks if pkgPath matches one of the comma-separated patterns.
+func isAllowed(pkgPath, allow string) bool {
+	for _, pattern := range strings.Split(allow, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if prefix, found := strings.CutSuffix(pattern, "/..."); found {
+			if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if pkgPath == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "log" || !exitingFuncs[fn.Name()] {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "logfatal") {
+		return nil
+	}
+
+	name := "log." + fn.Name()
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		name = "(*log.Logger)." + fn.Name()
+	}
+
+	effect := "exits the program, skipping deferred calls"
+	if strings.HasPrefix(fn.Name(), "Panic") {
+		effect = "panics"
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("%s in library package %s %s; return an error to the caller instead",
+			name, pass.Pkg.Path(), effect),
+	}
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,27 @@
+package logfatal_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/logfatal"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, logfatal.Analyzer, "lib", "app")
+}
+
+func TestAllow(t *testing.T) {
+	if err := logfatal.Analyzer.Flags.Set("allow", "example.com/other, allowed/..."); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = logfatal.Analyzer.Flags.Set("allow", "")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, logfatal.Analyzer, "allowed", "allowed/sub", "lib")
+}
@@ -0,0 +1,7 @@
+package allowed
+
+import "log"
+
+func mustInit() {
+	log.Fatal("allowed by -allow")
+}
@@ -0,0 +1,7 @@
+package sub
+
+import "log"
+
+func mustInit() {
+	log.Panic("allowed by -allow through allowed/...")
+}
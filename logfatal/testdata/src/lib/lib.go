@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"errors"
+	"log"
+	"os"
+)
+
+func load(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err) // want `log.Fatal in library package lib exits the program, skipping deferred calls; return an error to the caller instead`
+	}
+
+	return data
+}
+
+func mustPositive(n int) int {
+	if n <= 0 {
+		log.Panicf("not positive: %d", n) // want `log.Panicf in library package lib panics; return an error to the caller instead`
+	}
+
+	return n
+}
+
+func withLogger(logger *log.Logger) {
+	logger.Fatalln("done") // want `\(\*log.Logger\).Fatalln in library package lib exits the program`
+}
+
+func fine() error {
+	log.Println("still running")
+
+	return errors.New("failed")
+}
+
+//godernize:ignore=logfatal
+func ignored() {
+	log.Fatalf("ignored")
+}
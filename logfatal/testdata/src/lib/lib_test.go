@@ -0,0 +1,12 @@
+package lib
+
+import (
+	"log"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	if len(load("testdata")) == 0 {
+		log.Fatal("tests may exit")
+	}
+}
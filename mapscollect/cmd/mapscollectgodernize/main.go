@@ -0,0 +1,15 @@
+// Command mapscollectgodernize runs the mapscollect analyzer.
+//
+// It uses multichecker, whose flags are prefixed with the analyzer name, since
+// singlechecker reserves -fix for applying fixes and would drop -mapscollect.fix.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/jaeyeom/godernize/mapscollect"
+)
+
+func main() {
+	multichecker.Main(mapscollect.Analyzer)
+}
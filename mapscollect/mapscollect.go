@@ -0,0 +1,374 @@
+// Package mapscollect provides an analyzer to detect loops collecting the keys
+// or values of a map into a slice.
+package mapscollect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for loops collecting the keys or values of a map
+
+This analyzer reports a slice created with make and filled by the range loop
+right after it with the keys or values of a map, and suggests the iterator
+functions of Go 1.23:
+- keys := make([]K, 0, len(m)); for k := range m { keys = append(keys, k) } ->
+  keys := slices.Collect(maps.Keys(m))
+- vals := make([]V, 0, len(m)); for _, v := range m { vals = append(vals, v) } ->
+  vals := slices.Collect(maps.Values(m))
+
+The order of the collected elements is unspecified either way; sort the
+result if the order matters. Note that slices.Collect returns nil rather than
+an empty slice for an empty map. Since the replacement spans the slices and
+maps packages, fixes are only offered with -fix. Files built for Go versions
+before 1.23 are skipped.`
+
+// Analyzer is the main analyzer for map collection loops.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "mapscollect",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/mapscollect",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.fix, "fix", false,
+		"offer fixes rewriting collection loops to slices.Collect(maps.Keys(m)) or slices.Collect(maps.Values(m))")
+
+	return analyzer
+}
+
+type runner struct {
+	fix bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var stmts []ast.Stmt
+
+		switch node := n.(type) {
+		case *ast.BlockStmt:
+			stmts = node.List
+		case *ast.CaseClause:
+			stmts = node.Body
+		case *ast.CommClause:
+			stmts = node.Body
+		}
+
+		file := fileMap.File(n.Pos())
+
+		if file == nil || !supportsIterators(pass, file) {
+			return
+		}
+
+		for i := 1; i < len(stmts); i++ {
+			if diagnostic := r.diagnoseStmts(pass, file, stmts[i-1], stmts[i]); diagnostic != nil {
+				pass.Report(*diagnostic)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// diagnoseStmts checks if init creates an empty slice that the range loop
+// next fills with the keys or values of a map.
+func (r *runner) diagnoseStmts(pass *analysis.Pass, file *ast.File, init, next ast.Stmt) *analysis.Diagnostic {
+	assign, ok := init.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+
+	slice, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || slice.Name == "_" {
+		return nil
+	}
+
+	loop, ok := next.(*ast.RangeStmt)
+	if !ok || loop.Tok != token.DEFINE || len(loop.Body.List) != 1 || !isTrackable(loop.X) {
+		return nil
+	}
+
+	mapType, ok := typeOf(pass.TypesInfo, loop.X).(*types.Map)
+	if !ok {
+		return nil
+	}
+
+	fn, elem, element := "Keys", mapType.Key(), loop.Key
+	if !isBlank(loop.Value) {
+		if !isBlank(loop.Key) {
+			return nil
+		}
+
+		fn, elem, element = "Values", mapType.Elem(), loop.Value
+	}
+
+	elementIdent, ok := element.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	sliceObj := pass.TypesInfo.ObjectOf(slice)
+	if sliceObj == nil || !isEmptySlice(pass.TypesInfo, assign.Rhs[0], elem, loop.X) ||
+		!isAppendOf(pass.TypesInfo, loop.Body.List[0], sliceObj, pass.TypesInfo.ObjectOf(elementIdent)) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, init, "mapscollect") {
+		return nil
+	}
+
+	replacement := fmt.Sprintf("%s %s %s.Collect(%s.%s(%s))", slice.Name, assign.Tok,
+		importutil.LocalName(file, "slices"), importutil.LocalName(file, "maps"), fn, types.ExprString(loop.X))
+
+	diagnostic := &analysis.Diagnostic{
+		Pos: init.Pos(),
+		End: next.End(),
+		Message: fmt.Sprintf("loop collecting the %s of map %s can be replaced with %s of Go 1.23; "+
+			"the order of the elements is unspecified either way, so sort them if the order matters, "+
+			"and the result is nil rather than empty for an empty map, which encodes to JSON as null",
+			strings.ToLower(fn), types.ExprString(loop.X), replacement),
+	}
+
+	if !r.fix {
+		return diagnostic
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     init.Pos(),
+		End:     next.End(),
+		NewText: []byte(replacement),
+	}}
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{"maps", "slices"}, nil)...)
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Replace with slices.Collect(maps." + fn + "(...))",
+		TextEdits: edits,
+	}}
+
+	return diagnostic
+}
+
+// supportsIterators reports whether the file is compiled with a Go version
+// that has slices.Collect and the iterator functions of package maps. Files
+// without version information are assumed to be recent enough.
+func supportsIterators(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.23") >= 0
+}
+
+func typeOf(info *types.Info, expr ast.Expr) types.Type {
+	typ := info.TypeOf(expr)
+	if typ == nil {
+		return nil
+	}
+
+	return typ.Underlying()
+}
+
+// isEmptySlice checks if expr is make([]elem, 0) or make([]elem, 0, len(m))
+// with m the same map as x. The slice type must not be named, since
+// slices.Collect returns a []elem.
+func isEmptySlice(info *types.Info, expr ast.Expr, elem types.Type, x ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !isBuiltin(info, call.Fun, "make") || len(call.Args) < 2 || len(call.Args) > 3 {
+		return false
+	}
+
+	slice, ok := info.TypeOf(call.Args[0]).(*types.Slice)
+	if !ok || !types.Identical(slice.Elem(), elem) || !isZero(info, call.Args[1]) {
+		return false
+	}
+
+	if len(call.Args) == 2 {
+		return true
+	}
+
+	length, ok := ast.Unparen(call.Args[2]).(*ast.CallExpr)
+
+	return ok && isBuiltin(info, length.Fun, "len") && len(length.Args) == 1 && sameExpr(info, length.Args[0], x)
+}
+
+// isAppendOf checks if stmt is s = append(s, e) with s the slice and e the
+// element variable.
+func isAppendOf(info *types.Info, stmt ast.Stmt, slice, element types.Object) bool {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 ||
+		!refersTo(info, assign.Lhs[0], slice) {
+		return false
+	}
+
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || !isBuiltin(info, call.Fun, "append") || len(call.Args) != 2 || call.Ellipsis.IsValid() {
+		return false
+	}
+
+	return refersTo(info, call.Args[0], slice) && refersTo(info, call.Args[1], element)
+}
+
+func isZero(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+
+	return ok && tv.Value != nil && tv.Value.String() == "0"
+}
+
+func isBuiltin(info *types.Info, fun ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+
+	return ok && builtin.Name() == name
+}
+
+func isBlank(expr ast.Expr) bool {
+	if expr == nil {
+		return true
+	}
+
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+// isTrackable checks if expr is a variable, possibly through field
+// selections, which can be evaluated again without side effects.
+func isTrackable(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return isTrackable(e.X)
+	}
+
+	return false
+}
+
+// sameExpr checks if two side-effect free expressions denote the same
+// variable.
+func sameExpr(info *types.Info, a, b ast.Expr) bool {
+	switch exprA := ast.Unparen(a).(type) {
+	case *ast.Ident:
+		exprB, ok := ast.Unparen(b).(*ast.Ident)
+
+		return ok && info.ObjectOf(exprA) != nil && info.ObjectOf(exprA) == info.ObjectOf(exprB)
+	case *ast.SelectorExpr:
+		exprB, ok := ast.Unparen(b).(*ast.SelectorExpr)
+
+		return ok && exprA.Sel.Name == exprB.Sel.Name && sameExpr(info, exprA.X, exprB.X)
+	}
+
+	return false
+}
+
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && obj != nil && info.Uses[ident] == obj
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,35 @@
+package mapscollect_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/mapscollect"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, mapscollect.Analyzer, "a")
+}
+
+func TestFix(t *testing.T) {
+	if err := mapscollect.Analyzer.Flags.Set("fix", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = mapscollect.Analyzer.Flags.Set("fix", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, mapscollect.Analyzer, "autofix")
+}
+
+// TestGoVersion checks that modules before Go 1.23, which lack
+// slices.Collect, are skipped.
+func TestGoVersion(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "src", "old"), mapscollect.Analyzer, "./...")
+}
@@ -0,0 +1,117 @@
+package a
+
+import "sort"
+
+type config struct {
+	env map[string]int
+}
+
+func keys(m map[string]int) []string {
+	keys := make([]string, 0, len(m)) // want `loop collecting the keys of map m can be replaced with keys := slices.Collect\(maps.Keys\(m\)\) of Go 1.23; the order of the elements is unspecified either way, so sort them if the order matters, and the result is nil rather than empty for an empty map, which encodes to JSON as null`
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func values(m map[string]int) []int {
+	vals := make([]int, 0) // want `loop collecting the values of map m can be replaced with vals := slices.Collect\(maps.Values\(m\)\)`
+	for _, v := range m {
+		vals = append(vals, v)
+	}
+
+	return vals
+}
+
+func field(c *config) []string {
+	var names []string
+
+	switch {
+	case len(c.env) > 0:
+		names = make([]string, 0, len(c.env)) // want `loop collecting the keys of map c.env can be replaced with names = slices.Collect\(maps.Keys\(c.env\)\)`
+		for name := range c.env {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+type names []string
+
+// The slice has a named type, which slices.Collect does not return.
+func named(m map[string]int) names {
+	keys := make(names, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// The capacity is the length of another map.
+func otherMap(m, other map[string]int) []string {
+	keys := make([]string, 0, len(other))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// The loop does more than appending.
+func filtered(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		if v > 0 {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// The slice starts with an element.
+func nonEmpty(m map[string]int) []string {
+	keys := make([]string, 1, len(m)+1)
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// A statement separates the loop from the make call.
+func separated(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	sort.Strings(keys)
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Slices are not maps.
+func fromSlice(s []string) []string {
+	out := make([]string, 0, len(s))
+	for _, v := range s {
+		out = append(out, v)
+	}
+
+	return out
+}
+
+func ignored(m map[string]int) []string {
+	//godernize:ignore=mapscollect
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
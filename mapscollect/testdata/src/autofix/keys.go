@@ -0,0 +1,10 @@
+package autofix
+
+func keys(m map[string]int) []string {
+	keys := make([]string, 0, len(m)) // want `loop collecting the keys of map m`
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,12 @@
+package autofix
+
+import "fmt"
+
+func values(m map[string]int) {
+	vals := make([]int, 0, len(m)) // want `loop collecting the values of map m`
+	for _, v := range m {
+		vals = append(vals, v)
+	}
+
+	fmt.Println(vals)
+}
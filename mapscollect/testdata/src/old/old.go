@@ -0,0 +1,11 @@
+package old
+
+// Modules before Go 1.23 lack slices.Collect and maps.Keys.
+func keys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
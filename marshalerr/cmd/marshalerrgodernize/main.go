@@ -0,0 +1,12 @@
+// Command marshalerrgodernize runs the marshalerr analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/marshalerr"
+)
+
+func main() {
+	singlechecker.Main(marshalerr.Analyzer)
+}
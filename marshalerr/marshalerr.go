@@ -0,0 +1,253 @@
+// Package marshalerr provides an analyzer to detect json.Marshal results
+// written to an http.ResponseWriter while the marshal error is discarded.
+package marshalerr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for json.Marshal errors discarded before writing a response
+
+This analyzer reports assignments such as
+
+	data, _ := json.Marshal(v)
+
+whose result is later written with the Write method of an
+http.ResponseWriter in the same function. When marshaling fails, data is
+nil, so the handler silently sends an empty response instead of an error.
+json.MarshalIndent is reported likewise.
+
+Discarded errors whose result is not written to a ResponseWriter are not
+reported. The right handling depends on the handler, so the analyzer reports
+diagnostics only.`
+
+// Analyzer is the main analyzer for discarded json.Marshal errors in HTTP
+// handlers.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "marshalerr",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/marshalerr",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		stmt, ok := n.(*ast.AssignStmt)
+		if !push || !ok || stmt == nil {
+			return true
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if diagnostic := diagnoseAssignStmt(pass, file, stmt, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseAssignStmt(pass *analysis.Pass, file *ast.File, stmt *ast.AssignStmt, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil || len(stmt.Lhs) != 2 || len(stmt.Rhs) != 1 || !isBlank(stmt.Lhs[1]) {
+		return nil
+	}
+
+	call, ok := ast.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	name := ""
+
+	for _, marshal := range []string{"Marshal", "MarshalIndent"} {
+		if isPkgFunc(pass.TypesInfo, call, "encoding/json", marshal) {
+			name = marshal
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	data, ok := ast.Unparen(stmt.Lhs[0]).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	obj := pass.TypesInfo.ObjectOf(data)
+
+	body := enclosingBody(stack)
+	if obj == nil || body == nil || !writesAfter(pass.TypesInfo, body, obj, stmt.End()) ||
+		shouldIgnore(pass.Fset, file, stmt, "marshalerr") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("json.%s error is discarded but its result is written to an http.ResponseWriter, "+
+			"so a marshal failure sends an empty response; handle the error", name),
+	}
+}
+
+// enclosingBody returns the body of the innermost function in stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			return node.Body
+		case *ast.FuncLit:
+			return node.Body
+		}
+	}
+
+	return nil
+}
+
+// writesAfter checks if body passes obj to the Write method of an
+// http.ResponseWriter after pos, including in nested function literals.
+func writesAfter(info *types.Info, body *ast.BlockStmt, obj types.Object, pos token.Pos) bool {
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if found || !ok || call.Pos() < pos || len(call.Args) != 1 {
+			return !found
+		}
+
+		arg, ok := ast.Unparen(call.Args[0]).(*ast.Ident)
+		found = ok && info.Uses[arg] == obj && isResponseWrite(info, call)
+
+		return !found
+	})
+
+	return found
+}
+
+// isResponseWrite checks if call is the Write method of http.ResponseWriter,
+// also when promoted through a type embedding it.
+func isResponseWrite(info *types.Info, call *ast.CallExpr) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+
+	return ok && fn.FullName() == "(net/http.ResponseWriter).Write"
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,76 @@
+package a
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+type response struct {
+	Name string `json:"name"`
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	data, _ := json.Marshal(response{Name: r.URL.Path}) // want `json.Marshal error is discarded but its result is written to an http.ResponseWriter, so a marshal failure sends an empty response; handle the error`
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func indented(w http.ResponseWriter, v any) {
+	var data []byte
+	data, _ = json.MarshalIndent(v, "", "  ") // want `json.MarshalIndent error is discarded`
+	_, _ = w.Write(data)
+}
+
+func embedded(w *statusWriter, v any) {
+	data, _ := json.Marshal(v) // want `json.Marshal error is discarded`
+	w.Write(data)
+}
+
+func closure(v any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, _ := json.Marshal(v) // want `json.Marshal error is discarded`
+		func() {
+			w.Write(data)
+		}()
+	}
+}
+
+func checked(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(data)
+}
+
+func notWritten(w http.ResponseWriter, v any) int {
+	data, _ := json.Marshal(v)
+
+	return len(data)
+}
+
+func writtenElsewhere(v any) {
+	data, _ := json.Marshal(v)
+	os.Stdout.Write(data)
+}
+
+func writtenBefore(w http.ResponseWriter, v any) {
+	var data []byte
+	w.Write(data)
+	data, _ = json.Marshal(v)
+	_ = data
+}
+
+func ignored(w http.ResponseWriter, v any) {
+	//godernize:ignore=marshalerr
+	data, _ := json.Marshal(v)
+	w.Write(data)
+}
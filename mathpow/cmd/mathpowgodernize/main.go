@@ -0,0 +1,12 @@
+// Command mathpowgodernize runs the mathpow analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/mathpow"
+)
+
+func main() {
+	singlechecker.Main(mathpow.Analyzer)
+}
@@ -0,0 +1,364 @@
+// Package mathpow provides an analyzer to detect math.Pow calls with small
+// integer exponents.
+package mathpow
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const maxExponent = 4
+
+// Doc describes what this analyzer does.
+const Doc = `check for math.Pow calls with small integer exponents
+
+This analyzer reports math.Pow calls whose exponent is a small integer literal
+and suggests direct multiplication, which is faster and clearer:
+- math.Pow(x, 2) -> x*x
+- math.Pow(x, 3) -> x*x*x
+
+Fixes are offered for exponents 2 and 3 when the base is free of calls.`
+
+// Analyzer is the main analyzer for math.Pow calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "mathpow",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/mathpow",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the math import.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic := diagnoseCallExpr(pass, file, call, isOperand(stack))
+		if diagnostic == nil {
+			return true
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, call: call})
+
+		return true
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the math.Pow call it reports.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	call       *ast.CallExpr
+}
+
+// consolidateFixes removes the math import in the fixes of file when the
+// replaced calls held its last references. With several fixes, only the
+// first carries the rewrites of all of them, so that applying every fix of
+// the file does not remove the import twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		// The base is repeated in the replacement and may refer to math
+		// itself.
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.call.Fun, f.call.Args[1])
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	if !importutil.UsedOutside(pass.TypesInfo, file, "math", replaced...) {
+		edits = append(edits, importutil.Edits(pass.Fset, file, nil, []string{"math"})...)
+	}
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// isOperand reports whether the last node of stack is the operand of a
+// unary or binary expression, where a product must be parenthesized to keep
+// its precedence, as in y / (x*x).
+func isOperand(stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+
+	switch stack[len(stack)-2].(type) {
+	case *ast.BinaryExpr, *ast.UnaryExpr:
+		return true
+	}
+
+	return false
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, operand bool) *analysis.Diagnostic {
+	if file == nil || !isPkgFunc(pass.TypesInfo, call, "math", "Pow") || len(call.Args) != 2 {
+		return nil
+	}
+
+	exponent := smallIntLiteral(call.Args[1])
+	if exponent < 2 {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "mathpow") {
+		return nil
+	}
+
+	base := call.Args[0]
+
+	baseText := formatNode(pass.Fset, base)
+	if baseText == "" {
+		return nil
+	}
+
+	if needsParens(base) {
+		baseText = "(" + baseText + ")"
+	}
+
+	operands := make([]string, exponent)
+	for i := range operands {
+		operands[i] = baseText
+	}
+
+	replacement := strings.Join(operands, "*")
+	if operand {
+		replacement = "(" + replacement + ")"
+	}
+
+	message := fmt.Sprintf("math.Pow with exponent %d can be replaced with %s", exponent, replacement)
+
+	if exponent > 3 || !isFixable(pass.TypesInfo, base) {
+		return &analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: message,
+		}
+	}
+
+	// The math import is removed by consolidateFixes.
+	return &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	}
+}
+
+// smallIntLiteral returns the value of an integer literal exponent such as 2
+// or 2.0, or 0 if expr is not a literal in the range [2, maxExponent].
+func smallIntLiteral(expr ast.Expr) int {
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || (lit.Kind != token.INT && lit.Kind != token.FLOAT) {
+		return 0
+	}
+
+	value, err := strconv.ParseFloat(lit.Value, 64)
+	if err != nil || value != float64(int(value)) || value < 2 || value > maxExponent {
+		return 0
+	}
+
+	return int(value)
+}
+
+// needsParens checks if the base must be parenthesized to be repeated as a
+// multiplication operand. Binary expressions are always parenthesized so the
+// evaluation order of floating-point operations is preserved.
+func needsParens(expr ast.Expr) bool {
+	_, ok := expr.(*ast.BinaryExpr)
+
+	return ok
+}
+
+// isFixable checks if the base can be evaluated repeatedly without changing
+// behavior or type: it must contain no calls or receives and must not be a
+// constant, since constant multiplication would change the result type.
+func isFixable(info *types.Info, base ast.Expr) bool {
+	if tv, ok := info.Types[base]; ok && tv.Value != nil {
+		return false
+	}
+
+	fixable := true
+
+	ast.Inspect(base, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			fixable = false
+		case *ast.UnaryExpr:
+			fixable = fixable && node.Op != token.ARROW
+		}
+
+		return fixable
+	})
+
+	return fixable
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath && fn.Name() == name
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
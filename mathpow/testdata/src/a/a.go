@@ -0,0 +1,32 @@
+package a
+
+import "math"
+
+type point struct {
+	x, y float64
+}
+
+func literalExponents(x float64, p point) {
+	_ = math.Pow(x, 2)     // want `math.Pow with exponent 2 can be replaced with x\*x`
+	_ = math.Pow(x, 3)     // want `math.Pow with exponent 3 can be replaced with x\*x\*x`
+	_ = math.Pow(x, 2.0)   // want `math.Pow with exponent 2 can be replaced with x\*x`
+	_ = math.Pow(p.x, 2)   // want `math.Pow with exponent 2 can be replaced with p.x\*p.x`
+	_ = math.Pow(x+p.y, 2) // want `math.Pow with exponent 2 can be replaced with \(x \+ p.y\)\*\(x \+ p.y\)`
+	_ = math.Pow(x, 4)     // want `math.Pow with exponent 4 can be replaced with x\*x\*x\*x`
+}
+
+func callBase() {
+	_ = math.Pow(math.Sqrt(2), 2) // want `math.Pow with exponent 2 can be replaced with math.Sqrt\(2\)\*math.Sqrt\(2\)`
+}
+
+func variableExponent(x, n float64) {
+	_ = math.Pow(x, n)
+	_ = math.Pow(x, 0.5)
+	_ = math.Pow(x, 1)
+	_ = math.Pow(x, 10)
+}
+
+//godernize:ignore=mathpow
+func ignored(x float64) {
+	_ = math.Pow(x, 2)
+}
@@ -0,0 +1,15 @@
+package autofix
+
+import "math"
+
+func square(x float64) float64 {
+	return math.Pow(x, 2) // want `math.Pow with exponent 2 can be replaced with x\*x`
+}
+
+func cube(x, y float64) float64 {
+	return math.Pow(x-y, 3) // want `math.Pow with exponent 3 can be replaced with \(x - y\)\*\(x - y\)\*\(x - y\)`
+}
+
+func constant() float64 {
+	return math.Pow(10, 2) // want `math.Pow with exponent 2 can be replaced with 10\*10`
+}
@@ -0,0 +1,10 @@
+package autofix
+
+import (
+	"fmt"
+	"math"
+)
+
+func printSquare(x float64) {
+	fmt.Println(math.Pow(x, 2)) // want `math.Pow with exponent 2 can be replaced with x\*x`
+}
@@ -0,0 +1,11 @@
+package autofix
+
+import "math"
+
+func ratio(x, y float64) float64 {
+	return y / math.Pow(x, 3) // want `math.Pow with exponent 3 can be replaced with \(x\*x\*x\)`
+}
+
+func negatedSquare(x float64) float64 {
+	return -math.Pow(x, 2) // want `math.Pow with exponent 2 can be replaced with \(x\*x\)`
+}
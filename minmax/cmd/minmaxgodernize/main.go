@@ -0,0 +1,12 @@
+// Command minmaxgodernize runs the minmax analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/minmax"
+)
+
+func main() {
+	singlechecker.Main(minmax.Analyzer)
+}
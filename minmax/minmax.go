@@ -0,0 +1,327 @@
+// Package minmax provides an analyzer to detect helper functions that can use
+// the min and max builtins.
+package minmax
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for helper functions that can use the min and max builtins
+
+This analyzer reports functions of two integer or string parameters whose
+whole body picks one of them, and suggests the Go 1.21 min and max builtins:
+- func minInt(a, b int) int { if a < b { return a }; return b } ->
+  func minInt(a, b int) int { return min(a, b) }
+
+The if statement may also have an else clause and compare with <=, >, or >=.
+Floating-point parameters are skipped since min and max treat NaN
+differently. Helpers named min or max that shadow the builtins are reported
+without a fix, as deleting them changes what their callers refer to. Files
+built for Go versions before 1.21 are skipped.`
+
+// Analyzer is the main analyzer for min and max helper functions.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "minmax",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/minmax",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		funcDecl, ok := n.(*ast.FuncDecl)
+		if !ok || funcDecl == nil {
+			return
+		}
+
+		file := fileMap.File(funcDecl.Pos())
+
+		if diagnostic := diagnoseFuncDecl(pass, file, funcDecl); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseFuncDecl(pass *analysis.Pass, file *ast.File, funcDecl *ast.FuncDecl) *analysis.Diagnostic {
+	if file == nil || funcDecl.Body == nil || !supportsMinMax(pass, file) {
+		return nil
+	}
+
+	a, b := twoParams(pass.TypesInfo, funcDecl.Type)
+	if a == nil {
+		return nil
+	}
+
+	builtin := matchBody(pass.TypesInfo, funcDecl.Body.List, a, b)
+	if builtin == "" {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, funcDecl, "minmax") {
+		return nil
+	}
+
+	scope := pass.TypesInfo.Scopes[funcDecl.Type]
+	if scope == nil {
+		return nil
+	}
+
+	// A helper named after the builtin shadows it in the whole package, so
+	// its body cannot call the builtin. Other declarations shadowing it are
+	// left alone.
+	_, obj := scope.LookupParent(builtin, funcDecl.Body.Pos())
+	if _, isBuiltin := obj.(*types.Builtin); !isBuiltin {
+		if obj == nil || obj != pass.TypesInfo.Defs[funcDecl.Name] {
+			return nil
+		}
+
+		return &analysis.Diagnostic{
+			Pos: funcDecl.Pos(),
+			End: funcDecl.End(),
+			Message: fmt.Sprintf("function %s reimplements the %s builtin of Go 1.21 and shadows it; "+
+				"consider removing it", funcDecl.Name.Name, builtin),
+		}
+	}
+
+	replacement := fmt.Sprintf("return %s(%s, %s)", builtin, a.Name(), b.Name())
+
+	return &analysis.Diagnostic{
+		Pos:     funcDecl.Pos(),
+		End:     funcDecl.End(),
+		Message: fmt.Sprintf("function %s can use the %s builtin of Go 1.21: %s", funcDecl.Name.Name, builtin, replacement),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace body with " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     funcDecl.Body.List[0].Pos(),
+				End:     funcDecl.Body.List[len(funcDecl.Body.List)-1].End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	}
+}
+
+// supportsMinMax reports whether the file is compiled with a Go version that
+// has the min and max builtins. Files without version information are
+// assumed to be recent enough.
+func supportsMinMax(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.21") >= 0
+}
+
+// twoParams returns the parameters of a function taking two named values of
+// the same integer or string type and returning one value of that type.
+func twoParams(info *types.Info, funcType *ast.FuncType) (*types.Var, *types.Var) {
+	var params []*types.Var
+
+	for _, field := range funcType.Params.List {
+		for _, name := range field.Names {
+			if param, ok := info.Defs[name].(*types.Var); ok && name.Name != "_" {
+				params = append(params, param)
+			}
+		}
+	}
+
+	if len(params) != 2 || funcType.Results == nil || len(funcType.Results.List) != 1 ||
+		len(funcType.Results.List[0].Names) > 1 {
+		return nil, nil
+	}
+
+	typ := params[0].Type()
+	if !types.Identical(typ, params[1].Type()) || !types.Identical(typ, info.TypeOf(funcType.Results.List[0].Type)) {
+		return nil, nil
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsString) == 0 {
+		return nil, nil
+	}
+
+	return params[0], params[1]
+}
+
+// matchBody matches the statements
+//
+//	if x < y { return x }; return y
+//	if x < y { return x } else { return y }
+//
+// where x and y are a and b in either order and the comparison is one of <,
+// <=, >, and >=. It returns "min" or "max", or "" if body does not match.
+func matchBody(info *types.Info, body []ast.Stmt, a, b *types.Var) string {
+	if len(body) == 0 || len(body) > 2 {
+		return ""
+	}
+
+	ifStmt, ok := body[0].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || len(ifStmt.Body.List) != 1 {
+		return ""
+	}
+
+	var otherwise ast.Stmt
+
+	switch {
+	case len(body) == 2 && ifStmt.Else == nil:
+		otherwise = body[1]
+	case len(body) == 1 && ifStmt.Else != nil:
+		block, ok := ifStmt.Else.(*ast.BlockStmt)
+		if !ok || len(block.List) != 1 {
+			return ""
+		}
+
+		otherwise = block.List[0]
+	default:
+		return ""
+	}
+
+	cond, ok := ast.Unparen(ifStmt.Cond).(*ast.BinaryExpr)
+	if !ok {
+		return ""
+	}
+
+	x, y := paramOf(info, cond.X), paramOf(info, cond.Y)
+	first, second := returnedParam(info, ifStmt.Body.List[0]), returnedParam(info, otherwise)
+
+	if x == y || (x != a && x != b) || (y != a && y != b) || first == second || (first != x && first != y) ||
+		(second != x && second != y) {
+		return ""
+	}
+
+	// The then clause returns the smaller parameter when it returns the
+	// left operand of <.
+	switch cond.Op {
+	case token.LSS, token.LEQ:
+		if first == x {
+			return "min"
+		}
+
+		return "max"
+	case token.GTR, token.GEQ:
+		if first == x {
+			return "max"
+		}
+
+		return "min"
+	}
+
+	return ""
+}
+
+// returnedParam returns the parameter returned by stmt, or nil.
+func returnedParam(info *types.Info, stmt ast.Stmt) *types.Var {
+	ret, ok := stmt.(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+
+	return paramOf(info, ret.Results[0])
+}
+
+// paramOf returns the variable expr refers to, or nil.
+func paramOf(info *types.Info, expr ast.Expr) *types.Var {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+
+	param, _ := info.Uses[ident].(*types.Var)
+
+	return param
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,33 @@
+package minmax_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/minmax"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, minmax.Analyzer, "a", "shadowed")
+}
+
+func TestAutoFix(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, minmax.Analyzer, "autofix")
+}
+
+// TestGoVersion checks that modules before Go 1.21, which lack the min and
+// max builtins, are skipped.
+func TestGoVersion(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, filepath.Join(testdata, "src", "old"), minmax.Analyzer, "./...")
+}
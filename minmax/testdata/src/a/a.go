@@ -0,0 +1,145 @@
+package a
+
+type celsius int
+
+func minInt(a, b int) int { // want `function minInt can use the min builtin of Go 1.21: return min\(a, b\)`
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int { // want `function maxInt can use the max builtin of Go 1.21: return max\(a, b\)`
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func maxReturningSecond(a, b int64) int64 { // want `can use the max builtin of Go 1.21: return max\(a, b\)`
+	if a < b {
+		return b
+	}
+
+	return a
+}
+
+func minSwappedOperands(x, y uint) uint { // want `can use the min builtin of Go 1.21: return min\(x, y\)`
+	if y >= x {
+		return x
+	}
+
+	return y
+}
+
+func maxWithElse(a, b string) string { // want `can use the max builtin of Go 1.21: return max\(a, b\)`
+	if a <= b {
+		return b
+	} else {
+		return a
+	}
+}
+
+func warmest(a, b celsius) celsius { // want `can use the max builtin of Go 1.21: return max\(a, b\)`
+	if a >= b {
+		return a
+	}
+
+	return b
+}
+
+// Floating-point helpers differ from the builtins for NaN.
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func minGeneric[T ~int](a, b T) T {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func sameParam(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return a
+}
+
+func notEqual(a, b int) int {
+	if a != b {
+		return a
+	}
+
+	return b
+}
+
+func moreStatements(a, b int) int {
+	if a < b {
+		println(a)
+
+		return a
+	}
+
+	return b
+}
+
+func withInit(a, b int) int {
+	if c := a; c < b {
+		return a
+	}
+
+	return b
+}
+
+func threeParams(a, b, c int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func otherResult(a, b int) int64 {
+	if a < b {
+		return int64(a)
+	}
+
+	return int64(b)
+}
+
+// A parameter named max shadows the builtin.
+func shadowedByParam(a, max int) int {
+	if a > max {
+		return a
+	}
+
+	return max
+}
+
+// A parameter named min does not shadow max.
+func maxOfMin(a, min int) int { // want `can use the max builtin of Go 1.21: return max\(a, min\)`
+	if a > min {
+		return a
+	}
+
+	return min
+}
+
+//godernize:ignore=minmax
+func ignoredFunc(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,28 @@
+package autofix
+
+func minInt(a, b int) int { // want `function minInt can use the min builtin`
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// maxLen returns the longer length.
+func maxLen(x, y int) int { // want `function maxLen can use the max builtin`
+	if x < y {
+		return y
+	} else {
+		return x
+	}
+}
+
+type ring struct{}
+
+func (ring) clamp(a, b int) int { // want `function clamp can use the min builtin`
+	if b <= a {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,10 @@
+package old
+
+// The min and max builtins do not exist before Go 1.21.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,29 @@
+package shadowed
+
+// Callers of these helpers would refer to the builtins once they are
+// removed, so no fix is offered.
+
+func min(a, b int) int { // want `function min reimplements the min builtin of Go 1.21 and shadows it; consider removing it`
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a, b int) int { // want `function max reimplements the max builtin of Go 1.21 and shadows it`
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// minOf cannot call the builtin since min refers to the function above.
+func minOf(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,12 @@
+// Command netcontextgodernize runs the netcontext analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/netcontext"
+)
+
+func main() {
+	singlechecker.Main(netcontext.Analyzer)
+}
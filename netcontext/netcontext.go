@@ -0,0 +1,169 @@
+// Package netcontext provides an analyzer to detect imports of
+// golang.org/x/net/context, which is replaced by the standard context package.
+package netcontext
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const (
+	netContextPath = "golang.org/x/net/context"
+	contextPath    = "context"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for imports of golang.org/x/net/context
+
+This analyzer reports imports of golang.org/x/net/context and suggests the
+standard context package, whose API is identical. The fix rewrites only the
+import path: both packages are named context, so any alias and all references
+stay valid. Files that also import context get a diagnostic without a fix,
+because merging the two imports requires rewriting references.`
+
+// Analyzer is the main analyzer for golang.org/x/net/context imports.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "netcontext",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/netcontext",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		file, ok := n.(*ast.File)
+		if !ok || file == nil {
+			return
+		}
+
+		for _, spec := range file.Imports {
+			if diagnostic := diagnoseImport(pass, file, spec); diagnostic != nil {
+				pass.Report(*diagnostic)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseImport(pass *analysis.Pass, file *ast.File, spec *ast.ImportSpec) *analysis.Diagnostic {
+	path, err := strconv.Unquote(spec.Path.Value)
+	if err != nil || path != netContextPath || shouldIgnore(pass.Fset, file, spec, "netcontext") {
+		return nil
+	}
+
+	message := "golang.org/x/net/context can be replaced with context"
+
+	if importutil.Name(file, contextPath) != "" {
+		// Merging two imports of the same package name requires renaming.
+		return &analysis.Diagnostic{
+			Pos:     spec.Pos(),
+			End:     spec.End(),
+			Message: message + ", which is already imported",
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     spec.Pos(),
+		End:     spec.End(),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: `Replace import with "context"`,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     spec.Path.Pos(),
+				End:     spec.Path.End(),
+				NewText: []byte(strconv.Quote(contextPath)),
+			}},
+		}},
+	}
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,11 @@
+package a
+
+import (
+	"context"
+
+	netctx "golang.org/x/net/context" // want `golang.org/x/net/context can be replaced with context, which is already imported`
+)
+
+func conflict(ctx context.Context) netctx.Context {
+	return ctx
+}
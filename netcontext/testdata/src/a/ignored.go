@@ -0,0 +1,10 @@
+package a
+
+import (
+	//godernize:ignore=netcontext
+	"golang.org/x/net/context"
+)
+
+func ignored() context.Context {
+	return context.TODO()
+}
@@ -0,0 +1,7 @@
+package a
+
+import "golang.org/x/net/context" // want `golang.org/x/net/context can be replaced with context`
+
+func plain() context.Context {
+	return context.Background()
+}
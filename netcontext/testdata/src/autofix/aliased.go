@@ -0,0 +1,7 @@
+package autofix
+
+import netctx "golang.org/x/net/context" // want "golang.org/x/net/context can be replaced with context"
+
+func aliased() netctx.Context {
+	return netctx.TODO()
+}
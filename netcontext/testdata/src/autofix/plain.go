@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context" // want "golang.org/x/net/context can be replaced with context"
+)
+
+func plain() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fmt.Println(ctx.Err())
+}
@@ -0,0 +1,15 @@
+// Package context is a stub of golang.org/x/net/context.
+package context
+
+import "context"
+
+type (
+	Context    = context.Context
+	CancelFunc = context.CancelFunc
+)
+
+func Background() Context { return context.Background() }
+
+func TODO() Context { return context.TODO() }
+
+func WithCancel(parent Context) (Context, CancelFunc) { return context.WithCancel(parent) }
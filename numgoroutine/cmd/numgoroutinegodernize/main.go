@@ -0,0 +1,12 @@
+// Command numgoroutinegodernize runs the numgoroutine analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/numgoroutine"
+)
+
+func main() {
+	singlechecker.Main(numgoroutine.Analyzer)
+}
@@ -0,0 +1,241 @@
+// Package numgoroutine provides an analyzer to detect runtime.NumGoroutine
+// being used to limit concurrency.
+package numgoroutine
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for runtime.NumGoroutine used to limit concurrency
+
+This analyzer reports comparisons against runtime.NumGoroutine in if and for
+conditions of functions that start goroutines. NumGoroutine counts every
+goroutine in the process, including those owned by other packages and the
+runtime, so it cannot enforce a local concurrency limit. Use a semaphore such
+as a buffered channel or golang.org/x/sync/semaphore, or errgroup.Group with
+SetLimit instead. No fix is offered.`
+
+// Analyzer is the main analyzer for runtime.NumGoroutine comparisons.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "numgoroutine",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/numgoroutine",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+		(*ast.ForStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		var cond ast.Expr
+
+		switch stmt := n.(type) {
+		case *ast.IfStmt:
+			cond = stmt.Cond
+		case *ast.ForStmt:
+			cond = stmt.Cond
+		}
+
+		if cond == nil {
+			return true
+		}
+
+		file := fileMap.File(n.Pos())
+
+		for _, diagnostic := range diagnoseCondition(pass, file, cond, enclosingBody(stack)) {
+			pass.Report(diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingBody returns the body of the innermost function on the stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			return node.Body
+		case *ast.FuncLit:
+			return node.Body
+		}
+	}
+
+	return nil
+}
+
+// diagnoseCondition reports every runtime.NumGoroutine call compared within
+// cond, provided the enclosing function starts goroutines.
+func diagnoseCondition(pass *analysis.Pass, file *ast.File, cond ast.Expr, body *ast.BlockStmt) []analysis.Diagnostic {
+	if file == nil || body == nil {
+		return nil
+	}
+
+	var diagnostics []analysis.Diagnostic
+
+	ast.Inspect(cond, func(n ast.Node) bool {
+		// Function literals in the condition have their own scope.
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+
+		binary, ok := n.(*ast.BinaryExpr)
+		if !ok || !isComparison(binary.Op) {
+			return true
+		}
+
+		for _, operand := range []ast.Expr{binary.X, binary.Y} {
+			call, ok := ast.Unparen(operand).(*ast.CallExpr)
+			if !ok || !isPkgFunc(pass.TypesInfo, call, "runtime", "NumGoroutine") {
+				continue
+			}
+
+			if !startsGoroutine(body) || shouldIgnore(pass.Fset, file, call, "numgoroutine") {
+				continue
+			}
+
+			diagnostics = append(diagnostics, analysis.Diagnostic{
+				Pos: call.Pos(),
+				End: call.End(),
+				Message: "runtime.NumGoroutine counts all goroutines in the process and cannot limit local concurrency; " +
+					"use a semaphore or errgroup.Group.SetLimit instead",
+			})
+		}
+
+		return true
+	})
+
+	return diagnostics
+}
+
+func isComparison(op token.Token) bool {
+	return op == token.LSS || op == token.LEQ || op == token.GTR ||
+		op == token.GEQ || op == token.EQL || op == token.NEQ
+}
+
+// startsGoroutine checks if body contains a go statement, including those in
+// nested function literals.
+func startsGoroutine(body *ast.BlockStmt) bool {
+	found := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.GoStmt); ok {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,70 @@
+package a
+
+import (
+	"runtime"
+	"time"
+)
+
+const maxWorkers = 8
+
+func spawnIf(jobs []func()) {
+	for _, job := range jobs {
+		if runtime.NumGoroutine() < maxWorkers { // want `runtime.NumGoroutine counts all goroutines in the process and cannot limit local concurrency; use a semaphore or errgroup.Group.SetLimit instead`
+			go job()
+		} else {
+			job()
+		}
+	}
+}
+
+func spawnWait(jobs []func()) {
+	for _, job := range jobs {
+		for maxWorkers <= (runtime.NumGoroutine()) { // want `runtime.NumGoroutine counts all goroutines`
+			time.Sleep(time.Millisecond)
+		}
+
+		go job()
+	}
+}
+
+func spawnInLiteral(job func()) func() {
+	return func() {
+		if runtime.NumGoroutine() > maxWorkers && job != nil { // want `runtime.NumGoroutine counts all goroutines`
+			return
+		}
+
+		go job()
+	}
+}
+
+// Leak checks that do not start goroutines are fine.
+func leaked(before int) bool {
+	if runtime.NumGoroutine() > before {
+		return true
+	}
+
+	return false
+}
+
+// Reporting the count is fine.
+func count(job func()) int {
+	go job()
+
+	n := runtime.NumGoroutine()
+
+	return n
+}
+
+//godernize:ignore=numgoroutine
+func ignored(job func()) {
+	if runtime.NumGoroutine() < maxWorkers {
+		go job()
+	}
+}
+
+func ignoredInline(job func()) {
+	//godernize:ignore
+	if runtime.NumGoroutine() < maxWorkers {
+		go job()
+	}
+}
@@ -0,0 +1,4 @@
+// Package runtime mimics the name of the standard runtime package.
+package runtime
+
+func NumGoroutine() int { return 0 }
@@ -0,0 +1,9 @@
+package a
+
+import runtime "a/runtime"
+
+func shadowed(job func()) {
+	if runtime.NumGoroutine() < maxWorkers {
+		go job()
+	}
+}
@@ -2,11 +2,10 @@
 package main
 
 import (
-	"golang.org/x/tools/go/analysis/singlechecker"
-
+	"github.com/jaeyeom/godernize/internal/driver"
 	"github.com/jaeyeom/godernize/oserrors"
 )
 
 func main() {
-	singlechecker.Main(oserrors.Analyzer)
+	driver.Main(oserrors.Analyzer)
 }
@@ -9,12 +9,16 @@ import (
 	"go/token"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 
 	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/gofile"
+	"github.com/jaeyeom/godernize/internal/importutil"
 )
 
 // Doc describes what this analyzer does.
@@ -24,7 +28,19 @@ This analyzer reports usage of deprecated os error checking functions and sugges
 replacing them with modern errors.Is() patterns:
 - os.IsNotExist(err) -> errors.Is(err, fs.ErrNotExist)
 - os.IsExist(err) -> errors.Is(err, fs.ErrExist)
-- os.IsPermission(err) -> errors.Is(err, fs.ErrPermission)`
+- os.IsPermission(err) -> errors.Is(err, fs.ErrPermission)
+
+Generated files, marked by a "// Code generated ... DO NOT EDIT." header, are
+skipped unless -skip-generated=false, since regenerating them would undo the
+rewrite.`
+
+// CategoryDeprecatedFunc is the category of every diagnostic, set as
+// analysis.Diagnostic.Category so that tools can group them.
+const CategoryDeprecatedFunc = "oserrors.deprecated-func"
+
+// FixMessage is the message of every suggested fix. Unlike the diagnostic
+// message, it does not depend on the rewritten calls.
+const FixMessage = "Replace deprecated os error functions with errors.Is"
 
 // Analyzer is the main analyzer for deprecated os error functions.
 //
@@ -36,7 +52,7 @@ var Analyzer = newAnalyzer(map[string]string{
 })
 
 func newAnalyzer(osFuncsToFsErr map[string]string) *analysis.Analyzer {
-	runner := runner{osFuncsToFsErr: osFuncsToFsErr}
+	runner := &runner{osFuncsToFsErr: osFuncsToFsErr}
 
 	analyzer := &analysis.Analyzer{
 		Name:     "oserrors",
@@ -46,11 +62,18 @@ func newAnalyzer(osFuncsToFsErr map[string]string) *analysis.Analyzer {
 		Requires: []*analysis.Analyzer{inspect.Analyzer},
 	}
 
+	analyzer.Flags.BoolVar(&runner.defaultDisabled, "default-disabled", false,
+		"only report in functions and files with a //godernize:enable directive")
+	analyzer.Flags.BoolVar(&runner.skipGenerated, "skip-generated", true,
+		"skip files with a '// Code generated ... DO NOT EDIT.' header")
+
 	return analyzer
 }
 
 type runner struct {
-	osFuncsToFsErr map[string]string
+	osFuncsToFsErr  map[string]string
+	defaultDisabled bool
+	skipGenerated   bool
 }
 
 //nolint:nilnil // analyzer pattern
@@ -68,7 +91,15 @@ func (r *runner) run(pass *analysis.Pass) (any, error) {
 		(*ast.CallExpr)(nil),
 	}
 
-	fileMap := buildFileMap(pass)
+	fileMap := filemap.New(pass.Files)
+	generated := r.generatedFiles(pass)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single import adjustment.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]fileDiagnostic)
+	)
 
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call, ok := n.(*ast.CallExpr)
@@ -76,30 +107,97 @@ func (r *runner) run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
-		pos := pass.Fset.Position(call.Pos())
-		filename := pos.Filename
-		file := fileMap[filename]
+		// Nodes come from pass.Files, never from pass.OtherFiles or
+		// pass.IgnoredFiles, but a node without a file could be checked
+		// against no ignore directive, so it is skipped.
+		file := fileMap.File(call.Pos())
+		if file == nil || generated[file] {
+			return
+		}
 
-		if diagnostic := r.diagnoseCallExpr(file, call); diagnostic != nil {
-			pass.Report(*diagnostic)
+		if diagnostic := r.diagnoseCallExpr(pass.Fset, file, call); diagnostic != nil {
+			if _, seen := found[file]; !seen {
+				files = append(files, file)
+			}
+
+			found[file] = append(found[file], fileDiagnostic{diagnostic: *diagnostic, call: call})
 		}
 	})
 
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
 	return nil, nil
 }
 
-func buildFileMap(pass *analysis.Pass) map[string]*ast.File {
-	fileMap := make(map[string]*ast.File)
+// generatedFiles returns the files of pass to skip with -skip-generated,
+// which carry the standard header of generated code.
+func (r *runner) generatedFiles(pass *analysis.Pass) map[*ast.File]bool {
+	generated := make(map[*ast.File]bool)
+
+	if !r.skipGenerated {
+		return generated
+	}
 
 	for _, file := range pass.Files {
-		pos := pass.Fset.Position(file.Pos())
-		fileMap[pos.Filename] = file
+		if gofile.IsGenerated(file) {
+			generated[file] = true
+		}
+	}
+
+	return generated
+}
+
+// fileDiagnostic is a diagnostic with the call it rewrites.
+type fileDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	call       *ast.CallExpr
+}
+
+// consolidateFixes combines the call rewrites of all diagnostics in file with
+// one adjustment of the imports: errors and io/fs are added, and os is removed
+// when no other reference remains. With several diagnostics, only the first
+// carries the combined fix, so that applying every fix of the file does not
+// apply the same import edits twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []fileDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	calls := make([]ast.Node, len(found))
+
+	var edits []analysis.TextEdit
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+		calls[i] = f.call
+
+		for _, fix := range f.diagnostic.SuggestedFixes {
+			edits = append(edits, fix.TextEdits...)
+		}
+	}
+
+	if file == nil || len(edits) == 0 {
+		return diagnostics
+	}
+
+	var remove []string
+	if !importutil.UsedOutside(pass.TypesInfo, file, "os", calls...) {
+		remove = []string{"os"}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{"errors", "io/fs"}, remove)...)
+
+	diagnostics[0].SuggestedFixes = []analysis.SuggestedFix{{Message: FixMessage, TextEdits: edits}}
+
+	for i := 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
 	}
 
-	return fileMap
+	return diagnostics
 }
 
-func (r *runner) diagnoseCallExpr(file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+func (r *runner) diagnoseCallExpr(fset *token.FileSet, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
 	if call == nil || call.Fun == nil {
 		return nil
 	}
@@ -109,11 +207,11 @@ func (r *runner) diagnoseCallExpr(file *ast.File, call *ast.CallExpr) *analysis.
 		return nil // Not a deprecated os function
 	}
 
-	if shouldIgnore(file, call, fName) {
+	if (r.defaultDisabled && !shouldEnable(file, call, fName)) || shouldIgnore(fset, file, call, fName) {
 		return nil
 	}
 
-	return createDiagnostic(file, call, fName, fsErr)
+	return createDiagnostic(fset, file, call, fName, fsErr)
 }
 
 func (r *runner) findMapping(file *ast.File, call *ast.CallExpr) (fName, fsErr string) {
@@ -153,8 +251,59 @@ func importedIdentifier(file *ast.File, ident *ast.Ident, path string) string {
 	return findAliasName(file, path)
 }
 
-func shouldIgnore(file *ast.File, call *ast.CallExpr, funcName string) bool {
-	return shouldIgnoreInFunction(file, call, funcName) || shouldIgnoreFromComment(file, call, funcName)
+func shouldIgnore(fset *token.FileSet, file *ast.File, call *ast.CallExpr, funcName string) bool {
+	return shouldIgnoreInFile(file, funcName) ||
+		shouldIgnoreInRange(file, call, funcName) ||
+		shouldIgnoreInFunction(file, call, funcName) ||
+		shouldIgnoreFromComment(fset, file, call, funcName)
+}
+
+// shouldEnable checks the enable directives of the file and of the function
+// declaration containing call, which turn the analyzer on when it is disabled
+// by default.
+func shouldEnable(file *ast.File, call *ast.CallExpr, funcName string) bool {
+	if file == nil {
+		return false
+	}
+
+	if enable := directive.FileEnable(file); enable != nil &&
+		(enable.ShouldEnable("oserrors") || enable.ShouldEnable(funcName)) {
+		return true
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || call.Pos() < funcDecl.Pos() || call.End() > funcDecl.End() {
+			continue
+		}
+
+		enable := directive.ParseEnable(funcDecl.Doc)
+		if enable != nil && (enable.ShouldEnable("oserrors") || enable.ShouldEnable(funcName)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, funcName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && (ignore.ShouldIgnore("oserrors") || ignore.ShouldIgnore(funcName))
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, call *ast.CallExpr, funcName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(call.Pos()) && (r.ShouldIgnore("oserrors") || r.ShouldIgnore(funcName)) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func shouldIgnoreInFunction(file *ast.File, call *ast.CallExpr, funcName string) bool {
@@ -165,7 +314,7 @@ func shouldIgnoreInFunction(file *ast.File, call *ast.CallExpr, funcName string)
 		}
 
 		if call.Pos() >= funcDecl.Pos() && call.End() <= funcDecl.End() {
-			ignore := directive.ParseIgnore(funcDecl.Doc)
+			ignore := directive.FuncIgnore(file, funcDecl)
 			if ignore != nil && (ignore.ShouldIgnore("oserrors") || ignore.ShouldIgnore(funcName)) {
 				return true
 			}
@@ -175,21 +324,23 @@ func shouldIgnoreInFunction(file *ast.File, call *ast.CallExpr, funcName string)
 	return false
 }
 
-func shouldIgnoreFromComment(file *ast.File, call *ast.CallExpr, funcName string) bool {
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, call *ast.CallExpr, funcName string) bool {
 	for _, cg := range file.Comments {
 		// Check if comment appears before the call and is reasonably close
 		if cg.End() <= call.Pos() && call.Pos()-cg.End() <= 200 {
-			ignore := directive.ParseIgnore(cg)
+			ignore := directive.ParseGodernize(cg)
 			if ignore != nil && (ignore.ShouldIgnore("oserrors") || ignore.ShouldIgnore(funcName)) {
 				return true
 			}
 		}
 	}
 
-	return false
+	ignore := directive.NolintIgnore(fset, file, call)
+
+	return ignore != nil && (ignore.ShouldIgnore("oserrors") || ignore.ShouldIgnore(funcName))
 }
 
-func createDiagnostic(file *ast.File, call *ast.CallExpr, fName, fsErr string) *analysis.Diagnostic {
+func createDiagnostic(fset *token.FileSet, file *ast.File, call *ast.CallExpr, fName, fsErr string) *analysis.Diagnostic {
 	if call == nil || !call.Pos().IsValid() || !call.End().IsValid() {
 		return nil
 	}
@@ -200,7 +351,7 @@ func createDiagnostic(file *ast.File, call *ast.CallExpr, fName, fsErr string) *
 	}
 
 	// Get the argument as text
-	argText := formatASTNode(call.Args[0])
+	argText := formatASTNode(fset, call.Args[0])
 	if argText == "" {
 		argText = "err" // fallback
 	}
@@ -210,30 +361,71 @@ func createDiagnostic(file *ast.File, call *ast.CallExpr, fName, fsErr string) *
 		return nil // No valid replacement found
 	}
 
-	return &analysis.Diagnostic{
+	edits := []analysis.TextEdit{{
 		Pos:     call.Pos(),
-		Message: fmt.Sprintf("os.%s is deprecated, use %s instead", fName, replacementText),
+		End:     call.End(),
+		NewText: []byte(replacementText),
+	}}
+
+	// Comments inside the call would be lost with the call, so the
+	// parentheses and everything between them are kept instead.
+	if hasComments(file, call.Lparen, call.Rparen) {
+		errorsPackage, fsPackage := replacementPackages(file)
+		edits = []analysis.TextEdit{
+			{Pos: call.Pos(), End: call.Lparen + 1, NewText: []byte(errorsPackage + ".Is(")},
+			{Pos: call.Args[0].End(), End: call.Args[0].End(), NewText: []byte(", " + fsPackage + "." + fsErr)},
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos:      call.Pos(),
+		End:      call.End(),
+		Category: CategoryDeprecatedFunc,
+		Message:  fmt.Sprintf("os.%s is deprecated, use %s instead", fName, replacementText),
 		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: "Replace with " + replacementText,
-			TextEdits: []analysis.TextEdit{{
-				Pos:     call.Pos(),
-				End:     call.End(),
-				NewText: []byte(replacementText),
-			}},
+			Message:   FixMessage,
+			TextEdits: edits,
 		}},
 	}
 }
 
-func formatASTNode(node ast.Node) string {
+// hasComments checks if a comment of file lies between pos and end.
+func hasComments(file *ast.File, pos, end token.Pos) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > pos && group.End() <= end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bufferPool holds the buffers used by formatASTNode, which runs once per
+// reported call.
+//
+//nolint:gochecknoglobals // shared pool of reusable buffers
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// formatASTNode formats node against fset, the file set of the analyzed
+// package.
+func formatASTNode(fset *token.FileSet, node ast.Node) string {
 	if node == nil {
 		return ""
 	}
 
-	fset := token.NewFileSet()
+	buf, ok := bufferPool.Get().(*bytes.Buffer)
+	if !ok {
+		buf = new(bytes.Buffer)
+	}
 
-	var buf bytes.Buffer
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
 
-	err := format.Node(&buf, fset, node)
+	err := format.Node(buf, fset, node)
 	if err != nil {
 		return ""
 	}
@@ -242,17 +434,25 @@ func formatASTNode(node ast.Node) string {
 }
 
 func buildReplacementText(file *ast.File, argText, fsErr string) string {
-	errorsPackage := findAliasName(file, "errors")
+	errorsPackage, fsPackage := replacementPackages(file)
+
+	return fmt.Sprintf("%s.Is(%s, %s.%s)", errorsPackage, argText, fsPackage, fsErr)
+}
+
+// replacementPackages returns the names of the errors and io/fs packages in
+// file, which are imported with their default names if missing.
+func replacementPackages(file *ast.File) (errorsPackage, fsPackage string) {
+	errorsPackage = findAliasName(file, "errors")
 	if errorsPackage == "" {
 		errorsPackage = "errors" // new import
 	}
 
-	fsPackage := findAliasName(file, "io/fs")
+	fsPackage = findAliasName(file, "io/fs")
 	if fsPackage == "" {
 		fsPackage = "fs" // new import
 	}
 
-	return fmt.Sprintf("%s.Is(%s, %s.%s)", errorsPackage, argText, fsPackage, fsErr)
+	return errorsPackage, fsPackage
 }
 
 func findAliasName(file *ast.File, path string) string {
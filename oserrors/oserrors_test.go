@@ -1,9 +1,22 @@
 package oserrors_test
 
 import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
 
 	"github.com/jaeyeom/godernize/oserrors"
 )
@@ -12,12 +25,257 @@ func TestAnalyzer(t *testing.T) {
 	t.Parallel()
 
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, oserrors.Analyzer, "a")
+	analysistest.Run(t, testdata, oserrors.Analyzer, "a", "pkgdoc", "fileignore", "ranges")
 }
 
 func TestAutoFix(t *testing.T) {
 	t.Parallel()
 
 	testdata := analysistest.TestData()
-	analysistest.RunWithSuggestedFixes(t, testdata, oserrors.Analyzer, "autofix")
+	analysistest.RunWithSuggestedFixes(t, testdata, oserrors.Analyzer, "autofix", "comments", "args")
+}
+
+// TestArgumentSyntax checks that calls whose argument is a call, a method
+// call, a field, or parenthesized are rewritten to valid errors.Is calls
+// keeping the argument.
+func TestArgumentSyntax(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, oserrors.Analyzer, "args")
+
+	diagnosed, verified := 0, 0
+
+	for _, result := range results {
+		diagnosed += len(result.Diagnostics)
+
+		for _, diagnostic := range result.Diagnostics {
+			for _, fix := range diagnostic.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					if strings.Contains(string(edit.NewText), ".Is(") {
+						verified++
+
+						verifyGoSyntax(t, result.Pass.Fset.Position(edit.Pos), string(edit.NewText))
+					}
+				}
+			}
+		}
+	}
+
+	if verified != diagnosed || diagnosed == 0 {
+		t.Errorf("verified %d replacements, want one for each of the %d diagnostics", verified, diagnosed)
+	}
+}
+
+// verifyGoSyntax checks that replacement parses as a call of errors.Is with
+// two arguments.
+func verifyGoSyntax(t *testing.T, position token.Position, replacement string) {
+	t.Helper()
+
+	expr, err := parser.ParseExpr(replacement)
+	if err != nil {
+		t.Errorf("%s: replacement %q is not a valid expression: %v", position, replacement, err)
+
+		return
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || types.ExprString(call.Fun) != "errors.Is" || len(call.Args) != 2 {
+		t.Errorf("%s: replacement %q is not a call of errors.Is with two arguments", position, replacement)
+	}
+}
+
+// TestOtherFiles checks that files excluded by build constraints and non-Go
+// files of the package are not analyzed.
+func TestOtherFiles(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, oserrors.Analyzer, "otherfiles")
+
+	for _, result := range results {
+		if len(result.Pass.IgnoredFiles) == 0 || len(result.Pass.OtherFiles) == 0 {
+			t.Errorf("got ignored files %v and other files %v, want both",
+				result.Pass.IgnoredFiles, result.Pass.OtherFiles)
+		}
+	}
+}
+
+// TestDiagnosticRange checks that diagnostics cover exactly the deprecated
+// call, also when it is the condition of an if statement with an init
+// statement, and that the single fix of each file rewrites exactly those
+// calls besides the imports.
+func TestDiagnosticRange(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, oserrors.Analyzer, "autofix")
+
+	call := regexp.MustCompile(`^os\.Is\w+\(err\)$`)
+
+	type span struct{ pos, end token.Pos }
+
+	for _, result := range results {
+		fset := result.Pass.Fset
+		diagnosed := make(map[*token.File]map[span]bool)
+		rewritten := make(map[*token.File]map[span]bool)
+
+		for _, diagnostic := range result.Diagnostics {
+			file := fset.File(diagnostic.Pos)
+
+			src, err := os.ReadFile(file.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			text := func(pos, end token.Pos) string {
+				return string(src[file.Offset(pos):file.Offset(end)])
+			}
+
+			if got := text(diagnostic.Pos, diagnostic.End); !call.MatchString(got) {
+				t.Errorf("%s: diagnostic covers %q, want the deprecated call", fset.Position(diagnostic.Pos), got)
+			}
+
+			if diagnosed[file] == nil {
+				diagnosed[file], rewritten[file] = make(map[span]bool), make(map[span]bool)
+			} else if len(diagnostic.SuggestedFixes) > 0 {
+				t.Errorf("%s: fix on a diagnostic other than the first of the file", fset.Position(diagnostic.Pos))
+			}
+
+			diagnosed[file][span{diagnostic.Pos, diagnostic.End}] = true
+
+			for _, fix := range diagnostic.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					if edit.End.IsValid() && call.MatchString(text(edit.Pos, edit.End)) {
+						rewritten[file][span{edit.Pos, edit.End}] = true
+					}
+				}
+			}
+		}
+
+		for file, spans := range diagnosed {
+			if !maps.Equal(spans, rewritten[file]) {
+				t.Errorf("%s: fix rewrites %d calls, want the %d diagnosed calls", file.Name(), len(rewritten[file]), len(spans))
+			}
+		}
+	}
+}
+
+// TestCategories checks that every diagnostic carries the stable category
+// and that fixes carry the stable message, whatever the rewritten calls.
+func TestCategories(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, oserrors.Analyzer, "a", "autofix")
+
+	for _, result := range results {
+		for _, diagnostic := range result.Diagnostics {
+			position := result.Pass.Fset.Position(diagnostic.Pos)
+
+			if diagnostic.Category != oserrors.CategoryDeprecatedFunc {
+				t.Errorf("%s: got category %q, want %q", position, diagnostic.Category, oserrors.CategoryDeprecatedFunc)
+			}
+
+			for _, fix := range diagnostic.SuggestedFixes {
+				if fix.Message != oserrors.FixMessage {
+					t.Errorf("%s: got fix message %q, want %q", position, fix.Message, oserrors.FixMessage)
+				}
+			}
+		}
+	}
+}
+
+func TestDefaultDisabled(t *testing.T) {
+	if err := oserrors.Analyzer.Flags.Set("default-disabled", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	defer func() {
+		_ = oserrors.Analyzer.Flags.Set("default-disabled", "false")
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, oserrors.Analyzer, "enable")
+}
+
+func TestSkipGenerated(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, oserrors.Analyzer, "generated")
+}
+
+func TestKeepGenerated(t *testing.T) {
+	if err := oserrors.Analyzer.Flags.Set("skip-generated", "false"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	defer func() {
+		_ = oserrors.Analyzer.Flags.Set("skip-generated", "true")
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, oserrors.Analyzer, "generatedkept")
+}
+
+// BenchmarkFormatArg measures formatting the arguments of the reported calls
+// in a large file. Run it with -benchmem to see the allocations.
+func BenchmarkFormatArg(b *testing.B) {
+	pass := newBenchmarkPass(b, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := oserrors.Analyzer.Run(pass); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// newBenchmarkPass type-checks a synthetic package with calls os.IsNotExist
+// calls on arguments of varying shape.
+func newBenchmarkPass(tb testing.TB, calls int) *analysis.Pass {
+	tb.Helper()
+
+	var src strings.Builder
+
+	src.WriteString("package bench\n\nimport \"os\"\n\ntype result struct{ err error }\n\n")
+
+	for i := range calls {
+		fmt.Fprintf(&src, "func check%d(err error, r result, errs []error) bool {\n"+
+			"\treturn os.IsNotExist(err) || os.IsNotExist(r.err) || os.IsNotExist(errs[%d])\n}\n\n", i, i%3)
+	}
+
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, "bench.go", src.String(), parser.ParseComments)
+	if err != nil {
+		tb.Fatalf("Failed to parse: %v", err)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+
+	pkg, err := conf.Check("bench", fset, []*ast.File{file}, info)
+	if err != nil {
+		tb.Fatalf("Failed to type-check: %v", err)
+	}
+
+	files := []*ast.File{file}
+
+	return &analysis.Pass{
+		Analyzer:  oserrors.Analyzer,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       pkg,
+		TypesInfo: info,
+		ResultOf:  map[*analysis.Analyzer]any{inspect.Analyzer: inspector.New(files)},
+		Report:    func(analysis.Diagnostic) {},
+	}
 }
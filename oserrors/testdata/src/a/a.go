@@ -68,3 +68,52 @@ func ignoreWithComment() {
 		fmt.Println("Comment ignored")
 	}
 }
+
+//godernize:ignore=IsNotExist // legacy path, removed in Q3
+func ignoreWithReason() {
+	_, err := os.Stat("reason.txt")
+	if os.IsNotExist(err) { // This should be ignored
+		fmt.Println("Ignored with a reason")
+	}
+	if os.IsExist(err) { // want "os.IsExist is deprecated, use errors.Is\\(err, fs.ErrExist\\) instead"
+		fmt.Println("Not covered by the directive")
+	}
+}
+
+//nolint:oserrors // migrated in a follow-up
+func ignoreWithNolint() {
+	_, err := os.Stat("nolint.txt")
+	if os.IsNotExist(err) { // This should be ignored
+		fmt.Println("Ignored by nolint")
+	}
+}
+
+//nolint:errcheck
+func otherLinterNolint() {
+	_, err := os.Stat("errcheck.txt")
+	if os.IsNotExist(err) { // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+		fmt.Println("Not covered by the directive")
+	}
+}
+
+func nolintOnLine() {
+	_, err := os.Stat("line.txt")
+	if os.IsNotExist(err) { //nolint:oserrors // checked by the caller
+		fmt.Println("Ignored by the nolint directive on its line")
+	}
+}
+
+func nolintTrailingPreviousLine() {
+	_, err := os.Stat("previous.txt") //nolint
+	if os.IsNotExist(err) {           // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+		fmt.Println("A trailing nolint directive applies to its own line only")
+	}
+}
+
+func nolintAbove() {
+	_, err := os.Stat("above.txt")
+	//nolint:oserrors
+	if os.IsNotExist(err) {
+		fmt.Println("Ignored by the nolint directive standing alone above")
+	}
+}
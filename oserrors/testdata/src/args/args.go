@@ -0,0 +1,30 @@
+package args
+
+import (
+	"errors"
+	"os"
+)
+
+type response struct {
+	err error
+}
+
+func (r *response) Err() error {
+	return r.err
+}
+
+func nested(err error) bool {
+	return os.IsNotExist(errors.Unwrap(err)) // want `use errors.Is\(errors.Unwrap\(err\), fs.ErrNotExist\) instead`
+}
+
+func method(resp *response) bool {
+	return os.IsPermission(resp.Err()) // want `use errors.Is\(resp.Err\(\), fs.ErrPermission\) instead`
+}
+
+func field(resp *response) bool {
+	return os.IsNotExist(resp.err) // want `use errors.Is\(resp.err, fs.ErrNotExist\) instead`
+}
+
+func parenthesized(err error) bool {
+	return os.IsExist((err)) // want `use errors.Is\(\(err\), fs.ErrExist\) instead`
+}
@@ -0,0 +1,22 @@
+package autofix
+
+import (
+	"fmt"
+	"os"
+)
+
+var _ = ifInit
+
+func doThing() error { return nil }
+
+func ifInit() {
+	if err := doThing(); os.IsNotExist(err) { // want `os.IsNotExist is deprecated, use errors.Is\(err, fs.ErrNotExist\) instead`
+		fmt.Println("File does not exist")
+	}
+
+	if f, err := os.Open("test.txt"); os.IsPermission(err) { // want `os.IsPermission is deprecated, use errors.Is\(err, fs.ErrPermission\) instead`
+		fmt.Println("Permission denied")
+	} else if err == nil {
+		f.Close()
+	}
+}
@@ -0,0 +1,29 @@
+package comments
+
+import "os"
+
+func trailing(err error) bool {
+	if os.IsNotExist(err) { // check existence // want "os.IsNotExist is deprecated"
+		return true
+	}
+
+	return os.IsExist(err) // already there // want "os.IsExist is deprecated"
+}
+
+func leading(err error) bool {
+	// Permission problems are reported separately.
+	if os.IsPermission(err) { // want "os.IsPermission is deprecated"
+		return false
+	}
+
+	/* block comment before the call */
+	return os.IsNotExist(err) // want "os.IsNotExist is deprecated"
+}
+
+func inside(err error) bool {
+	return os.IsNotExist(err /* from Open */) || // want "os.IsNotExist is deprecated"
+		os.IsExist( // want "os.IsExist is deprecated"
+			// from Mkdir
+			err,
+		)
+}
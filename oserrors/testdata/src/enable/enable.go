@@ -0,0 +1,41 @@
+package enable
+
+import (
+	"fmt"
+	"os"
+)
+
+//godernize:enable=oserrors
+func enabled() {
+	_, err := os.Stat("enabled.txt")
+	if os.IsNotExist(err) { // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+		fmt.Println("reported")
+	}
+}
+
+//godernize:enable=IsExist
+func enabledFunction() {
+	_, err := os.Stat("function.txt")
+	if os.IsNotExist(err) {
+		fmt.Println("not enabled")
+	}
+	if os.IsExist(err) { // want "os.IsExist is deprecated, use errors.Is\\(err, fs.ErrExist\\) instead"
+		fmt.Println("reported")
+	}
+}
+
+//godernize:enable=oserrors
+//godernize:ignore=IsPermission
+func enabledButIgnored() {
+	_, err := os.Stat("ignored.txt")
+	if os.IsPermission(err) {
+		fmt.Println("ignored")
+	}
+}
+
+func notEnabled() {
+	_, err := os.Stat("disabled.txt")
+	if os.IsNotExist(err) {
+		fmt.Println("not enabled")
+	}
+}
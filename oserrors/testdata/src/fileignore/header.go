@@ -0,0 +1,13 @@
+package fileignore
+
+//godernize:ignore=oserrors
+
+import "os"
+
+func ignoredNotExist(err error) bool {
+	return os.IsNotExist(err) || os.IsExist(err)
+}
+
+func ignoredPermission(err error) bool {
+	return os.IsPermission(err)
+}
@@ -0,0 +1,8 @@
+package fileignore
+
+import "os"
+
+// File-level directives of other files do not apply to this file.
+func reported(err error) bool {
+	return os.IsExist(err) // want "os.IsExist is deprecated, use errors.Is\\(err, fs.ErrExist\\) instead"
+}
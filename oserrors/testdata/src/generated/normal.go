@@ -0,0 +1,7 @@
+package generated
+
+import "os"
+
+func missing(err error) bool {
+	return os.IsNotExist(err) // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+}
@@ -0,0 +1,9 @@
+//go:build ignore
+
+package otherfiles
+
+import "os"
+
+func exists(err error) bool {
+	return os.IsExist(err)
+}
@@ -0,0 +1,10 @@
+// Package otherfiles has files that are not analyzed: a file excluded by a
+// build constraint, which is in pass.IgnoredFiles, and an assembly file,
+// which is in pass.OtherFiles.
+package otherfiles
+
+import "os"
+
+func missing(err error) bool {
+	return os.IsNotExist(err) // want "os.IsNotExist is deprecated"
+}
@@ -0,0 +1 @@
+// os.IsExist(err) is not Go code.
@@ -0,0 +1,10 @@
+// Package pkgdoc checks that the package doc comment can disable oserrors.
+//
+//godernize:ignore=oserrors
+package pkgdoc
+
+import "os"
+
+func ignoredEverywhere(err error) bool {
+	return os.IsNotExist(err) || os.IsExist(err)
+}
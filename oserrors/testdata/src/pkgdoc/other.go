@@ -0,0 +1,8 @@
+package pkgdoc
+
+import "os"
+
+// The directives in the package docs of other files do not apply to this file.
+func reported(err error) bool {
+	return os.IsPermission(err) // want "os.IsPermission is deprecated, use errors.Is\\(err, fs.ErrPermission\\) instead"
+}
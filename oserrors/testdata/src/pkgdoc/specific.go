@@ -0,0 +1,8 @@
+//godernize:ignore=IsNotExist
+package pkgdoc
+
+import "os"
+
+func specific(err error) bool {
+	return os.IsNotExist(err) || os.IsExist(err) // want "os.IsExist is deprecated, use errors.Is\\(err, fs.ErrExist\\) instead"
+}
@@ -0,0 +1,31 @@
+package ranges
+
+import "os"
+
+func nested(err error) []bool {
+	//godernize:ignore-begin=oserrors
+	first := os.IsNotExist(err)
+	//godernize:ignore-begin=IsExist
+	second := os.IsExist(err)
+	//godernize:ignore-end=oserrors
+	third := os.IsExist(err)
+	fourth := os.IsPermission(err) // want "os.IsPermission is deprecated, use errors.Is\\(err, fs.ErrPermission\\) instead"
+	//godernize:ignore-end=IsExist
+	fifth := os.IsExist(err) // want "os.IsExist is deprecated, use errors.Is\\(err, fs.ErrExist\\) instead"
+
+	return []bool{first, second, third, fourth, fifth}
+}
+
+func mismatched(err error) bool {
+	// An end directive without a matching begin has no effect.
+	//godernize:ignore-end=oserrors
+	return os.IsNotExist(err) // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+}
+
+func otherAnalyzer(err error) bool {
+	//godernize:ignore-begin=ctxnil
+	notExist := os.IsNotExist(err) // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+	//godernize:ignore-end=ctxnil
+
+	return notExist
+}
@@ -0,0 +1,17 @@
+package ranges
+
+import "os"
+
+func beforeUnclosed(err error) bool {
+	return os.IsNotExist(err) // want "os.IsNotExist is deprecated, use errors.Is\\(err, fs.ErrNotExist\\) instead"
+}
+
+func opensRange(err error) bool {
+	// A begin directive that is never closed suppresses the rest of the file.
+	//godernize:ignore-begin
+	return os.IsNotExist(err)
+}
+
+func afterUnclosed(err error) bool {
+	return os.IsExist(err) || os.IsPermission(err)
+}
@@ -0,0 +1,15 @@
+// Command panicsprintgodernize runs the panicsprint analyzer.
+//
+// It uses multichecker, whose flags are prefixed with the analyzer name, since
+// singlechecker reserves -fix for applying fixes and would drop -panicsprint.fix.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/jaeyeom/godernize/panicsprint"
+)
+
+func main() {
+	multichecker.Main(panicsprint.Analyzer)
+}
@@ -0,0 +1,229 @@
+// Package panicsprint provides an analyzer to detect panics with a message
+// formatted by fmt.Sprintf.
+package panicsprint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const fmtPath = "fmt"
+
+// Doc describes what this analyzer does.
+const Doc = `check for panic(fmt.Sprintf(...))
+
+This analyzer reports panics whose value is a string formatted with
+fmt.Sprintf, which could be a formatted error instead:
+- panic(fmt.Sprintf("bad value %v", v)) -> panic(fmt.Errorf("bad value %v", v))
+
+A recovered error can be inspected with errors.Is and errors.As, and %w
+verbs wrap the errors they format. Whether to panic with an error is a matter
+of style, so fixes are only offered with -fix. The fix renames the call and
+keeps the format and its arguments as written.`
+
+// Analyzer is the main analyzer for panics with fmt.Sprintf messages.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "panicsprint",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/panicsprint",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.fix, "fix", false,
+		"offer fixes rewriting panic(fmt.Sprintf(...)) to panic(fmt.Errorf(...))")
+
+	return analyzer
+}
+
+type runner struct {
+	fix bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := r.diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func (r *runner) diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) != 1 || !isBuiltin(pass.TypesInfo, call.Fun, "panic") {
+		return nil
+	}
+
+	sprintf, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok || !isPkgFunc(pass.TypesInfo, sprintf, fmtPath, "Sprintf") {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "panicsprint") {
+		return nil
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "panic(fmt.Sprintf(...)) panics with a string, use panic(fmt.Errorf(...)) to panic with an error",
+	}
+
+	name := funcName(sprintf.Fun)
+	if !r.fix || name == nil {
+		return diagnostic
+	}
+
+	// Only the name is replaced, so that the qualifier, the format, and the
+	// arguments stay as written.
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace fmt.Sprintf with fmt.Errorf",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     name.Pos(),
+			End:     name.End(),
+			NewText: []byte("Errorf"),
+		}},
+	}}
+
+	return diagnostic
+}
+
+// funcName returns the identifier naming the called function, as in
+// fmt.Sprintf or, with a dot import, Sprintf.
+func funcName(fun ast.Expr) *ast.Ident {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	}
+
+	return nil
+}
+
+func isBuiltin(info *types.Info, fun ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+
+	return ok && builtin.Name() == name
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
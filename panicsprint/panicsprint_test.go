@@ -0,0 +1,27 @@
+package panicsprint_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/panicsprint"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, panicsprint.Analyzer, "a")
+}
+
+func TestFix(t *testing.T) {
+	if err := panicsprint.Analyzer.Flags.Set("fix", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = panicsprint.Analyzer.Flags.Set("fix", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, panicsprint.Analyzer, "autofix")
+}
@@ -0,0 +1,34 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	f "fmt"
+)
+
+func check(v int, err error) {
+	if v < 0 {
+		panic(fmt.Sprintf("negative value %d", v)) // want `panic\(fmt.Sprintf\(...\)\) panics with a string, use panic\(fmt.Errorf\(...\)\) to panic with an error`
+	}
+
+	if err != nil {
+		panic(f.Sprintf("check %d: %v", v, err)) // want `panic\(fmt.Sprintf\(...\)\) panics with a string`
+	}
+}
+
+func notReported(v int) {
+	panic(fmt.Errorf("bad value %d", v))
+	panic(errors.New("bad value"))
+	panic(fmt.Sprint("bad value ", v))
+	panic("bad value")
+}
+
+func shadowed(v int) {
+	panic := func(v any) {}
+	panic(fmt.Sprintf("bad value %d", v))
+}
+
+func ignored(v int) {
+	//godernize:ignore=panicsprint
+	panic(fmt.Sprintf("bad value %d", v))
+}
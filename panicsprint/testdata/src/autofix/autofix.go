@@ -0,0 +1,20 @@
+package autofix
+
+import (
+	"fmt"
+	. "fmt"
+)
+
+func check(v int, err error) {
+	if v < 0 {
+		panic(fmt.Sprintf("negative value %d", v)) // want `panic\(fmt.Sprintf\(...\)\) panics with a string`
+	}
+
+	if err != nil {
+		panic(fmt.Sprintf("check %d: %v", // want `panic\(fmt.Sprintf\(...\)\) panics with a string`
+			v, err, // keep the arguments
+		))
+	}
+
+	panic((Sprintf("value %d", v))) // want `panic\(fmt.Sprintf\(...\)\) panics with a string`
+}
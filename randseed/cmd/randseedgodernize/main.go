@@ -0,0 +1,12 @@
+// Command randseedgodernize runs the randseed analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/randseed"
+)
+
+func main() {
+	singlechecker.Main(randseed.Analyzer)
+}
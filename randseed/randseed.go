@@ -0,0 +1,355 @@
+// Package randseed provides an analyzer to detect deprecated math/rand.Seed calls.
+package randseed
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for deprecated math/rand.Seed calls
+
+This analyzer reports calls to rand.Seed from math/rand, which is deprecated
+since Go 1.20 because the global source is seeded automatically. Calls seeded
+from the current time can simply be removed; programs that need a reproducible
+sequence should use a local rand.New(rand.NewSource(seed)) instead.
+
+With -remove, calls seeded from time.Now() are deleted and the time import is
+dropped when no longer used. Files built for Go versions before 1.20, whose
+global source is not seeded automatically, are reported without a fix.`
+
+// Analyzer is the main analyzer for math/rand.Seed calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = newAnalyzer()
+
+func newAnalyzer() *analysis.Analyzer {
+	runner := &runner{}
+
+	analyzer := &analysis.Analyzer{
+		Name:     "randseed",
+		Doc:      Doc,
+		URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/randseed",
+		Run:      runner.run,
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+	}
+
+	analyzer.Flags.BoolVar(&runner.remove, "remove", false,
+		"remove rand.Seed calls seeded from time.Now()")
+
+	return analyzer
+}
+
+type runner struct {
+	remove bool
+}
+
+//nolint:nilnil // analyzer pattern
+func (r *runner) run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the imports.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]stmtDiagnostic)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic, stmt := r.diagnoseCallExpr(pass, file, call, stack)
+		if diagnostic == nil {
+			return true
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], stmtDiagnostic{diagnostic: *diagnostic, stmt: stmt})
+
+		return true
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// stmtDiagnostic is a diagnostic with the statement its fix removes, or nil
+// if it has no fix.
+type stmtDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	stmt       ast.Stmt
+}
+
+// consolidateFixes adds one removal of the time and math/rand imports to the
+// fixes in file, for the imports whose last references are in the removed
+// statements. With several fixes, only the first carries the removals of all
+// of them, so that applying every fix of the file does not remove the
+// imports twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []stmtDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.stmt)
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	var remove []string
+
+	for _, importPath := range []string{"math/rand", "time"} {
+		if !importutil.UsedOutside(pass.TypesInfo, file, importPath, replaced...) {
+			remove = append(remove, importPath)
+		}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, nil, remove)...)
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// diagnoseCallExpr returns the diagnostic of a rand.Seed call, with the
+// statement removed by its fix. The imports are removed by consolidateFixes.
+func (r *runner) diagnoseCallExpr(
+	pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node,
+) (*analysis.Diagnostic, ast.Stmt) {
+	if file == nil || !isPkgFunc(pass.TypesInfo, call, "math/rand", "Seed") || len(call.Args) != 1 {
+		return nil, nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "randseed") {
+		return nil, nil
+	}
+
+	if !isTimeSeed(pass.TypesInfo, call.Args[0]) {
+		return &analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: "rand.Seed is deprecated: for a reproducible sequence " +
+				"use a local generator from rand.New(rand.NewSource(seed))",
+		}, nil
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: "rand.Seed is deprecated: the global source is seeded automatically since Go 1.20, " +
+			"remove the call, or use rand.New(rand.NewSource(seed)) for a reproducible sequence",
+	}
+
+	// The call can only be removed when it is a statement on its own.
+	if !r.remove || !seedsAutomatically(pass, file) || len(stack) < 2 {
+		return diagnostic, nil
+	}
+
+	stmt, ok := stack[len(stack)-2].(*ast.ExprStmt)
+	if !ok {
+		return diagnostic, nil
+	}
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Remove rand.Seed call",
+		TextEdits: []analysis.TextEdit{stmtEdit(pass, stmt)},
+	}}
+
+	return diagnostic, stmt
+}
+
+// seedsAutomatically reports whether the file is compiled with a Go version
+// whose global source is seeded automatically. Files without version
+// information are assumed to be recent enough.
+func seedsAutomatically(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.20") >= 0
+}
+
+// isTimeSeed checks if the seed is derived from time.Now().
+func isTimeSeed(info *types.Info, seed ast.Expr) bool {
+	found := false
+
+	ast.Inspect(seed, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && isPkgFunc(info, call, "time", "Now") {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+// stmtEdit returns an edit deleting stmt. When stmt is alone on its lines,
+// apart from a trailing line comment, the edit deletes the full lines so that
+// no blank line is left behind; otherwise, as in func() { rand.Seed(s) }, it
+// deletes only the statement.
+func stmtEdit(pass *analysis.Pass, stmt ast.Stmt) analysis.TextEdit {
+	edit := analysis.TextEdit{Pos: stmt.Pos(), End: stmt.End()}
+
+	tokFile := pass.Fset.File(stmt.Pos())
+	if tokFile == nil || pass.ReadFile == nil {
+		return edit
+	}
+
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil || len(src) != tokFile.Size() {
+		return edit
+	}
+
+	start := tokFile.LineStart(tokFile.Line(stmt.Pos()))
+	end := token.Pos(tokFile.Base() + tokFile.Size())
+
+	if endLine := tokFile.Line(stmt.End()); endLine < tokFile.LineCount() {
+		end = tokFile.LineStart(endLine + 1)
+	}
+
+	before := bytes.TrimSpace(src[tokFile.Offset(start):tokFile.Offset(stmt.Pos())])
+	after := bytes.TrimSpace(src[tokFile.Offset(stmt.End()):tokFile.Offset(end)])
+
+	if len(before) != 0 || (len(after) != 0 && !bytes.HasPrefix(after, []byte("//"))) {
+		return edit
+	}
+
+	return analysis.TextEdit{Pos: start, End: end}
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
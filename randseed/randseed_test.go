@@ -0,0 +1,43 @@
+package randseed_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/jaeyeom/godernize/randseed"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, randseed.Analyzer, "a")
+}
+
+func TestRemove(t *testing.T) {
+	if err := randseed.Analyzer.Flags.Set("remove", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = randseed.Analyzer.Flags.Set("remove", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, randseed.Analyzer, "autofix")
+}
+
+// TestRemoveGoVersion checks that calls in modules before Go 1.20, whose
+// global source is not seeded automatically, are not removed.
+func TestRemoveGoVersion(t *testing.T) {
+	if err := randseed.Analyzer.Flags.Set("remove", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = randseed.Analyzer.Flags.Set("remove", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, filepath.Join(testdata, "src", "old"), randseed.Analyzer, "./...")
+}
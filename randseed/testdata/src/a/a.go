@@ -0,0 +1,26 @@
+package a
+
+import (
+	"math/rand"
+	"time"
+)
+
+func timeSeed() {
+	rand.Seed(time.Now().UnixNano()) // want `rand.Seed is deprecated: the global source is seeded automatically since Go 1.20, remove the call, or use rand.New\(rand.NewSource\(seed\)\) for a reproducible sequence`
+}
+
+func explicitSeed(seed int64) {
+	rand.Seed(seed) // want `rand.Seed is deprecated: for a reproducible sequence use a local generator from rand.New\(rand.NewSource\(seed\)\)`
+	rand.Seed(42)   // want `rand.Seed is deprecated: for a reproducible sequence use a local generator`
+}
+
+func localSource() {
+	r := rand.New(rand.NewSource(42))
+	r.Seed(7)
+	_ = r.Intn(10)
+}
+
+//godernize:ignore=randseed
+func ignored() {
+	rand.Seed(time.Now().Unix())
+}
@@ -0,0 +1,12 @@
+package autofix
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+func dropTime() {
+	rand.Seed(time.Now().UnixNano()) // want `rand.Seed is deprecated: the global source is seeded automatically`
+	fmt.Println(rand.Intn(10))
+}
@@ -0,0 +1,15 @@
+package autofix
+
+import (
+	"math/rand"
+	"time"
+)
+
+func keepTime(seed int64) time.Duration {
+	start := time.Now()
+	rand.Seed(start.UnixNano())  // want `rand.Seed is deprecated: for a reproducible sequence`
+	rand.Seed(time.Now().Unix()) // want `rand.Seed is deprecated: the global source is seeded automatically`
+	rand.Seed(seed)              // want `rand.Seed is deprecated: for a reproducible sequence`
+
+	return time.Since(start)
+}
@@ -0,0 +1,13 @@
+package autofix
+
+import (
+	"math/rand"
+	"time"
+)
+
+func seedTwice() {
+	rand.Seed(time.Now().UnixNano()) // want `rand.Seed is deprecated: the global source is seeded automatically`
+	rand.Seed(time.Now().Unix())     // want `rand.Seed is deprecated: the global source is seeded automatically`
+}
+
+func seedInline() { rand.Seed(time.Now().UnixNano()) } // want `rand.Seed is deprecated: the global source is seeded automatically`
@@ -0,0 +1,11 @@
+package old
+
+import (
+	"math/rand"
+	"time"
+)
+
+// The global source is not seeded automatically before Go 1.20.
+func seed() {
+	rand.Seed(time.Now().UnixNano()) // want `rand.Seed is deprecated: the global source is seeded automatically`
+}
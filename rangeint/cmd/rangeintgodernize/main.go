@@ -0,0 +1,12 @@
+// Command rangeintgodernize runs the rangeint analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/rangeint"
+)
+
+func main() {
+	singlechecker.Main(rangeint.Analyzer)
+}
@@ -0,0 +1,489 @@
+// Package rangeint provides an analyzer to detect classic counting loops that
+// can range over an integer.
+package rangeint
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for classic counting loops that can range over an integer
+
+This analyzer reports three-clause loops of the form
+	for i := 0; i < n; i++ { ... }
+and suggests the Go 1.22 form
+	for i := range n { ... }
+The loop variable must not be modified in the body. A fix is offered only when
+n is a literal, a constant, or a local variable that the body does not modify
+and whose address is not taken nor captured by a function literal, because
+range evaluates n once; loops bounded by package-level variables, calls, or
+other expressions are reported without a fix.`
+
+// Analyzer is the main analyzer for classic counting loops.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "rangeint",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/rangeint",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.ForStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		stmt, ok := n.(*ast.ForStmt)
+		if !ok || stmt == nil {
+			return
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if diagnostic := diagnoseForStmt(pass, file, stmt); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseForStmt(pass *analysis.Pass, file *ast.File, stmt *ast.ForStmt) *analysis.Diagnostic {
+	if file == nil || !supportsRangeInt(pass, file) {
+		return nil
+	}
+
+	index, limit := matchCountingLoop(pass, stmt)
+	if index == nil || limit == nil {
+		return nil
+	}
+
+	indexObj := pass.TypesInfo.Defs[index]
+	if indexObj == nil || isModified(pass.TypesInfo, stmt.Body, indexObj) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, stmt, "rangeint") {
+		return nil
+	}
+
+	return createDiagnostic(pass, file, stmt, index, limit, isUsed(pass.TypesInfo, stmt.Body, indexObj))
+}
+
+// supportsRangeInt reports whether the file is compiled with a Go version that
+// allows ranging over integers. Files without version information are assumed
+// to be recent enough.
+func supportsRangeInt(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.22") >= 0
+}
+
+// matchCountingLoop matches "for i := 0; i < n; i++" and returns i and n.
+func matchCountingLoop(pass *analysis.Pass, stmt *ast.ForStmt) (index *ast.Ident, limit ast.Expr) {
+	if stmt == nil || stmt.Init == nil || stmt.Cond == nil || stmt.Post == nil {
+		return nil, nil
+	}
+
+	init, ok := stmt.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+		return nil, nil
+	}
+
+	index, ok = init.Lhs[0].(*ast.Ident)
+	if !ok || index.Name == "_" || !isZeroLiteral(init.Rhs[0]) {
+		return nil, nil
+	}
+
+	cond, ok := stmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.LSS || !isIdentNamed(cond.X, index.Name) {
+		return nil, nil
+	}
+
+	post, ok := stmt.Post.(*ast.IncDecStmt)
+	if !ok || post.Tok != token.INC || !isIdentNamed(post.X, index.Name) {
+		return nil, nil
+	}
+
+	if !isInteger(pass.TypesInfo.TypeOf(cond.Y)) {
+		return nil, nil
+	}
+
+	return index, cond.Y
+}
+
+func isZeroLiteral(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+
+	return ok && lit.Kind == token.INT && lit.Value == "0"
+}
+
+func isIdentNamed(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == name
+}
+
+func isInteger(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsInteger != 0
+}
+
+// refersTo checks if expr is an identifier referring to obj.
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && info.Uses[ident] == obj
+}
+
+// isModified checks if obj is assigned, incremented, or has its address taken
+// within node.
+func isModified(info *types.Info, node ast.Node, obj types.Object) bool {
+	modified := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range stmt.Lhs {
+				if refersTo(info, lhs, obj) {
+					modified = true
+				}
+			}
+		case *ast.IncDecStmt:
+			modified = modified || refersTo(info, stmt.X, obj)
+		case *ast.RangeStmt:
+			modified = modified || stmt.Tok == token.ASSIGN &&
+				(refersTo(info, stmt.Key, obj) || refersTo(info, stmt.Value, obj))
+		case *ast.UnaryExpr:
+			modified = modified || stmt.Op == token.AND && refersTo(info, stmt.X, obj)
+		}
+
+		return !modified
+	})
+
+	return modified
+}
+
+// isUsed checks if obj is referenced anywhere within node.
+func isUsed(info *types.Info, node ast.Node, obj types.Object) bool {
+	used := false
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == obj {
+			used = true
+		}
+
+		return !used
+	})
+
+	return used
+}
+
+// isFixable checks if the loop limit can be evaluated once without changing
+// behavior: it must be a literal, a constant, or a local variable that only
+// the function declaring it can change and that the body leaves alone.
+func isFixable(info *types.Info, file *ast.File, body *ast.BlockStmt, limit ast.Expr) bool {
+	switch expr := ast.Unparen(limit).(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		obj := info.Uses[expr]
+		if _, isConst := obj.(*types.Const); isConst {
+			return true
+		}
+
+		v, isVar := obj.(*types.Var)
+
+		return isVar && isLocal(v) && !isModified(info, body, v) && !isShared(info, file, v)
+	}
+
+	return false
+}
+
+// isLocal checks if v is a local variable or parameter rather than a
+// package-level variable, which called functions may change.
+func isLocal(v *types.Var) bool {
+	return v.Pkg() != nil && v.Parent() != nil && v.Parent() != v.Pkg().Scope()
+}
+
+// isShared checks if the local variable v may be changed other than by
+// assignments in its function: when its address is taken or a function
+// literal captures it. Calls of pointer methods, which take the address
+// implicitly, need no check: the limit has the type int of the index.
+func isShared(info *types.Info, file *ast.File, v *types.Var) bool {
+	var decl *ast.FuncDecl
+
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Pos() <= v.Pos() && v.Pos() < fn.End() {
+			decl = fn
+		}
+	}
+
+	if decl == nil {
+		return true
+	}
+
+	shared := false
+
+	var funcLits []*ast.FuncLit
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			funcLits = append(funcLits, node)
+		case *ast.UnaryExpr:
+			shared = shared || node.Op == token.AND && refersTo(info, node.X, v)
+		case *ast.Ident:
+			shared = shared || info.Uses[node] == v && capturedBy(funcLits, node, v)
+		}
+
+		return !shared
+	})
+
+	return shared
+}
+
+// capturedBy checks if the use of v at ident is within one of funcLits that
+// v is declared outside of.
+func capturedBy(funcLits []*ast.FuncLit, ident *ast.Ident, v *types.Var) bool {
+	for _, lit := range funcLits {
+		inside := lit.Pos() <= ident.Pos() && ident.Pos() < lit.End()
+		declaredInside := lit.Pos() <= v.Pos() && v.Pos() < lit.End()
+
+		if inside && !declaredInside {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasIndexType checks if ranging over limit gives the index the type it has
+// in the loop, int from its 0 initializer. Range gives an untyped constant
+// limit its default type: range 'a' makes the index a rune, and range 1e3
+// does not compile.
+func hasIndexType(info *types.Info, index *ast.Ident, limit ast.Expr) bool {
+	indexObj := info.Defs[index]
+	if indexObj == nil {
+		return false
+	}
+
+	// The recorded type of an untyped constant is the one it is converted
+	// to by the comparison, so the default type is found from the source.
+	if defaultType, untyped := untypedDefault(info, limit); untyped {
+		return defaultType != nil && types.Identical(defaultType, indexObj.Type())
+	}
+
+	return types.Identical(info.TypeOf(limit), indexObj.Type())
+}
+
+// untypedDefault returns the default type of expr if it is an untyped
+// constant expression, or nil if expr is untyped but not numeric.
+func untypedDefault(info *types.Info, expr ast.Expr) (types.Type, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		return literalDefaults[e.Kind], true
+	case *ast.Ident:
+		c, ok := info.Uses[e].(*types.Const)
+		if !ok {
+			return nil, false
+		}
+
+		if basic, isBasic := c.Type().(*types.Basic); isBasic && basic.Info()&types.IsUntyped != 0 {
+			return types.Default(basic), true
+		}
+	case *ast.UnaryExpr:
+		return untypedDefault(info, e.X)
+	case *ast.BinaryExpr:
+		x, xUntyped := untypedDefault(info, e.X)
+		if e.Op == token.SHL || e.Op == token.SHR {
+			return x, xUntyped
+		}
+
+		y, yUntyped := untypedDefault(info, e.Y)
+		if !xUntyped || !yUntyped {
+			return nil, false
+		}
+
+		// The operand of the later kind in int, rune, float64, complex128
+		// decides the kind of the result.
+		if slices.Index(numericDefaults, y) > slices.Index(numericDefaults, x) {
+			return y, true
+		}
+
+		return x, true
+	}
+
+	return nil, false
+}
+
+//nolint:gochecknoglobals // static tables of default types
+var (
+	// literalDefaults are the default types of the numeric literals.
+	literalDefaults = map[token.Token]types.Type{
+		token.INT:   types.Typ[types.Int],
+		token.CHAR:  types.Universe.Lookup("rune").Type(),
+		token.FLOAT: types.Typ[types.Float64],
+		token.IMAG:  types.Typ[types.Complex128],
+	}
+	// numericDefaults are the default types of numeric constants in the
+	// order of their kinds.
+	numericDefaults = []types.Type{
+		types.Typ[types.Int], types.Universe.Lookup("rune").Type(), types.Typ[types.Float64], types.Typ[types.Complex128],
+	}
+)
+
+func createDiagnostic(
+	pass *analysis.Pass, file *ast.File, stmt *ast.ForStmt, index *ast.Ident, limit ast.Expr, indexUsed bool,
+) *analysis.Diagnostic {
+	limitText := formatNode(pass.Fset, limit)
+	if limitText == "" {
+		return nil
+	}
+
+	header := "range " + limitText
+	if indexUsed {
+		header = index.Name + " := " + header
+	}
+
+	if !hasIndexType(pass.TypesInfo, index, limit) {
+		return &analysis.Diagnostic{
+			Pos: stmt.Pos(),
+			End: stmt.Body.Lbrace,
+			Message: fmt.Sprintf("for loop can range over an integer, but ranging over %s "+
+				"would not give %s its type int; convert the limit with int(%s)", limitText, index.Name, limitText),
+		}
+	}
+
+	if !isFixable(pass.TypesInfo, file, stmt.Body, limit) {
+		return &analysis.Diagnostic{
+			Pos: stmt.Pos(),
+			End: stmt.Body.Lbrace,
+			Message: fmt.Sprintf("for loop can be modernized to 'for %s', "+
+				"but %s is evaluated only once by range; verify it has no side effects", header, limitText),
+		}
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     stmt.Pos(),
+		End:     stmt.Body.Lbrace,
+		Message: fmt.Sprintf("for loop can be modernized to 'for %s'", header),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with range over int",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     stmt.Init.Pos(),
+				End:     stmt.Post.End(),
+				NewText: []byte(header),
+			}},
+		}},
+	}
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,85 @@
+package a
+
+import "fmt"
+
+const size = 3
+
+func identLimit(n int) {
+	for i := 0; i < n; i++ { // want "for loop can be modernized to 'for i := range n'"
+		fmt.Println(i)
+	}
+}
+
+func literalLimit() {
+	for i := 0; i < 10; i++ { // want "for loop can be modernized to 'for i := range 10'"
+		fmt.Println(i)
+	}
+}
+
+func constLimit() {
+	for i := 0; i < size; i++ { // want "for loop can be modernized to 'for i := range size'"
+		fmt.Println(i)
+	}
+}
+
+func unusedIndex(n int) {
+	for i := 0; i < n; i++ { // want "for loop can be modernized to 'for range n'"
+		fmt.Println("tick")
+	}
+}
+
+func callLimit(items []string) {
+	for i := 0; i < len(items); i++ { // want "for loop can be modernized to 'for i := range len\\(items\\)', but len\\(items\\) is evaluated only once by range; verify it has no side effects"
+		fmt.Println(items[i])
+	}
+}
+
+func modifiedLimit(n int) {
+	for i := 0; i < n; i++ { // want "for loop can be modernized to 'for i := range n', but n is evaluated only once by range"
+		if i == 2 {
+			n--
+		}
+	}
+}
+
+func modifiedIndex(n int) {
+	for i := 0; i < n; i++ {
+		if i == 2 {
+			i++
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		i += 2
+	}
+
+	for i := 0; i < n; i++ {
+		p := &i
+		_ = p
+	}
+}
+
+func notCountingLoop(n int) {
+	for i := 1; i < n; i++ {
+		fmt.Println(i)
+	}
+
+	for i := 0; i <= n; i++ {
+		fmt.Println(i)
+	}
+
+	for i := 0; i < n; i += 2 {
+		fmt.Println(i)
+	}
+
+	for i := n; i > 0; i-- {
+		fmt.Println(i)
+	}
+}
+
+//godernize:ignore=rangeint
+func ignored(n int) {
+	for i := 0; i < n; i++ {
+		fmt.Println(i)
+	}
+}
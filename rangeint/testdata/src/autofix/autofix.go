@@ -0,0 +1,77 @@
+package autofix
+
+import "fmt"
+
+func rangeOverInt(n int) {
+	for i := 0; i < n; i++ { // want "for loop can be modernized to 'for i := range n'"
+		fmt.Println(i)
+	}
+
+	for i := 0; i < 3; i++ { // want "for loop can be modernized to 'for range 3'"
+		fmt.Println("retry")
+	}
+
+	for i := 0; i < n; i++ { // want "for loop can be modernized to 'for i := range n'"
+		for j := 0; j < i; j++ { // want "for loop can be modernized to 'for j := range i'"
+			fmt.Println(i, j)
+		}
+	}
+}
+
+func callLimit(items []string) {
+	for i := 0; i < len(items); i++ { // want "for loop can be modernized to 'for i := range len\\(items\\)'"
+		fmt.Println(items[i])
+	}
+}
+
+func useInt(i int) {
+	fmt.Println(i)
+}
+
+// Ranging over these limits would change the type of i or not compile.
+func untypedLimits() {
+	for i := 0; i < 1e3; i++ { // want `for loop can range over an integer, but ranging over 1e3 would not give i its type int; convert the limit with int\(1e3\)`
+		useInt(i)
+	}
+
+	for i := 0; i < 'a'; i++ { // want `ranging over 'a' would not give i its type int`
+		useInt(i)
+	}
+
+	const limit = 'z' - 'a'
+
+	for i := 0; i < limit; i++ { // want `ranging over limit would not give i its type int`
+		useInt(i)
+	}
+}
+
+func untypedIntLimit() {
+	const shifted = 1 << 4
+
+	for i := 0; i < shifted; i++ { // want "for loop can be modernized to 'for i := range shifted'"
+		useInt(i)
+	}
+}
+
+var limit = 3
+
+func bump() { limit++ }
+
+// Range would not see the changes to these limits made during the loop.
+func sharedLimits(n int) {
+	for i := 0; i < limit; i++ { // want `for loop can be modernized to 'for range limit', but limit is evaluated only once by range`
+		bump()
+	}
+
+	p := &n
+	for i := 0; i < n; i++ { // want `for loop can be modernized to 'for range n', but n is evaluated only once by range`
+		*p = 0
+	}
+
+	m := 3
+	shrink := func() { m-- }
+
+	for i := 0; i < m; i++ { // want `for loop can be modernized to 'for range m', but m is evaluated only once by range`
+		shrink()
+	}
+}
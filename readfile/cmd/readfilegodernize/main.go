@@ -0,0 +1,12 @@
+// Command readfilegodernize runs the readfile analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/readfile"
+)
+
+func main() {
+	singlechecker.Main(readfile.Analyzer)
+}
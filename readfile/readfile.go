@@ -0,0 +1,434 @@
+// Package readfile provides an analyzer to detect files opened only to be read
+// entirely, which os.ReadFile does in one call.
+package readfile
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for files opened only to be read entirely
+
+This analyzer reports an os.Open call whose file is read entirely with
+io.ReadAll and closed by the statements right after it, and suggests
+os.ReadFile:
+- f, _ := os.Open(p); data, err := io.ReadAll(f); f.Close() ->
+  data, err := os.ReadFile(p)
+
+The close may also be deferred right after the open. A fix is offered when
+the error of os.Open is discarded; otherwise os.ReadFile reports it in place
+of the read error, so the diagnostic is reported alone. Files built for Go
+versions before 1.16 are skipped.`
+
+// Analyzer is the main analyzer for open, read all, and close sequences.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "readfile",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/readfile",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single import adjustment.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]fileDiagnostic)
+	)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var stmts []ast.Stmt
+
+		switch node := n.(type) {
+		case *ast.BlockStmt:
+			stmts = node.List
+		case *ast.CaseClause:
+			stmts = node.Body
+		case *ast.CommClause:
+			stmts = node.Body
+		}
+
+		file := fileMap.File(n.Pos())
+		if file == nil || !supportsReadFile(pass, file) {
+			return
+		}
+
+		for i := range stmts {
+			f, ok := diagnoseStmts(pass, file, stmts[i:])
+			if !ok {
+				continue
+			}
+
+			if _, seen := found[file]; !seen {
+				files = append(files, file)
+			}
+
+			found[file] = append(found[file], f)
+		}
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// fileDiagnostic is a diagnostic with the statements its fix replaces.
+type fileDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	stmts      []ast.Stmt
+}
+
+// consolidateFixes adds one adjustment of the imports to the fixes in file:
+// io and io/ioutil are removed when the replaced statements held their last
+// references. With several fixes, only the first carries the rewrites of all
+// of them, so that applying every fix of the file does not apply the same
+// import edits twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []fileDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+
+		for _, stmt := range f.stmts {
+			replaced = append(replaced, stmt)
+		}
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	var remove []string
+
+	for _, importPath := range []string{"io", "io/ioutil"} {
+		if importutil.Name(file, importPath) != "" && !importutil.UsedOutside(pass.TypesInfo, file, importPath, replaced...) {
+			remove = append(remove, importPath)
+		}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, nil, remove)...)
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// diagnoseStmts checks if stmts starts with an os.Open call followed by
+// reading the whole file and closing it, and returns the diagnostic.
+func diagnoseStmts(pass *analysis.Pass, file *ast.File, stmts []ast.Stmt) (fileDiagnostic, bool) {
+	open, ok := stmts[0].(*ast.AssignStmt)
+	if !ok || open.Tok != token.DEFINE || len(open.Lhs) != 2 || len(open.Rhs) != 1 {
+		return fileDiagnostic{}, false
+	}
+
+	call, ok := open.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isPkgFunc(pass.TypesInfo, call, "os", "Open") {
+		return fileDiagnostic{}, false
+	}
+
+	fileIdent, ok := open.Lhs[0].(*ast.Ident)
+	if !ok {
+		return fileDiagnostic{}, false
+	}
+
+	fileVar := pass.TypesInfo.Defs[fileIdent]
+	if fileVar == nil {
+		return fileDiagnostic{}, false
+	}
+
+	read, n := matchReadAndClose(pass.TypesInfo, stmts[1:], fileVar)
+	if read == nil || usedAfter(pass.TypesInfo, stmts[1+n:], fileVar) || shouldIgnore(pass.Fset, file, open, "readfile") {
+		return fileDiagnostic{}, false
+	}
+
+	replaced := stmts[:1+n]
+	readFile := "ReadFile" // dot import
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+		readFile = formatNode(pass.Fset, sel.X) + ".ReadFile"
+	}
+
+	replacement := fmt.Sprintf("%s, %s %s %s(%s)", formatNode(pass.Fset, read.Lhs[0]), formatNode(pass.Fset, read.Lhs[1]),
+		read.Tok, readFile, formatNode(pass.Fset, call.Args[0]))
+
+	diagnostic := analysis.Diagnostic{
+		Pos: open.Pos(),
+		End: replaced[len(replaced)-1].End(),
+		Message: fmt.Sprintf("file opened only to be read entirely can be read with os.ReadFile(%s)",
+			formatNode(pass.Fset, call.Args[0])),
+	}
+
+	// os.ReadFile reports the open error in place of the read error, which
+	// only preserves the behavior when the open error is discarded.
+	if !isBlank(open.Lhs[1]) {
+		return fileDiagnostic{diagnostic: diagnostic, stmts: replaced}, true
+	}
+
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace with " + readFile,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     open.Pos(),
+			End:     diagnostic.End,
+			NewText: []byte(replacement),
+		}},
+	}}
+
+	return fileDiagnostic{diagnostic: diagnostic, stmts: replaced}, true
+}
+
+// matchReadAndClose checks if stmts starts with reading the whole file and
+// closing it, in either order when the close is deferred. It returns the read
+// statement and the number of statements matched.
+func matchReadAndClose(info *types.Info, stmts []ast.Stmt, fileVar types.Object) (*ast.AssignStmt, int) {
+	if len(stmts) < 2 {
+		return nil, 0
+	}
+
+	if read := readAll(info, stmts[0], fileVar); read != nil && isClose(info, stmts[1], fileVar, false) {
+		return read, 2
+	}
+
+	if read := readAll(info, stmts[1], fileVar); read != nil && isClose(info, stmts[0], fileVar, true) {
+		return read, 2
+	}
+
+	return nil, 0
+}
+
+// readAll returns stmt if it is data, err := io.ReadAll(f), or the
+// io/ioutil variant, with f the file.
+func readAll(info *types.Info, stmt ast.Stmt, fileVar types.Object) *ast.AssignStmt {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return nil
+	}
+
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !refersTo(info, call.Args[0], fileVar) {
+		return nil
+	}
+
+	if !isPkgFunc(info, call, "io", "ReadAll") && !isPkgFunc(info, call, "io/ioutil", "ReadAll") {
+		return nil
+	}
+
+	return assign
+}
+
+// isClose checks if stmt is f.Close() or _ = f.Close(), or defer f.Close()
+// when deferred is set.
+func isClose(info *types.Info, stmt ast.Stmt, fileVar types.Object, deferred bool) bool {
+	var call ast.Expr
+
+	switch s := stmt.(type) {
+	case *ast.DeferStmt:
+		if !deferred {
+			return false
+		}
+
+		call = s.Call
+	case *ast.ExprStmt:
+		call = s.X
+	case *ast.AssignStmt:
+		if len(s.Lhs) != 1 || len(s.Rhs) != 1 || !isBlank(s.Lhs[0]) {
+			return false
+		}
+
+		call = s.Rhs[0]
+	}
+
+	closeCall, ok := call.(*ast.CallExpr)
+	if !ok || len(closeCall.Args) != 0 {
+		return false
+	}
+
+	sel, ok := closeCall.Fun.(*ast.SelectorExpr)
+
+	return ok && sel.Sel.Name == "Close" && refersTo(info, sel.X, fileVar)
+}
+
+// usedAfter checks if fileVar is referenced within stmts.
+func usedAfter(info *types.Info, stmts []ast.Stmt, fileVar types.Object) bool {
+	used := false
+
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == fileVar {
+				used = true
+			}
+
+			return !used
+		})
+	}
+
+	return used
+}
+
+// supportsReadFile reports whether the file is compiled with a Go version
+// that has os.ReadFile. Files without version information are assumed to be
+// recent enough.
+func supportsReadFile(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.16") >= 0
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && info.Uses[ident] == obj
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && ident.Name == "_"
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
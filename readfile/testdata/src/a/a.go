@@ -0,0 +1,78 @@
+package a
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+func canonical(path string) ([]byte, error) {
+	f, _ := os.Open(path) // want `file opened only to be read entirely can be read with os.ReadFile\(path\)`
+	data, err := io.ReadAll(f)
+	f.Close()
+
+	return data, err
+}
+
+func deferred(path string) ([]byte, error) {
+	f, _ := os.Open(path) // want `file opened only to be read entirely can be read with os.ReadFile\(path\)`
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+
+	return data, err
+}
+
+func openErrorKept(path string) ([]byte, error) {
+	f, err := os.Open(path) // want `file opened only to be read entirely`
+	data, err := io.ReadAll(f)
+	_ = f.Close()
+
+	return data, err
+}
+
+// Reading line by line streams the file.
+func streaming(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
+
+// The file is used after it is read.
+func usedAfter(path string) (os.FileInfo, []byte) {
+	f, _ := os.Open(path)
+	data, _ := io.ReadAll(f)
+	f.Close()
+
+	info, _ := f.Stat()
+
+	return info, data
+}
+
+// The file is not closed right after it is read.
+func notClosed(path string) []byte {
+	f, _ := os.Open(path)
+	data, _ := io.ReadAll(f)
+
+	return data
+}
+
+func ignored(path string) []byte {
+	//godernize:ignore=readfile
+	f, _ := os.Open(path)
+	data, _ := io.ReadAll(f)
+	f.Close()
+
+	return data
+}
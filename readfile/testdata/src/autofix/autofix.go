@@ -0,0 +1,26 @@
+package autofix
+
+import (
+	"io"
+	"os"
+)
+
+func load(path string) ([]byte, error) {
+	f, _ := os.Open(path) // want `file opened only to be read entirely`
+	data, err := io.ReadAll(f)
+	f.Close()
+
+	return data, err
+}
+
+func loadBoth(a, b string) (x, y []byte) {
+	f, _ := os.Open(a) // want `file opened only to be read entirely`
+	defer f.Close()
+	x, _ = io.ReadAll(f)
+
+	g, _ := os.Open(b) // want `file opened only to be read entirely`
+	y, _ = io.ReadAll(g)
+	_ = g.Close()
+
+	return x, y
+}
@@ -0,0 +1,15 @@
+package old
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+// Modules before Go 1.16 lack os.ReadFile.
+func read(path string) ([]byte, error) {
+	f, _ := os.Open(path)
+	data, err := ioutil.ReadAll(f)
+	f.Close()
+
+	return data, err
+}
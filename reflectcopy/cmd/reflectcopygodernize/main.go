@@ -0,0 +1,12 @@
+// Command reflectcopygodernize runs the reflectcopy analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/reflectcopy"
+)
+
+func main() {
+	singlechecker.Main(reflectcopy.Analyzer)
+}
@@ -0,0 +1,261 @@
+// Package reflectcopy provides an analyzer to detect structs copied through
+// reflection where a plain assignment suffices.
+package reflectcopy
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const reflectPath = "reflect"
+
+// Doc describes what this analyzer does.
+const Doc = `check for structs copied through reflection
+
+This analyzer reports the reflection idiom copying one struct into another of
+the same type through pointers to them:
+- reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem()) -> *dst = *src
+
+The assignment is checked by the compiler and does not allocate. No fix is
+offered.`
+
+// Analyzer is the main analyzer for reflection-based struct copies.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "reflectcopy",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/reflectcopy",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 1 || !isValueMethod(pass.TypesInfo, sel, "Set") {
+		return nil
+	}
+
+	dst := pointeeOf(pass.TypesInfo, sel.X)
+	src := pointeeOf(pass.TypesInfo, call.Args[0])
+
+	if dst == nil || src == nil || shouldIgnore(pass.Fset, file, call, "reflectcopy") {
+		return nil
+	}
+
+	dstType := structPointer(pass.TypesInfo, dst)
+	if dstType == nil || !types.Identical(dstType, pass.TypesInfo.TypeOf(src)) {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("struct copy through reflection can be replaced with the assignment %s = %s",
+			dereference(pass.Fset, dst), dereference(pass.Fset, src)),
+	}
+}
+
+// pointeeOf returns p if expr is reflect.ValueOf(p).Elem().
+func pointeeOf(info *types.Info, expr ast.Expr) ast.Expr {
+	elem, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(elem.Args) != 0 {
+		return nil
+	}
+
+	sel, ok := ast.Unparen(elem.Fun).(*ast.SelectorExpr)
+	if !ok || !isValueMethod(info, sel, "Elem") {
+		return nil
+	}
+
+	valueOf, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+	if !ok || len(valueOf.Args) != 1 || !isPkgFunc(info, valueOf, reflectPath, "ValueOf") {
+		return nil
+	}
+
+	return valueOf.Args[0]
+}
+
+// structPointer returns the type of expr if it is a pointer to a struct.
+func structPointer(info *types.Info, expr ast.Expr) types.Type {
+	typ := info.TypeOf(expr)
+	if typ == nil {
+		return nil
+	}
+
+	ptr, ok := typ.Underlying().(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := ptr.Elem().Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	return typ
+}
+
+// isValueMethod checks if sel selects the method name of reflect.Value.
+func isValueMethod(info *types.Info, sel *ast.SelectorExpr, name string) bool {
+	if sel.Sel.Name != name {
+		return false
+	}
+
+	method, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || method.Pkg() == nil || method.Pkg().Path() != reflectPath {
+		return false
+	}
+
+	sig, ok := method.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+
+	named, ok := sig.Recv().Type().(*types.Named)
+
+	return ok && named.Obj().Name() == "Value"
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+// dereference renders the value pointed to by expr, turning &x back into x.
+func dereference(fset *token.FileSet, expr ast.Expr) string {
+	if unary, ok := ast.Unparen(expr).(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return formatNode(fset, unary.X)
+	}
+
+	return "*" + formatNode(fset, expr)
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,52 @@
+package a
+
+import (
+	"reflect"
+
+	fake "fake/reflect"
+)
+
+type config struct {
+	name string
+	port int
+}
+
+type other struct {
+	name string
+	port int
+}
+
+type holder struct {
+	cfg *config
+}
+
+func copies(dst, src *config, h *holder) {
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem()) // want `struct copy through reflection can be replaced with the assignment \*dst = \*src`
+
+	var local config
+	reflect.ValueOf(&local).Elem().Set(reflect.ValueOf(src).Elem()) // want `struct copy through reflection can be replaced with the assignment local = \*src`
+
+	reflect.ValueOf(h.cfg).Elem().Set((reflect.ValueOf(dst)).Elem()) // want `struct copy through reflection can be replaced with the assignment \*h.cfg = \*dst`
+}
+
+func untouched(dst *config, src *other, n, m *int, anyDst any, v reflect.Value) {
+	// The types differ.
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+
+	// Not structs.
+	reflect.ValueOf(n).Elem().Set(reflect.ValueOf(m).Elem())
+
+	// The static type is not a pointer.
+	reflect.ValueOf(anyDst).Elem().Set(reflect.ValueOf(dst).Elem())
+
+	// The source is not a pointee.
+	reflect.ValueOf(dst).Elem().Set(v)
+
+	// Not the reflect package.
+	fake.ValueOf(dst).Elem().Set(fake.ValueOf(dst).Elem())
+}
+
+func ignored(dst, src *config) {
+	//godernize:ignore=reflectcopy
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+}
@@ -0,0 +1,12 @@
+// Command removeallgodernize runs the removeall analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/removeall"
+)
+
+func main() {
+	singlechecker.Main(removeall.Analyzer)
+}
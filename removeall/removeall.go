@@ -0,0 +1,261 @@
+// Package removeall provides an analyzer to detect directory trees deleted
+// entry by entry with os.Remove.
+package removeall
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const (
+	osPath       = "os"
+	filepathPath = "path/filepath"
+	fsPath       = "io/fs"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for manual recursive deletion with os.Remove
+
+This analyzer reports filepath.Walk and filepath.WalkDir callbacks, and loops
+over the entries returned by os.ReadDir, that do nothing but call os.Remove:
+- filepath.Walk(dir, func(path string, _ os.FileInfo, _ error) error { return os.Remove(path) }) -> os.RemoveAll(dir)
+- for _, e := range entries { os.Remove(filepath.Join(dir, e.Name())) } -> os.RemoveAll(dir)
+
+os.RemoveAll removes a tree in one call, children before their directory,
+and ignores paths that do not exist. A walk removing parents before their
+children fails on non-empty directories. The analyzer reports diagnostics
+only, since whether the root itself should go away is up to the caller.`
+
+// Analyzer is the main analyzer for manual recursive deletion.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "removeall",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/removeall",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.RangeStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		file := fileMap.File(n.Pos())
+
+		var diagnostic *analysis.Diagnostic
+
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			diagnostic = diagnoseCallExpr(pass, file, node)
+		case *ast.RangeStmt:
+			diagnostic = diagnoseRangeStmt(pass, file, node)
+		}
+
+		if diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+// diagnoseCallExpr reports filepath.Walk and filepath.WalkDir calls whose
+// callback is a function literal that only calls os.Remove.
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) != 2 {
+		return nil
+	}
+
+	name := ""
+
+	for _, walk := range []string{"Walk", "WalkDir"} {
+		if isPkgFunc(pass.TypesInfo, call, filepathPath, walk) {
+			name = walk
+		}
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	callback, ok := ast.Unparen(call.Args[1]).(*ast.FuncLit)
+	if !ok || !onlyRemoves(pass.TypesInfo, callback.Body) || shouldIgnore(pass.Fset, file, call, "removeall") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: "filepath." + name + " callback only calls os.Remove, " +
+			"use os.RemoveAll to remove the tree in one call",
+	}
+}
+
+// diagnoseRangeStmt reports loops over directory entries, such as those
+// returned by os.ReadDir, whose body only calls os.Remove.
+func diagnoseRangeStmt(pass *analysis.Pass, file *ast.File, stmt *ast.RangeStmt) *analysis.Diagnostic {
+	if file == nil || !isDirEntries(pass.TypesInfo.TypeOf(stmt.X)) || !onlyRemoves(pass.TypesInfo, stmt.Body) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, stmt, "removeall") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: stmt.Pos(),
+		End: stmt.Body.Lbrace,
+		Message: "loop over directory entries only calls os.Remove, " +
+			"use os.RemoveAll to remove the directory and its contents in one call",
+	}
+}
+
+// isDirEntries checks if typ is a slice of fs.DirEntry, which os.DirEntry
+// aliases.
+func isDirEntries(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	named, ok := types.Unalias(slice.Elem()).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == fsPath && obj.Name() == "DirEntry"
+}
+
+// onlyRemoves checks if body calls os.Remove at least once and makes no
+// other calls, except in the arguments of os.Remove, where the path is built.
+func onlyRemoves(info *types.Info, body *ast.BlockStmt) bool {
+	if body == nil {
+		return false
+	}
+
+	removes, others := 0, 0
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		if isPkgFunc(info, call, osPath, "Remove") {
+			removes++
+		} else {
+			others++
+		}
+
+		// Calls in the arguments are not counted.
+		return false
+	})
+
+	return removes > 0 && others == 0
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
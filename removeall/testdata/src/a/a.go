@@ -0,0 +1,55 @@
+package a
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+func walk(dir string) error {
+	return filepath.Walk(dir, func(path string, _ os.FileInfo, err error) error { // want `filepath.Walk callback only calls os.Remove, use os.RemoveAll to remove the tree in one call`
+		if err != nil {
+			return err
+		}
+
+		return os.Remove(path)
+	})
+}
+
+func walkDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, _ fs.DirEntry, _ error) error { // want `filepath.WalkDir callback only calls os.Remove`
+		return os.Remove(path)
+	})
+}
+
+func readDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries { // want `loop over directory entries only calls os.Remove, use os.RemoveAll to remove the directory and its contents in one call`
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readDirInline(dir string) {
+	for _, entry := range must(os.ReadDir(dir)) { // want `loop over directory entries only calls os.Remove`
+		os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
+
+func ignored(dir string) {
+	//godernize:ignore=removeall
+	filepath.Walk(dir, func(path string, _ os.FileInfo, _ error) error {
+		return os.Remove(path)
+	})
+}
+
+func must(entries []os.DirEntry, _ error) []os.DirEntry {
+	return entries
+}
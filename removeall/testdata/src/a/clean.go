@@ -0,0 +1,59 @@
+package a
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Only some entries are removed.
+func removeFiles(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		return os.Remove(path)
+	})
+}
+
+func removeMatching(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return nil
+}
+
+// The loop does more than remove.
+func removeLogged(dir string) {
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		fmt.Println("removing", entry.Name())
+		os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
+
+// Not directory entries.
+func removePaths(paths []string) {
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
+
+// The callback is not a function literal.
+func walkNamed(dir string) error {
+	return filepath.Walk(dir, remove)
+}
+
+func remove(path string, _ os.FileInfo, _ error) error {
+	return os.Remove(path)
+}
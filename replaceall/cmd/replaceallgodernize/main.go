@@ -0,0 +1,12 @@
+// Command replaceallgodernize runs the replaceall analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/replaceall"
+)
+
+func main() {
+	singlechecker.Main(replaceall.Analyzer)
+}
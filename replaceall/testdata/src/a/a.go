@@ -0,0 +1,29 @@
+package a
+
+import (
+	"bytes"
+	"strings"
+	str "strings"
+)
+
+const all = -1
+
+func replace(s string, b []byte, n int) {
+	_ = strings.Replace(s, "a", "b", -1)               // want `strings.Replace with a count of -1 replaces every match, use strings.ReplaceAll instead`
+	_ = bytes.Replace(b, []byte("a"), []byte("b"), -1) // want `bytes.Replace with a count of -1 replaces every match, use bytes.ReplaceAll instead`
+	_ = str.Replace(s, "a", "b", (-1))                 // want `strings.Replace with a count of -1`
+}
+
+func notReported(s string, b []byte, n int) {
+	_ = strings.Replace(s, "a", "b", n)
+	_ = strings.Replace(s, "a", "b", all)
+	_ = strings.Replace(s, "a", "b", 1)
+	_ = strings.Replace(s, "a", "b", -2)
+	_ = bytes.Replace(b, nil, nil, n)
+	_ = strings.ReplaceAll(s, "a", "b")
+}
+
+func ignored(s string) string {
+	//godernize:ignore=replaceall
+	return strings.Replace(s, "a", "b", -1)
+}
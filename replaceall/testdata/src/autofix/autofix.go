@@ -0,0 +1,17 @@
+package autofix
+
+import (
+	"bytes"
+	str "strings"
+)
+
+func clean(s string) string {
+	return str.Replace(s, "\t", "    ", -1) // want `strings.Replace with a count of -1`
+}
+
+func cleanBytes(b []byte) []byte {
+	return bytes.Replace(b, // want `bytes.Replace with a count of -1`
+		[]byte("\r\n"),
+		[]byte("\n"), -1,
+	)
+}
@@ -0,0 +1,12 @@
+// Command sepjoingodernize runs the sepjoin analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/sepjoin"
+)
+
+func main() {
+	singlechecker.Main(sepjoin.Analyzer)
+}
@@ -0,0 +1,282 @@
+// Package sepjoin provides an analyzer to detect paths built by concatenating
+// filepath.Separator.
+package sepjoin
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for paths built by concatenating filepath.Separator
+
+This analyzer reports string concatenations that join path elements with
+string(filepath.Separator) and suggests filepath.Join instead:
+- a + string(filepath.Separator) + b -> filepath.Join(a, b)
+
+A fix is offered when separators strictly alternate with path elements of
+type string. Note that filepath.Join also cleans the resulting path: empty
+elements and trailing separators are dropped and . and .. elements are
+resolved, so review each fix.`
+
+// Analyzer is the main analyzer for manual path concatenation.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "sepjoin",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/sepjoin",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		expr, ok := n.(*ast.BinaryExpr)
+		if !push || !ok || expr == nil {
+			return true
+		}
+
+		// Only the outermost expression of a concatenation chain is diagnosed.
+		if len(stack) >= 2 && isConcat(pass.TypesInfo, stack[len(stack)-2]) {
+			return true
+		}
+
+		file := fileMap.File(expr.Pos())
+
+		if diagnostic := diagnoseBinaryExpr(pass, file, expr); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExpr) *analysis.Diagnostic {
+	if file == nil || !isConcat(pass.TypesInfo, expr) {
+		return nil
+	}
+
+	operands := flattenConcat(pass.TypesInfo, expr)
+
+	separators := 0
+
+	for _, operand := range operands {
+		if isSeparator(pass.TypesInfo, operand) {
+			separators++
+		}
+	}
+
+	if separators == 0 || shouldIgnore(pass.Fset, file, expr, "sepjoin") {
+		return nil
+	}
+
+	// Constant expressions cannot be replaced with a call, and the string
+	// returned by filepath.Join does not convert implicitly to a defined
+	// string type.
+	elements := joinElements(pass.TypesInfo, operands)
+	if elements == nil || pass.TypesInfo.Types[expr].Value != nil || !isString(pass.TypesInfo.TypeOf(expr)) {
+		return &analysis.Diagnostic{
+			Pos:     expr.Pos(),
+			End:     expr.End(),
+			Message: "use filepath.Join instead of concatenating string(filepath.Separator)",
+		}
+	}
+
+	args := make([]string, len(elements))
+	for i, element := range elements {
+		args[i] = formatNode(pass.Fset, element)
+	}
+
+	replacement := fmt.Sprintf("%s.Join(%s)", importutil.LocalName(file, "path/filepath"), strings.Join(args, ", "))
+
+	// Join cleans the result, which concatenation does not, so the fix can
+	// change the path.
+	return &analysis.Diagnostic{
+		Pos: expr.Pos(),
+		End: expr.End(),
+		Message: "use filepath.Join instead of concatenating string(filepath.Separator), " +
+			"noting that Join cleans the path: empty elements and trailing separators are dropped " +
+			"and . and .. elements are resolved",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + replacement,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     expr.Pos(),
+				End:     expr.End(),
+				NewText: []byte(replacement),
+			}},
+		}},
+	}
+}
+
+// isConcat checks if node is a string concatenation.
+func isConcat(info *types.Info, node ast.Node) bool {
+	expr, ok := node.(*ast.BinaryExpr)
+	if !ok || expr.Op != token.ADD {
+		return false
+	}
+
+	basic, ok := info.TypeOf(expr).Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isString checks if typ is the predeclared string type.
+func isString(typ types.Type) bool {
+	return typ != nil && types.Identical(typ, types.Typ[types.String])
+}
+
+// flattenConcat returns the operands of a chain of string concatenations.
+func flattenConcat(info *types.Info, expr ast.Expr) []ast.Expr {
+	binary, ok := expr.(*ast.BinaryExpr)
+	if !ok || !isConcat(info, binary) {
+		return []ast.Expr{expr}
+	}
+
+	return append(flattenConcat(info, binary.X), flattenConcat(info, binary.Y)...)
+}
+
+// isSeparator checks if expr is string(filepath.Separator).
+func isSeparator(info *types.Info, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !info.Types[call.Fun].IsType() {
+		return false
+	}
+
+	sel, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	obj, ok := info.Uses[sel.Sel].(*types.Const)
+
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == "path/filepath" && obj.Name() == "Separator"
+}
+
+// joinElements returns the path elements when separators strictly alternate
+// with them, as in a + sep + b + sep + c, or nil otherwise.
+func joinElements(info *types.Info, operands []ast.Expr) []ast.Expr {
+	if len(operands)%2 == 0 {
+		return nil
+	}
+
+	elements := make([]ast.Expr, 0, len(operands)/2+1)
+
+	for i, operand := range operands {
+		if isSeparator(info, operand) != (i%2 == 1) {
+			return nil
+		}
+
+		if i%2 == 0 {
+			elements = append(elements, operand)
+		}
+	}
+
+	return elements
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
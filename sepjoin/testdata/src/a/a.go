@@ -0,0 +1,38 @@
+package a
+
+import (
+	"os"
+	"path/filepath"
+	fp "path/filepath"
+)
+
+func manualJoin(dir, name string) {
+	_ = dir + string(filepath.Separator) + name                                      // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+	_ = dir + string(filepath.Separator) + "sub" + string(filepath.Separator) + name // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+	_ = dir + string(fp.Separator) + name                                            // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+}
+
+func notSimple(dir string) {
+	_ = dir + string(filepath.Separator)                 // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+	_ = string(filepath.Separator) + dir                 // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+	_ = dir + "/" + string(filepath.Separator) + "x.txt" // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+}
+
+const configDir = "etc" + string(filepath.Separator) + "app" // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+
+func notFilepath(dir, name string) {
+	_ = dir + string(os.PathSeparator) + name
+	_ = dir + "/" + name
+	_ = filepath.Join(dir, name)
+}
+
+type Path string
+
+func definedString(dir, name Path) Path {
+	return dir + Path(filepath.Separator) + name // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+}
+
+//godernize:ignore=sepjoin
+func ignored(dir, name string) {
+	_ = dir + string(filepath.Separator) + name
+}
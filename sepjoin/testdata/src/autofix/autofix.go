@@ -0,0 +1,17 @@
+package autofix
+
+import "path/filepath"
+
+func configPath(home, app string) string {
+	return home + string(filepath.Separator) + ".config" + string(filepath.Separator) + app // want `use filepath.Join instead of concatenating string\(filepath.Separator\), noting that Join cleans the path`
+}
+
+func trailing(dir string) string {
+	return dir + string(filepath.Separator) // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+}
+
+type Path string
+
+func definedString(dir, name Path) Path {
+	return dir + Path(filepath.Separator) + name // want `use filepath.Join instead of concatenating string\(filepath.Separator\)`
+}
@@ -0,0 +1,12 @@
+// Command slicessortstablegodernize runs the slicessortstable analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/slicessortstable"
+)
+
+func main() {
+	singlechecker.Main(slicessortstable.Analyzer)
+}
@@ -0,0 +1,449 @@
+// Package slicessortstable provides an analyzer to detect sort.SliceStable
+// calls that can use slices.SortStableFunc.
+package slicessortstable
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for sort.SliceStable calls that can use slices.SortStableFunc
+
+This analyzer reports sort.SliceStable calls and suggests the Go 1.21
+slices.SortStableFunc with a cmp-based comparison:
+- sort.SliceStable(s, func(i, j int) bool { return s[i].f < s[j].f }) ->
+  slices.SortStableFunc(s, func(a, b T) int { return cmp.Compare(a.f, b.f) })
+
+Less functions comparing elements or one of their fields with < or > are fixed
+automatically; other forms are reported without a fix. Files built for Go
+versions before 1.21 are skipped.`
+
+// Analyzer is the main analyzer for sort.SliceStable calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "slicessortstable",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/slicessortstable",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single adjustment of the imports.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic := diagnoseCallExpr(pass, file, call)
+		if diagnostic == nil {
+			return
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, call: call})
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the sort.SliceStable call it reports.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	call       *ast.CallExpr
+}
+
+// consolidateFixes adds one adjustment of the imports to the fixes in file:
+// cmp and slices are added, and sort is removed when the replaced calls held
+// its last references. With several fixes, only the first carries the
+// rewrites of all of them, so that applying every fix of the file does not
+// apply the same import edits twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.call)
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	var removeImports []string
+	if !importutil.UsedOutside(pass.TypesInfo, file, "sort", replaced...) {
+		removeImports = []string{"sort"}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, []string{"cmp", "slices"}, removeImports)...)
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || !supportsSlices(pass, file) ||
+		!isPkgFunc(pass.TypesInfo, call, "sort", "SliceStable") || len(call.Args) != 2 {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "slicessortstable") {
+		return nil
+	}
+
+	sliceText := formatNode(pass.Fset, call.Args[0])
+	if sliceText == "" {
+		return nil
+	}
+
+	cmpLess := matchLessFunc(pass.TypesInfo, call.Args[0], call.Args[1])
+	if cmpLess == nil {
+		return &analysis.Diagnostic{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			Message: "sort.SliceStable can be replaced with slices.SortStableFunc using a cmp-based comparison function",
+		}
+	}
+
+	elemType, qualified := typeString(file, pass.Pkg, cmpLess.elem)
+
+	left, right := "a", "b"
+	if !cmpLess.ascending {
+		left, right = right, left
+	}
+
+	if cmpLess.field != "" {
+		left, right = left+"."+cmpLess.field, right+"."+cmpLess.field
+	}
+
+	cmpFunc := fmt.Sprintf("func(a, b %s) int { return %s.Compare(%s, %s) }",
+		elemType, importutil.LocalName(file, "cmp"), left, right)
+	replacement := fmt.Sprintf("%s.SortStableFunc(%s, %s)", importutil.LocalName(file, "slices"), sliceText, cmpFunc)
+
+	diagnostic := &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "sort.SliceStable can be replaced with " + replacement,
+	}
+
+	// The element type cannot be spelled in this file when its package is
+	// not imported here.
+	if !qualified {
+		return diagnostic
+	}
+
+	// The imports are adjusted by consolidateFixes.
+	diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+		Message: "Replace with slices.SortStableFunc",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     call.Pos(),
+			End:     call.End(),
+			NewText: []byte(replacement),
+		}},
+	}}
+
+	return diagnostic
+}
+
+// supportsSlices reports whether the file is compiled with a Go version that
+// has the slices and cmp packages. Files without version information are
+// assumed to be recent enough.
+func supportsSlices(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.21") >= 0
+}
+
+// typeString returns typ as written in file, qualifying types of other
+// packages by their import name. It reports false if such a package is not
+// imported by file.
+func typeString(file *ast.File, pkg *types.Package, typ types.Type) (string, bool) {
+	qualified := true
+
+	text := types.TypeString(typ, func(other *types.Package) string {
+		if other == pkg {
+			return ""
+		}
+
+		name := importutil.Name(file, other.Path())
+		if name == "" {
+			qualified = false
+
+			return other.Name()
+		}
+
+		return name
+	})
+
+	return text, qualified
+}
+
+// lessFunc describes a recognized sort.SliceStable less function.
+type lessFunc struct {
+	elem      types.Type // type of the slice elements
+	field     string     // compared field name, or "" when comparing elements
+	ascending bool
+}
+
+// matchLessFunc matches less functions of the form
+//
+//	func(i, j int) bool { return s[i] < s[j] }
+//	func(i, j int) bool { return s[i].f > s[j].f }
+//
+// comparing values of an ordered type.
+func matchLessFunc(info *types.Info, slice, less ast.Expr) *lessFunc {
+	lit, ok := less.(*ast.FuncLit)
+	if !ok || lit.Body == nil || len(lit.Body.List) != 1 {
+		return nil
+	}
+
+	params := lit.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 2 {
+		return nil
+	}
+
+	ret, ok := lit.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+
+	cmpExpr, ok := ret.Results[0].(*ast.BinaryExpr)
+	if !ok || (cmpExpr.Op != token.LSS && cmpExpr.Op != token.GTR) {
+		return nil
+	}
+
+	paramI, paramJ := info.Defs[params[0].Names[0]], info.Defs[params[0].Names[1]]
+
+	leftIndex, leftField := splitOperand(cmpExpr.X)
+	rightIndex, rightField := splitOperand(cmpExpr.Y)
+
+	if leftIndex == nil || rightIndex == nil || leftField != rightField ||
+		!sameExpr(info, leftIndex.X, slice) || !sameExpr(info, rightIndex.X, slice) {
+		return nil
+	}
+
+	var forward bool
+
+	switch {
+	case refersTo(info, leftIndex.Index, paramI) && refersTo(info, rightIndex.Index, paramJ):
+		forward = true
+	case refersTo(info, leftIndex.Index, paramJ) && refersTo(info, rightIndex.Index, paramI):
+		forward = false
+	default:
+		return nil
+	}
+
+	if !isOrdered(info.TypeOf(cmpExpr.X)) {
+		return nil
+	}
+
+	return &lessFunc{
+		elem:      info.TypeOf(leftIndex),
+		field:     leftField,
+		ascending: forward == (cmpExpr.Op == token.LSS),
+	}
+}
+
+// splitOperand splits s[i] or s[i].f into the index expression and field name.
+func splitOperand(expr ast.Expr) (*ast.IndexExpr, string) {
+	switch operand := expr.(type) {
+	case *ast.IndexExpr:
+		return operand, ""
+	case *ast.SelectorExpr:
+		index, ok := operand.X.(*ast.IndexExpr)
+		if !ok {
+			return nil, ""
+		}
+
+		return index, operand.Sel.Name
+	}
+
+	return nil, ""
+}
+
+// sameExpr checks if two side-effect free expressions denote the same variable.
+func sameExpr(info *types.Info, a, b ast.Expr) bool {
+	switch exprA := a.(type) {
+	case *ast.Ident:
+		exprB, ok := b.(*ast.Ident)
+
+		return ok && info.ObjectOf(exprA) != nil && info.ObjectOf(exprA) == info.ObjectOf(exprB)
+	case *ast.SelectorExpr:
+		exprB, ok := b.(*ast.SelectorExpr)
+
+		return ok && exprA.Sel.Name == exprB.Sel.Name && sameExpr(info, exprA.X, exprB.X)
+	}
+
+	return false
+}
+
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := expr.(*ast.Ident)
+
+	return ok && obj != nil && info.Uses[ident] == obj
+}
+
+func isOrdered(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	basic, ok := typ.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsOrdered != 0
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkgPath && fn.Name() == name
+}
+
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, fset, node); err != nil {
+		return ""
+	}
+
+	return buf.String()
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
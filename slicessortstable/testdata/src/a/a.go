@@ -0,0 +1,62 @@
+package a
+
+import (
+	"net/url"
+	"sort"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func ascendingInts(s []int) {
+	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(s, func\(a, b int\) int { return cmp.Compare\(a, b\) }\)`
+}
+
+func descendingStrings(s []string) {
+	sort.SliceStable(s, func(i, j int) bool { return s[i] > s[j] }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(s, func\(a, b string\) int { return cmp.Compare\(b, a\) }\)`
+}
+
+func structField(people []person) {
+	sort.SliceStable(people, func(i, j int) bool { return people[i].age < people[j].age }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(people, func\(a, b person\) int { return cmp.Compare\(a.age, b.age\) }\)`
+}
+
+func structFieldReversedOperands(people []person) {
+	sort.SliceStable(people, func(i, j int) bool { return people[j].name < people[i].name }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(people, func\(a, b person\) int { return cmp.Compare\(b.name, a.name\) }\)`
+}
+
+func pointerElements(people []*person) {
+	sort.SliceStable(people, func(i, j int) bool { return people[i].age < people[j].age }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(people, func\(a, b \*person\) int { return cmp.Compare\(a.age, b.age\) }\)`
+}
+
+func otherPackageType(urls []*url.URL) {
+	sort.SliceStable(urls, func(i, j int) bool { return urls[i].Host < urls[j].Host }) // want `sort.SliceStable can be replaced with slices.SortStableFunc\(urls, func\(a, b \*url.URL\) int { return cmp.Compare\(a.Host, b.Host\) }\)`
+}
+
+func complexLess(people []person) {
+	sort.SliceStable(people, func(i, j int) bool { // want `sort.SliceStable can be replaced with slices.SortStableFunc using a cmp-based comparison function`
+		if people[i].age != people[j].age {
+			return people[i].age < people[j].age
+		}
+
+		return people[i].name < people[j].name
+	})
+}
+
+func otherSlice(s, t []int) {
+	sort.SliceStable(s, func(i, j int) bool { return t[i] < t[j] }) // want `sort.SliceStable can be replaced with slices.SortStableFunc using a cmp-based comparison function`
+}
+
+func namedLess(s []int, less func(i, j int) bool) {
+	sort.SliceStable(s, less) // want `sort.SliceStable can be replaced with slices.SortStableFunc using a cmp-based comparison function`
+}
+
+//godernize:ignore=slicessortstable
+func ignored(s []int) {
+	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] })
+}
+
+func notSliceStable(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
@@ -0,0 +1,12 @@
+package autofix
+
+import (
+	"slices"
+	"sort"
+)
+
+func keepImport(s []string, t []int) {
+	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort.SliceStable can be replaced with slices.SortStableFunc`
+	sort.Ints(t)
+	_ = slices.Contains(t, 1)
+}
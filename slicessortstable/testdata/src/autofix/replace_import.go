@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"fmt"
+	"sort"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func replaceImport(people []person) {
+	sort.SliceStable(people, func(i, j int) bool { return people[i].age > people[j].age }) // want `sort.SliceStable can be replaced with slices.SortStableFunc`
+	fmt.Println(people)
+}
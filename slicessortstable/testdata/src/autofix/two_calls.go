@@ -0,0 +1,8 @@
+package autofix
+
+import "sort"
+
+func twoCalls(names []string, ages []int) {
+	sort.SliceStable(names, func(i, j int) bool { return names[i] < names[j] }) // want `sort.SliceStable can be replaced with slices.SortStableFunc`
+	sort.SliceStable(ages, func(i, j int) bool { return ages[i] > ages[j] })    // want `sort.SliceStable can be replaced with slices.SortStableFunc`
+}
@@ -0,0 +1,12 @@
+// Command sortslicesgodernize runs the sortslices analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/sortslices"
+)
+
+func main() {
+	singlechecker.Main(sortslices.Analyzer)
+}
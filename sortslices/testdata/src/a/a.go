@@ -0,0 +1,51 @@
+package a
+
+import "sort"
+
+type person struct {
+	name string
+	age  int
+}
+
+func ascendingInts(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort.Slice can be replaced with slices.Sort\(s\)`
+}
+
+func ascendingReversedOperands(s []string) {
+	sort.Slice(s, func(i, j int) bool { return s[j] > s[i] }) // want `sort.Slice can be replaced with slices.Sort\(s\)`
+}
+
+func descendingInts(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] > s[j] }) // want `sort.Slice can be replaced with slices.SortFunc\(s, func\(a, b int\) int { return cmp.Compare\(b, a\) }\)`
+}
+
+func structField(people []person) {
+	sort.Slice(people, func(i, j int) bool { return people[i].age < people[j].age }) // want `sort.Slice can be replaced with slices.SortFunc\(people, func\(a, b person\) int { return cmp.Compare\(a.age, b.age\) }\)`
+}
+
+func structFieldDescending(people []person) {
+	sort.Slice(people, func(i, j int) bool { return people[i].name > people[j].name }) // want `sort.Slice can be replaced with slices.SortFunc\(people, func\(a, b person\) int { return cmp.Compare\(b.name, a.name\) }\)`
+}
+
+func complexLess(people []person) {
+	sort.Slice(people, func(i, j int) bool { // want `sort.Slice can be replaced with slices.SortFunc using a cmp-based comparison function`
+		if people[i].age != people[j].age {
+			return people[i].age < people[j].age
+		}
+
+		return people[i].name < people[j].name
+	})
+}
+
+func otherSlice(s, t []int) {
+	sort.Slice(s, func(i, j int) bool { return t[i] < t[j] }) // want `sort.Slice can be replaced with slices.SortFunc using a cmp-based comparison function`
+}
+
+//godernize:ignore=sortslices
+func ignored(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
+
+func notSortSlice(s []int) {
+	sort.Ints(s)
+}
@@ -0,0 +1,8 @@
+package autofix
+
+import "sort"
+
+func keepImport(names []string, s []int) {
+	sort.Strings(names)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort.Slice can be replaced with slices.Sort\(s\)`
+}
@@ -0,0 +1,11 @@
+package autofix
+
+import (
+	"fmt"
+	"sort"
+)
+
+func replaceImport(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort.Slice can be replaced with slices.Sort\(s\)`
+	fmt.Println(s)
+}
@@ -0,0 +1,8 @@
+package autofix
+
+import "sort"
+
+func twoCalls(a, b []int) {
+	sort.Slice(a, func(i, j int) bool { return a[i] < a[j] }) // want `sort.Slice can be replaced with slices.Sort\(a\)`
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] }) // want `sort.Slice can be replaced with slices.Sort\(b\)`
+}
@@ -0,0 +1,8 @@
+package old
+
+import "sort"
+
+// The slices package does not exist before Go 1.21.
+func ascendingInts(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
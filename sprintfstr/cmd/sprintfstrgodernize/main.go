@@ -0,0 +1,12 @@
+// Command sprintfstrgodernize runs the sprintfstr analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/sprintfstr"
+)
+
+func main() {
+	singlechecker.Main(sprintfstr.Analyzer)
+}
@@ -0,0 +1,268 @@
+// Package sprintfstr provides an analyzer to detect fmt.Sprintf calls that
+// format a single string as is.
+package sprintfstr
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const fmtPath = "fmt"
+
+// Doc describes what this analyzer does.
+const Doc = `check for fmt.Sprintf("%s", s) with a string s
+
+This analyzer reports fmt.Sprintf calls whose format is "%s" or "%v" and
+whose only argument is a string, which the call returns unchanged:
+- fmt.Sprintf("%s", s) -> s
+- fmt.Sprintf("%v", s) -> s
+
+Arguments of defined string types are not reported, since they may have a
+String method and the call converts them to string. The fix replaces the call
+with its argument and removes the fmt import when it becomes unused.`
+
+// Analyzer is the main analyzer for redundant fmt.Sprintf calls.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "sprintfstr",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/sprintfstr",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the fmt import.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic := diagnoseCallExpr(pass, file, call)
+		if diagnostic == nil {
+			return
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, call: call})
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the fmt.Sprintf call its fix replaces.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	call       *ast.CallExpr
+}
+
+// consolidateFixes removes the fmt import in the fixes of file when the
+// replaced calls held its last references. With several fixes, only the
+// first carries the rewrites of all of them, so that applying every fix of
+// the file does not remove the import twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	edits := make([]analysis.TextEdit, 0, 2*len(found))
+
+	// The function and the format go away; the argument is kept and may
+	// refer to fmt itself.
+	replaced := make([]ast.Node, 0, 2*len(found))
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.call.Fun, f.call.Args[0])
+	}
+
+	if !importutil.UsedOutside(pass.TypesInfo, file, fmtPath, replaced...) {
+		edits = append(edits, importutil.Edits(pass.Fset, file, nil, []string{fmtPath})...)
+	}
+
+	diagnostics[0].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[0].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) *analysis.Diagnostic {
+	if file == nil || len(call.Args) != 2 || call.Ellipsis.IsValid() ||
+		!isPkgFunc(pass.TypesInfo, call, fmtPath, "Sprintf") {
+		return nil
+	}
+
+	format := formatString(pass.TypesInfo, call.Args[0])
+	if format != "%s" && format != "%v" {
+		return nil
+	}
+
+	arg := call.Args[1]
+	if !isString(pass.TypesInfo.TypeOf(arg)) || shouldIgnore(pass.Fset, file, call, "sprintfstr") {
+		return nil
+	}
+
+	// Deleting around the argument keeps it as written, comments included.
+	return &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: fmt.Sprintf("fmt.Sprintf(%q, s) returns the string s unchanged, use s directly", format),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with the argument",
+			TextEdits: []analysis.TextEdit{
+				{Pos: call.Pos(), End: arg.Pos()},
+				{Pos: arg.End(), End: call.End()},
+			},
+		}},
+	}
+}
+
+// formatString returns the value of a constant string format, or "" if the
+// format is not constant.
+func formatString(info *types.Info, expr ast.Expr) string {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return ""
+	}
+
+	return constant.StringVal(tv.Value)
+}
+
+// isString checks if typ is string or an untyped string constant. Defined
+// string types are not, as fmt may call their String method.
+func isString(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	basic, ok := types.Unalias(typ).(*types.Basic)
+
+	return ok && (basic.Kind() == types.String || basic.Kind() == types.UntypedString)
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
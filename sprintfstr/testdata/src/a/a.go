@@ -0,0 +1,37 @@
+package a
+
+import "fmt"
+
+type name string
+
+func (n name) String() string { return "name: " + string(n) }
+
+type text = string
+
+const verb = "%s"
+
+func redundant(s string, t text) {
+	_ = fmt.Sprintf("%s", s)     // want `fmt.Sprintf\("%s", s\) returns the string s unchanged, use s directly`
+	_ = fmt.Sprintf("%v", s)     // want `fmt.Sprintf\("%v", s\) returns the string s unchanged, use s directly`
+	_ = fmt.Sprintf(`%s`, s+"!") // want `fmt.Sprintf\("%s", s\) returns the string s unchanged`
+	_ = fmt.Sprintf(verb, t)     // want `fmt.Sprintf\("%s", s\) returns the string s unchanged`
+	_ = fmt.Sprintf("%s", "lit") // want `fmt.Sprintf\("%s", s\) returns the string s unchanged`
+}
+
+func notReported(s string, n name, i int, b []byte, args []any, format string) {
+	_ = fmt.Sprintf("%s", n)
+	_ = fmt.Sprintf("%s", i)
+	_ = fmt.Sprintf("%s", b)
+	_ = fmt.Sprintf("%q", s)
+	_ = fmt.Sprintf("%s!", s)
+	_ = fmt.Sprintf("%s%s", s, s)
+	_ = fmt.Sprintf("%s", s, s)
+	_ = fmt.Sprintf(format, s)
+	_ = fmt.Sprintf("%s", args...)
+	_ = fmt.Sprint(s)
+}
+
+func ignored(s string) {
+	//godernize:ignore=sprintfstr
+	_ = fmt.Sprintf("%s", s)
+}
@@ -0,0 +1,14 @@
+package autofix
+
+import (
+	"fmt"
+	"strings"
+)
+
+func greet(name string) string {
+	return fmt.Sprintf("%s", strings.TrimSpace(name)) // want `fmt.Sprintf\("%s", s\) returns the string s unchanged`
+}
+
+func join(a, b string) string {
+	return fmt.Sprintf("%v", a+ /* separator */ " "+b) // want `fmt.Sprintf\("%v", s\) returns the string s unchanged`
+}
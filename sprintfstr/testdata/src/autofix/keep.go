@@ -0,0 +1,8 @@
+package autofix
+
+import "fmt"
+
+// The argument refers to fmt, so the import stays.
+func describe(v int) string {
+	return fmt.Sprintf("%s", fmt.Sprint(v)) // want `fmt.Sprintf\("%s", s\) returns the string s unchanged`
+}
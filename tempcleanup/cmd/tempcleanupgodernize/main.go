@@ -0,0 +1,12 @@
+// Command tempcleanupgodernize runs the tempcleanup analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/tempcleanup"
+)
+
+func main() {
+	singlechecker.Main(tempcleanup.Analyzer)
+}
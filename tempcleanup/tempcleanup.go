@@ -0,0 +1,436 @@
+// Package tempcleanup provides an analyzer to detect temporary files and
+// directories that are never removed.
+package tempcleanup
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for temporary files and directories that are never removed
+
+This analyzer reports os.CreateTemp, os.MkdirTemp, ioutil.TempFile, and
+ioutil.TempDir calls whose result is never passed to os.Remove or os.RemoveAll
+in the enclosing function, leaking the temporary file or directory. Results
+that leave the function, for example by being returned, stored in a field, or
+passed to another function, are assumed to be cleaned up elsewhere. No fix is
+offered.`
+
+// Analyzer is the main analyzer for temporary file cleanup.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "tempcleanup",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/tempcleanup",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// tempFunc describes a function creating a temporary file or directory.
+type tempFunc struct {
+	pkgPath string
+	name    string
+	// dir reports whether the function creates a directory.
+	dir bool
+}
+
+//nolint:gochecknoglobals // static table of temporary file constructors
+var tempFuncs = []tempFunc{
+	{pkgPath: "os", name: "CreateTemp"},
+	{pkgPath: "os", name: "MkdirTemp", dir: true},
+	{pkgPath: "io/ioutil", name: "TempFile"},
+	{pkgPath: "io/ioutil", name: "TempDir", dir: true},
+}
+
+// safePkgs lists packages whose functions may receive a temporary path or
+// file without taking over responsibility for removing it.
+//
+//nolint:gochecknoglobals // static allowlist
+var safePkgs = []string{"bufio", "fmt", "io", "io/ioutil", "log", "os", "path/filepath"}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+
+		if diagnostic := diagnoseCallExpr(pass, file, call, stack); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node) *analysis.Diagnostic {
+	if file == nil {
+		return nil
+	}
+
+	fn, ok := findTempFunc(pass.TypesInfo, call)
+	if !ok {
+		return nil
+	}
+
+	body := enclosingBody(stack)
+	if body == nil {
+		return nil
+	}
+
+	result, tracked := resultObject(pass.TypesInfo, call, stack)
+	if !tracked {
+		return nil
+	}
+
+	if result != nil && !isLeaked(pass.TypesInfo, body, result) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "tempcleanup") {
+		return nil
+	}
+
+	kind, removal := "file", "defer os.Remove(f.Name())"
+	if fn.dir {
+		kind, removal = "directory", "defer os.RemoveAll(dir)"
+	}
+
+	return &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("temporary %s created by %s.%s is never removed; add %s",
+			kind, pkgName(fn.pkgPath), fn.name, removal),
+	}
+}
+
+func findTempFunc(info *types.Info, call *ast.CallExpr) (tempFunc, bool) {
+	for _, fn := range tempFuncs {
+		if isPkgFunc(info, call, fn.pkgPath, fn.name) {
+			return fn, true
+		}
+	}
+
+	return tempFunc{}, false
+}
+
+func pkgName(pkgPath string) string {
+	if pkgPath == "io/ioutil" {
+		return "ioutil"
+	}
+
+	return pkgPath
+}
+
+// enclosingBody returns the body of the innermost function on the stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			return node.Body
+		case *ast.FuncLit:
+			return node.Body
+		}
+	}
+
+	return nil
+}
+
+// resultObject returns the local variable receiving the first result of
+// call. It returns nil and true if the result is discarded, and false if the
+// result is used in a way that cannot be tracked.
+func resultObject(info *types.Info, call *ast.CallExpr, stack []ast.Node) (types.Object, bool) {
+	// The last element of the stack is the call itself.
+	if len(stack) < 2 {
+		return nil, false
+	}
+
+	var lhs ast.Expr
+
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.ExprStmt:
+		return nil, true
+	case *ast.AssignStmt:
+		if len(parent.Rhs) != 1 || ast.Unparen(parent.Rhs[0]) != call || len(parent.Lhs) == 0 {
+			return nil, false
+		}
+
+		lhs = parent.Lhs[0]
+	case *ast.ValueSpec:
+		if len(parent.Values) != 1 || len(parent.Names) == 0 {
+			return nil, false
+		}
+
+		lhs = parent.Names[0]
+	default:
+		return nil, false
+	}
+
+	ident, ok := lhs.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	if ident.Name == "_" {
+		return nil, true
+	}
+
+	obj := info.ObjectOf(ident)
+
+	// Package-level variables outlive the function.
+	if obj == nil || obj.Pkg() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+// isLeaked checks if the temporary file or directory held by result is
+// neither removed nor handed over within body.
+func isLeaked(info *types.Info, body *ast.BlockStmt, result types.Object) bool {
+	tracked := []types.Object{result}
+
+	// Names derived from the result, as in name := f.Name(), are tracked too.
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+
+		for i, rhs := range assign.Rhs {
+			ident, ok := assign.Lhs[i].(*ast.Ident)
+			if ok && ident.Name != "_" && mentions(info, rhs, tracked) {
+				if obj := info.ObjectOf(ident); obj != nil && !slices.Contains(tracked, obj) {
+					tracked = append(tracked, obj)
+				}
+			}
+		}
+
+		return true
+	})
+
+	leaked := true
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if !leaked {
+			return false
+		}
+
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if isRemoval(info, node) && slices.ContainsFunc(node.Args, func(arg ast.Expr) bool {
+				return mentions(info, arg, tracked)
+			}) {
+				leaked = false
+			}
+
+			if !isSafeCall(info, node) && slices.ContainsFunc(node.Args, func(arg ast.Expr) bool {
+				return isTracked(info, arg, tracked)
+			}) {
+				leaked = false
+			}
+		case *ast.ReturnStmt:
+			if slices.ContainsFunc(node.Results, func(result ast.Expr) bool {
+				return isTracked(info, result, tracked)
+			}) {
+				leaked = false
+			}
+		case *ast.AssignStmt:
+			if escapesByAssignment(info, node, tracked) {
+				leaked = false
+			}
+		case *ast.CompositeLit:
+			if slices.ContainsFunc(node.Elts, func(elt ast.Expr) bool {
+				return mentions(info, elt, tracked)
+			}) {
+				leaked = false
+			}
+		case *ast.SendStmt:
+			if mentions(info, node.Value, tracked) {
+				leaked = false
+			}
+		}
+
+		return true
+	})
+
+	return leaked
+}
+
+// escapesByAssignment checks if assign stores a tracked value anywhere other
+// than a local variable.
+func escapesByAssignment(info *types.Info, assign *ast.AssignStmt, tracked []types.Object) bool {
+	if len(assign.Lhs) != len(assign.Rhs) {
+		return false
+	}
+
+	for i, rhs := range assign.Rhs {
+		if !isTracked(info, rhs, tracked) {
+			continue
+		}
+
+		ident, ok := assign.Lhs[i].(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		obj := info.ObjectOf(ident)
+		if obj != nil && obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRemoval(info *types.Info, call *ast.CallExpr) bool {
+	return isPkgFunc(info, call, "os", "Remove") || isPkgFunc(info, call, "os", "RemoveAll")
+}
+
+// isSafeCall checks if call cannot take over a temporary file passed to it.
+func isSafeCall(info *types.Info, call *ast.CallExpr) bool {
+	fn := typeutil.Callee(info, call)
+	if fn == nil {
+		// Conversions are safe, calls of function values are not.
+		tv, ok := info.Types[call.Fun]
+
+		return ok && tv.IsType()
+	}
+
+	if _, ok := fn.(*types.Builtin); ok {
+		return true
+	}
+
+	return fn.Pkg() != nil && slices.Contains(safePkgs, fn.Pkg().Path())
+}
+
+// isTracked checks if expr is a tracked variable itself.
+func isTracked(info *types.Info, expr ast.Expr, tracked []types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && slices.Contains(tracked, info.ObjectOf(ident))
+}
+
+// mentions checks if expr refers to a tracked variable anywhere.
+func mentions(info *types.Info, expr ast.Node, tracked []types.Object) bool {
+	found := false
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && slices.Contains(tracked, info.ObjectOf(ident)) {
+			found = true
+		}
+
+		return !found
+	})
+
+	return found
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,132 @@
+package a
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var globalDir string
+
+type workspace struct {
+	dir string
+}
+
+func missingFile() error {
+	f, err := os.CreateTemp("", "data-*.json") // want `temporary file created by os.CreateTemp is never removed; add defer os.Remove\(f.Name\(\)\)`
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintln(f, "data")
+
+	return err
+}
+
+func missingDir() error {
+	dir, err := os.MkdirTemp("", "work") // want `temporary directory created by os.MkdirTemp is never removed; add defer os.RemoveAll\(dir\)`
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "config"), nil, 0o600)
+}
+
+func discarded() {
+	_, _ = os.MkdirTemp("", "work") // want `temporary directory created by os.MkdirTemp is never removed`
+	os.CreateTemp("", "x")          // want `temporary file created by os.CreateTemp is never removed`
+}
+
+func missingIoutil() {
+	f, _ := ioutil.TempFile("", "x") // want `temporary file created by ioutil.TempFile is never removed`
+	fmt.Println(f.Name())
+
+	var dir, _ = ioutil.TempDir("", "x") // want `temporary directory created by ioutil.TempDir is never removed`
+	fmt.Println(dir)
+}
+
+func deferredFile() error {
+	f, err := os.CreateTemp("", "data")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	return f.Close()
+}
+
+func deferredName() error {
+	f, err := os.CreateTemp("", "data")
+	if err != nil {
+		return err
+	}
+
+	name := f.Name()
+	defer func() { _ = os.Remove(name) }()
+
+	return f.Close()
+}
+
+func deferredDir() error {
+	dir, err := os.MkdirTemp("", "work")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	return os.WriteFile(filepath.Join(dir, "config"), nil, 0o600)
+}
+
+func testCleanup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "work")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(dir) })
+}
+
+func returned() (string, error) {
+	return os.MkdirTemp("", "work")
+}
+
+func returnedVar() (*os.File, error) {
+	f, err := os.CreateTemp("", "data")
+
+	return f, err
+}
+
+func storedInField(w *workspace) {
+	dir, _ := os.MkdirTemp("", "work")
+	w.dir = dir
+}
+
+func storedInLiteral() workspace {
+	dir, _ := os.MkdirTemp("", "work")
+
+	return workspace{dir: dir}
+}
+
+func storedInGlobal() {
+	dir, _ := os.MkdirTemp("", "work")
+	globalDir = dir
+}
+
+func handedOver(v any) error {
+	f, err := os.CreateTemp("", "data")
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(f).Encode(v)
+}
+
+//godernize:ignore=tempcleanup
+func ignored() {
+	dir, _ := os.MkdirTemp("", "work")
+	fmt.Println(dir)
+}
@@ -0,0 +1,33 @@
+package fileignore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+func reported(ctx context.Context, err error, start time.Time, s []int, x float64, name string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if os.IsNotExist(err) {
+		fmt.Println(ctx)
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	fmt.Println(time.Now().Sub(start), math.Pow(x, 2), strings.Replace(name, "a", "b", -1))
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+
+	for i := 0; i < 10; i++ {
+		fmt.Println(i)
+	}
+
+	fmt.Println(errors.New(fmt.Sprintf("bad %s", name)), fmt.Sprintf("%s", name))
+}
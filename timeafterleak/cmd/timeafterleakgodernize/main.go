@@ -0,0 +1,12 @@
+// Command timeafterleakgodernize runs the timeafterleak analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/timeafterleak"
+)
+
+func main() {
+	singlechecker.Main(timeafterleak.Analyzer)
+}
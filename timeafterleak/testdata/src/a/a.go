@@ -0,0 +1,77 @@
+//go:build go1.22
+
+package a
+
+import (
+	"fmt"
+	"time"
+)
+
+func loop(ch <-chan int) {
+	for {
+		select {
+		case v := <-ch:
+			fmt.Println(v)
+		case <-time.After(time.Second): // want `time.After in a select inside a loop creates a timer per iteration that is not stopped`
+			return
+		}
+	}
+}
+
+func rangeLoop(chs []chan int) {
+	for _, ch := range chs {
+		select {
+		case v := <-ch:
+			fmt.Println(v)
+		case t := <-time.After(time.Second): // want `time.After in a select inside a loop`
+			fmt.Println(t)
+		}
+	}
+}
+
+func oneShot(ch <-chan int) {
+	select {
+	case v := <-ch:
+		fmt.Println(v)
+	case <-time.After(time.Second):
+	}
+}
+
+func closure(chs []chan int) {
+	for _, ch := range chs {
+		// Each goroutine selects once.
+		go func() {
+			select {
+			case v := <-ch:
+				fmt.Println(v)
+			case <-time.After(time.Second):
+			}
+		}()
+	}
+}
+
+func stopped(ch <-chan int) {
+	for {
+		timer := time.NewTimer(time.Second)
+
+		select {
+		case v := <-ch:
+			timer.Stop()
+			fmt.Println(v)
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+func ignored(ch <-chan int) {
+	for {
+		select {
+		case v := <-ch:
+			fmt.Println(v)
+		//godernize:ignore=timeafterleak
+		case <-time.After(time.Second):
+			return
+		}
+	}
+}
@@ -0,0 +1,20 @@
+//go:build go1.23
+
+package a
+
+import (
+	"fmt"
+	"time"
+)
+
+// Since Go 1.23, unstopped timers are garbage collected.
+func recent(ch <-chan int) {
+	for {
+		select {
+		case v := <-ch:
+			fmt.Println(v)
+		case <-time.After(time.Second):
+			return
+		}
+	}
+}
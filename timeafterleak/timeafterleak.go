@@ -0,0 +1,232 @@
+// Package timeafterleak provides an analyzer to detect time.After calls in
+// select statements inside loops, whose timers are not stopped.
+package timeafterleak
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for time.After in select statements inside loops
+
+This analyzer reports select statements inside a for loop that receive from a
+time.After call:
+
+  for {
+      select {
+      case v := <-ch:
+          ...
+      case <-time.After(d):
+          ...
+      }
+  }
+
+Each iteration creates a timer that is not stopped, so timers of iterations
+ending on another case stay alive until they fire. Create one with
+time.NewTimer and Stop it, or reuse a timer with Reset.
+
+Go 1.23 garbage collects unstopped timers, so files compiled for Go 1.23 or
+later are not reported; files without version information are assumed to be
+recent enough. Loops of a function enclosing a function literal do not count.
+The analyzer reports diagnostics only.`
+
+// Analyzer is the main analyzer for time.After in select statements.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "timeafterleak",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/timeafterleak",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.SelectStmt)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		stmt, ok := n.(*ast.SelectStmt)
+		if !push || !ok || stmt == nil {
+			return true
+		}
+
+		file := fileMap.File(stmt.Pos())
+
+		if file == nil || collectsTimers(pass, file) || !inLoop(stack) {
+			return true
+		}
+
+		for _, call := range timeAfterCalls(pass.TypesInfo, stmt) {
+			if !shouldIgnore(pass.Fset, file, call, "timeafterleak") {
+				pass.Report(analysis.Diagnostic{
+					Pos: call.Pos(),
+					End: call.End(),
+					Message: "time.After in a select inside a loop creates a timer per iteration that is not stopped, " +
+						"use time.NewTimer with Stop or reuse a timer with Reset",
+				})
+			}
+		}
+
+		return true
+	})
+
+	return nil, nil
+}
+
+// collectsTimers reports whether the file is compiled with a Go version that
+// garbage collects unstopped timers. Files without version information are
+// assumed to be recent enough.
+func collectsTimers(pass *analysis.Pass, file *ast.File) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+
+	return fileVersion == "" || version.Compare(fileVersion, "go1.23") >= 0
+}
+
+// inLoop reports whether the last node of stack is inside the body of a for
+// or range loop of the same function.
+func inLoop(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.ForStmt:
+			return stack[i+1] == node.Body
+		case *ast.RangeStmt:
+			return stack[i+1] == node.Body
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		}
+	}
+
+	return false
+}
+
+// timeAfterCalls returns the time.After calls received from by the cases of
+// stmt, as in case <-time.After(d) or case t := <-time.After(d).
+func timeAfterCalls(info *types.Info, stmt *ast.SelectStmt) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+
+	for _, clause := range stmt.Body.List {
+		comm, ok := clause.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+
+		var received ast.Expr
+
+		switch s := comm.Comm.(type) {
+		case *ast.ExprStmt:
+			received = s.X
+		case *ast.AssignStmt:
+			if len(s.Rhs) == 1 {
+				received = s.Rhs[0]
+			}
+		}
+
+		recv, ok := ast.Unparen(received).(*ast.UnaryExpr)
+		if !ok || recv.Op != token.ARROW {
+			continue
+		}
+
+		call, ok := ast.Unparen(recv.X).(*ast.CallExpr)
+		if ok && isTimeAfter(info, call) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func isTimeAfter(info *types.Info, call *ast.CallExpr) bool {
+	fn := typeutil.StaticCallee(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Name() == "After"
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
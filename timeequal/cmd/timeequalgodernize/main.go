@@ -0,0 +1,12 @@
+// Command timeequalgodernize runs the timeequal analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/timeequal"
+)
+
+func main() {
+	singlechecker.Main(timeequal.Analyzer)
+}
@@ -0,0 +1,38 @@
+package a
+
+import "time"
+
+type stamp = time.Time
+
+type event struct {
+	at time.Time
+}
+
+type myTime time.Time
+
+func compare(t1, t2 time.Time, s stamp, e event, p *time.Time) {
+	_ = t1 == t2         // want `time.Time compared using ==, which also compares the location and monotonic clock reading; use t1.Equal\(t2\)`
+	_ = t1 != t2         // want `time.Time compared using !=, which also compares the location and monotonic clock reading; use !t1.Equal\(t2\)`
+	_ = s == e.at        // want `time.Time compared using ==`
+	_ = *p == t1         // want `time.Time compared using ==`
+	_ = t1 == time.Now() // want `time.Time compared using ==`
+}
+
+func zero(t time.Time) {
+	_ = t == time.Time{}   // want `time.Time compared with the zero value using ==, which also requires no location and no monotonic clock reading; use t.IsZero\(\) unless that is intended`
+	_ = (time.Time{}) != t // want `time.Time compared with the zero value using !=, which also holds for a zero time with a location; use !t.IsZero\(\) unless that is intended`
+}
+
+func notReported(t1, t2 time.Time, p1, p2 *time.Time, m1, m2 myTime, e1, e2 event) {
+	_ = t1.Equal(t2)
+	_ = p1 == p2
+	_ = p1 == nil
+	_ = m1 == m2
+	_ = e1 == e2
+	_ = t1.Before(t2)
+}
+
+func ignored(t1, t2 time.Time) bool {
+	//godernize:ignore=timeequal
+	return t1 == t2
+}
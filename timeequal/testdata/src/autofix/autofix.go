@@ -0,0 +1,23 @@
+package autofix
+
+import "time"
+
+type event struct {
+	at time.Time
+}
+
+func same(t1, t2 time.Time) bool {
+	return t1 == t2 // want `time.Time compared using ==`
+}
+
+func changed(e event, t time.Time) bool {
+	return e.at != t.UTC() // want `time.Time compared using !=`
+}
+
+func deref(p *time.Time, t time.Time) bool {
+	return *p == t // want `time.Time compared using ==`
+}
+
+func zero(t time.Time) bool {
+	return t == time.Time{} // want `time.Time compared with the zero value`
+}
@@ -0,0 +1,232 @@
+// Package timeequal provides an analyzer to detect time.Time values compared
+// with == and != instead of Equal.
+package timeequal
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+const timePath = "time"
+
+// Doc describes what this analyzer does.
+const Doc = `check for time.Time values compared with == or !=
+
+This analyzer reports comparisons of two time.Time values with == or !=,
+which compare the location and the monotonic clock reading as well as the
+instant, and suggests Equal:
+- t1 == t2 -> t1.Equal(t2)
+- t1 != t2 -> !t1.Equal(t2)
+
+Comparisons with the zero value, such as t == time.Time{}, are reported
+without a fix: they are sometimes intentional, and t.IsZero() is the usual
+way to write them.`
+
+// Analyzer is the main analyzer for time.Time comparisons.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "timeequal",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/timeequal",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr, ok := n.(*ast.BinaryExpr)
+		if !ok || expr == nil {
+			return
+		}
+
+		file := fileMap.File(expr.Pos())
+
+		if diagnostic := diagnoseBinaryExpr(pass, file, expr); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseBinaryExpr(pass *analysis.Pass, file *ast.File, expr *ast.BinaryExpr) *analysis.Diagnostic {
+	if file == nil || (expr.Op != token.EQL && expr.Op != token.NEQ) {
+		return nil
+	}
+
+	if !isTime(pass.TypesInfo.TypeOf(expr.X)) || !isTime(pass.TypesInfo.TypeOf(expr.Y)) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, expr, "timeequal") {
+		return nil
+	}
+
+	if isZeroTime(expr.X) || isZeroTime(expr.Y) {
+		message := "time.Time compared with the zero value using ==, which also requires no location and " +
+			"no monotonic clock reading; use t.IsZero() unless that is intended"
+		if expr.Op == token.NEQ {
+			message = "time.Time compared with the zero value using !=, which also holds for a zero time with " +
+				"a location; use !t.IsZero() unless that is intended"
+		}
+
+		return &analysis.Diagnostic{
+			Pos:     expr.Pos(),
+			End:     expr.End(),
+			Message: message,
+		}
+	}
+
+	message, fixMessage, prefix := "time.Time compared using ==, which also compares the location and "+
+		"monotonic clock reading; use t1.Equal(t2)", "Replace with t1.Equal(t2)", ""
+	if expr.Op == token.NEQ {
+		message, fixMessage, prefix = "time.Time compared using !=, which also compares the location and "+
+			"monotonic clock reading; use !t1.Equal(t2)", "Replace with !t1.Equal(t2)", "!"
+	}
+
+	// The operands are kept as written; only the receiver may need
+	// parentheses.
+	open, closing := "", ""
+	if needsParens(expr.X) {
+		open, closing = "(", ")"
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		End:     expr.End(),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: fixMessage,
+			TextEdits: []analysis.TextEdit{
+				{Pos: expr.X.Pos(), End: expr.X.Pos(), NewText: []byte(prefix + open)},
+				{Pos: expr.X.End(), End: expr.Y.Pos(), NewText: []byte(closing + ".Equal(")},
+				{Pos: expr.Y.End(), End: expr.Y.End(), NewText: []byte(")")},
+			},
+		}},
+	}
+}
+
+// isTime checks if typ is time.Time.
+func isTime(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == timePath && obj.Name() == "Time"
+}
+
+// isZeroTime checks if expr is an empty composite literal such as
+// time.Time{}.
+func isZeroTime(expr ast.Expr) bool {
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+
+	return ok && len(lit.Elts) == 0
+}
+
+// needsParens checks if expr must be parenthesized to be the receiver of a
+// method call.
+func needsParens(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.ParenExpr:
+		return false
+	}
+
+	return true
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,12 @@
+// Command timesincegodernize runs the timesince analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/timesince"
+)
+
+func main() {
+	singlechecker.Main(timesince.Analyzer)
+}
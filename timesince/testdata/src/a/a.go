@@ -0,0 +1,35 @@
+package a
+
+import (
+	"fmt"
+	"time"
+)
+
+type clock struct{ start time.Time }
+
+func (c clock) Sub(t time.Time) time.Duration { return 0 }
+func (c clock) Now() clock                    { return c }
+
+func elapsed(start time.Time) time.Duration {
+	return time.Now().Sub(start) // want `time.Now\(\).Sub\(t\) can be simplified to time.Since\(t\)`
+}
+
+func nested(c clock) {
+	fmt.Println(time.Now().Sub(c.start).Seconds())                      // want `time.Now\(\).Sub\(t\) can be simplified to time.Since\(t\)`
+	fmt.Println((time.Now()).Sub(c.start.Add(time.Now().Sub(c.start)))) // want `can be simplified to time.Since` `can be simplified to time.Since`
+}
+
+func untouched(start, deadline time.Time, c clock) {
+	_ = deadline.Sub(time.Now())
+	_ = time.Now().UnixNano()
+	_ = start.Sub(start)
+	_ = c.Now().Sub(start)
+	_ = time.Now().Add(time.Second).Sub(start)
+	sub := time.Now().Sub
+	_ = sub(start)
+}
+
+//godernize:ignore=timesince
+func ignored(start time.Time) time.Duration {
+	return time.Now().Sub(start)
+}
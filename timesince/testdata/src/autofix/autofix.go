@@ -0,0 +1,18 @@
+package autofix
+
+import (
+	"fmt"
+	t "time"
+)
+
+func elapsed(start t.Time) t.Duration {
+	return t.Now().Sub(start) // want "time.Now\\(\\).Sub\\(t\\) can be simplified to time.Since\\(t\\)"
+}
+
+func report(starts map[string]t.Time) {
+	fmt.Println(t.Now().Sub(starts["a"]).Seconds()) // want "time.Now\\(\\).Sub\\(t\\) can be simplified to time.Since\\(t\\)"
+}
+
+func nested(start t.Time) t.Duration {
+	return t.Now().Sub(start.Add(t.Now().Sub(start))) // want "can be simplified to time.Since" "can be simplified to time.Since"
+}
@@ -0,0 +1,7 @@
+package dot
+
+import . "time"
+
+func elapsed(start Time) Duration {
+	return Now().Sub(start) // want `time.Now\(\).Sub\(t\) can be simplified to time.Since\(t\)`
+}
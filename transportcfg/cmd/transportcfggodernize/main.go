@@ -0,0 +1,12 @@
+// Command transportcfggodernize runs the transportcfg analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/transportcfg"
+)
+
+func main() {
+	singlechecker.Main(transportcfg.Analyzer)
+}
@@ -0,0 +1,47 @@
+package a
+
+import (
+	"net/http"
+	"time"
+)
+
+func bare() *http.Client {
+	return &http.Client{Transport: &http.Transport{}} // want `http.Transport sets neither MaxIdleConns nor IdleConnTimeout`
+}
+
+func value() http.Transport {
+	return http.Transport{ // want `http.Transport sets neither MaxIdleConns nor IdleConnTimeout`
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+func maxIdleConns() *http.Transport {
+	return &http.Transport{
+		MaxIdleConns: 100,
+	}
+}
+
+func idleConnTimeout() *http.Transport {
+	return &http.Transport{
+		IdleConnTimeout: 90 * time.Second,
+	}
+}
+
+func configured() *http.Transport {
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+type transport = http.Transport
+
+func aliased() *transport {
+	return &transport{} // want `http.Transport sets neither MaxIdleConns nor IdleConnTimeout`
+}
+
+func otherLiteral() *http.Client {
+	return &http.Client{Timeout: time.Minute}
+}
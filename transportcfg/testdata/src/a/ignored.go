@@ -0,0 +1,13 @@
+package a
+
+import "net/http"
+
+//godernize:ignore=transportcfg
+func ignoredFunction() *http.Transport {
+	return &http.Transport{}
+}
+
+func ignoredComment() *http.Transport {
+	//godernize:ignore=transportcfg
+	return &http.Transport{}
+}
@@ -0,0 +1,194 @@
+// Package transportcfg provides an opt-in analyzer to detect http.Transport
+// literals without connection reuse settings.
+package transportcfg
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+)
+
+// reuseFields are the http.Transport fields that bound idle connections.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var reuseFields = map[string]bool{
+	"MaxIdleConns":    true,
+	"IdleConnTimeout": true,
+}
+
+// Doc describes what this analyzer does.
+const Doc = `check for http.Transport literals without connection reuse settings
+
+This opinionated analyzer reports http.Transport composite literals that set
+neither MaxIdleConns nor IdleConnTimeout. Their zero values keep idle
+connections without limit and never close them, which can exhaust
+connections or file descriptors under load. http.DefaultTransport uses
+MaxIdleConns: 100 and IdleConnTimeout: 90 * time.Second. The analyzer reports
+diagnostics only and is not part of godernizecheck; run it on its own to opt
+in.`
+
+// Analyzer is the main analyzer for http.Transport literals.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "transportcfg",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/transportcfg",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CompositeLit)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || lit == nil {
+			return
+		}
+
+		file := fileMap.File(lit.Pos())
+
+		if diagnostic := diagnoseCompositeLit(pass, file, lit); diagnostic != nil {
+			pass.Report(*diagnostic)
+		}
+	})
+
+	return nil, nil
+}
+
+func diagnoseCompositeLit(pass *analysis.Pass, file *ast.File, lit *ast.CompositeLit) *analysis.Diagnostic {
+	if file == nil || !isTransport(pass.TypesInfo.TypeOf(lit)) || setsReuseField(lit) {
+		return nil
+	}
+
+	if shouldIgnore(pass.Fset, file, lit, "transportcfg") {
+		return nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos: lit.Pos(),
+		End: lit.End(),
+		Message: "http.Transport sets neither MaxIdleConns nor IdleConnTimeout, so idle connections are never " +
+			"limited or closed; consider MaxIdleConns: 100 and IdleConnTimeout: 90 * time.Second " +
+			"as in http.DefaultTransport",
+	}
+}
+
+// isTransport checks if typ is net/http.Transport, possibly through an alias.
+func isTransport(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == "net/http" && obj.Name() == "Transport"
+}
+
+// setsReuseField checks if the literal sets MaxIdleConns or IdleConnTimeout.
+func setsReuseField(lit *ast.CompositeLit) bool {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		if key, ok := kv.Key.(*ast.Ident); ok && reuseFields[key.Name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,12 @@
+// Command ttempdirgodernize runs the ttempdir analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/ttempdir"
+)
+
+func main() {
+	singlechecker.Main(ttempdir.Analyzer)
+}
@@ -0,0 +1,16 @@
+package a
+
+import (
+	"os"
+	"testing"
+)
+
+// Helpers outside _test.go files are not reported.
+func helper(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "helper")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
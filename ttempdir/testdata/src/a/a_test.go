@@ -0,0 +1,85 @@
+package a
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestMkdirTemp(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test") // want `os.MkdirTemp in a test leaves the temporary directory behind if the test fails before removing it; use t.TempDir\(\), which is removed when the test ends`
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	_ = helper(t)
+}
+
+func TestCreateTemp(t *testing.T) {
+	f, err := os.CreateTemp("", "test") // want `os.CreateTemp in a test leaves the temporary file behind if the test fails before removing it; create it in t.TempDir\(\), which is removed when the test ends`
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+}
+
+func TestIoutil(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test") // want `ioutil.TempDir in a test leaves the temporary directory behind`
+	_ = dir
+
+	f, _ := ioutil.TempFile("", "test") // want `ioutil.TempFile in a test leaves the temporary file behind`
+	_ = f
+}
+
+func BenchmarkMkdirTemp(b *testing.B) {
+	dir, _ := os.MkdirTemp("", "bench") // want `use b.TempDir\(\)`
+	_ = dir
+}
+
+func FuzzMkdirTemp(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		dir, _ := os.MkdirTemp("", s) // want `use t.TempDir\(\)`
+		_ = dir
+	})
+}
+
+func helperTB(tb testing.TB) string {
+	dir, _ := os.MkdirTemp("", "tb") // want `use tb.TempDir\(\)`
+
+	return dir
+}
+
+func TestClosure(t *testing.T) {
+	mk := func() string {
+		dir, _ := os.MkdirTemp("", "closure") // want `use t.TempDir\(\)`
+
+		return dir
+	}
+
+	_ = mk()
+	_ = helperTB(t)
+}
+
+func TestInTempDir(t *testing.T) {
+	// Files and directories in t.TempDir() are removed with it.
+	f, _ := os.CreateTemp(t.TempDir(), "test")
+	_ = f
+
+	dir, _ := os.MkdirTemp(t.TempDir(), "test")
+	_ = dir
+}
+
+// Functions without a testing value in scope are not reported.
+func noTesting() string {
+	dir, _ := os.MkdirTemp("", "none")
+
+	return dir
+}
+
+func TestIgnored(t *testing.T) {
+	//godernize:ignore=ttempdir
+	dir, _ := os.MkdirTemp("", "ignored")
+	_ = dir
+	_ = noTesting()
+}
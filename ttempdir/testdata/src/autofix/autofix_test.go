@@ -0,0 +1,76 @@
+package autofix
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
+
+func TestDiscarded(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "test") // want `ioutil.TempDir in a test`
+	_ = dir
+}
+
+func TestReused(t *testing.T) {
+	var dir string
+
+	dir, err := os.MkdirTemp("", "test") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
+
+func TestAssigned(t *testing.T) {
+	var dir string
+
+	dir, _ = os.MkdirTemp("", "test") // want `os.MkdirTemp in a test`
+	_ = dir
+}
+
+// The error is used after its check, so the call is only reported.
+func TestErrorUsed(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = dir, err
+}
+
+// The error check has an else clause, so the call is only reported.
+func TestElse(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	} else {
+		t.Log(dir)
+	}
+}
+
+// The file case is only reported.
+func TestFile(t *testing.T) {
+	f, _ := ioutil.TempFile("", "test") // want `ioutil.TempFile in a test`
+	_ = f
+}
+
+// t.TempDir() is in the default temporary directory, so calls choosing another
+// one are only reported.
+func TestOtherDir(t *testing.T) {
+	dir, err := os.MkdirTemp("/dev/shm", "test") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = dir
+}
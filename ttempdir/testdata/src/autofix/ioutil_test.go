@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// The fix removes the io/ioutil import with its last use and keeps os.
+func TestLastUse(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test") // want `ioutil.TempDir in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+}
@@ -0,0 +1,18 @@
+package autofix
+
+import (
+	"os"
+	"testing"
+)
+
+// One fix rewrites both calls and removes the os import once.
+func TestBoth(t *testing.T) {
+	first, err := os.MkdirTemp("", "first") // want `os.MkdirTemp in a test`
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, _ := os.MkdirTemp("", "second") // want `os.MkdirTemp in a test`
+
+	t.Log(first, second)
+}
@@ -0,0 +1,523 @@
+// Package ttempdir provides an analyzer to detect temporary files and
+// directories created in tests without t.TempDir.
+package ttempdir
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for temporary directories created in tests without t.TempDir
+
+This analyzer reports os.MkdirTemp, os.CreateTemp, ioutil.TempDir, and
+ioutil.TempFile calls in _test.go files inside functions with a *testing.T,
+*testing.B, *testing.F, or testing.TB in scope. The directory returned by
+t.TempDir is removed when the test ends, so nothing is left behind when the
+test fails before its own cleanup:
+- dir, err := os.MkdirTemp("", "x"); if err != nil { ... } -> dir := t.TempDir()
+
+Directory calls in the default temporary directory "", whose error is
+discarded or only checked by the if statement right after them, are fixed
+automatically, and the os or io/ioutil import is removed when no longer used.
+Temporary files are reported without a fix; create them in t.TempDir()
+instead. Calls that already create their file or directory in t.TempDir() are
+not reported.`
+
+// Analyzer is the main analyzer for temporary directories in tests.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "ttempdir",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/ttempdir",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// tempFunc describes a function creating a temporary file or directory.
+type tempFunc struct {
+	pkgPath string
+	name    string
+	// dir reports whether the function creates a directory.
+	dir bool
+}
+
+//nolint:gochecknoglobals // static table of temporary file constructors
+var tempFuncs = []tempFunc{
+	{pkgPath: "os", name: "CreateTemp"},
+	{pkgPath: "os", name: "MkdirTemp", dir: true},
+	{pkgPath: "io/ioutil", name: "TempFile"},
+	{pkgPath: "io/ioutil", name: "TempDir", dir: true},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the imports.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !push || !ok || call == nil {
+			return true
+		}
+
+		file := fileMap.File(call.Pos())
+		if file == nil || !strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			return true
+		}
+
+		diagnostic, replaced := diagnoseCallExpr(pass, file, call, stack)
+		if diagnostic == nil {
+			return true
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, replaced: replaced})
+
+		return true
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the statements its fix replaces, or
+// nil if it has no fix.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	replaced   []ast.Node
+}
+
+// consolidateFixes adds one adjustment of the imports to the fixes in file:
+// os and io/ioutil are removed when the replaced statements held their last
+// references. With several fixes, only the first carries the rewrites of all
+// of them, so that applying every fix of the file does not apply the same
+// import edits twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+	first := -1
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+
+		if len(f.diagnostic.SuggestedFixes) == 0 {
+			continue
+		}
+
+		if first < 0 {
+			first = i
+		}
+
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.replaced...)
+	}
+
+	if first < 0 {
+		return diagnostics
+	}
+
+	var remove []string
+
+	for _, importPath := range []string{"os", "io/ioutil"} {
+		if importutil.Name(file, importPath) != "" && !importutil.UsedOutside(pass.TypesInfo, file, importPath, replaced...) {
+			remove = append(remove, importPath)
+		}
+	}
+
+	edits = append(edits, importutil.Edits(pass.Fset, file, nil, remove)...)
+
+	diagnostics[first].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[first].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := first + 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// diagnoseCallExpr reports call if it creates a temporary file or directory
+// in a test, together with the statements replaced by its fix. The imports are
+// removed by consolidateFixes.
+func diagnoseCallExpr(
+	pass *analysis.Pass, file *ast.File, call *ast.CallExpr, stack []ast.Node,
+) (*analysis.Diagnostic, []ast.Node) {
+	if file == nil || len(call.Args) == 0 {
+		return nil, nil
+	}
+
+	fn, ok := findTempFunc(pass.TypesInfo, call)
+	if !ok || isTempDirCall(pass.TypesInfo, call.Args[0]) {
+		return nil, nil
+	}
+
+	tb := testingParam(pass.TypesInfo, stack)
+	if tb == nil {
+		return nil, nil
+	}
+
+	if shouldIgnore(pass.Fset, file, call, "ttempdir") {
+		return nil, nil
+	}
+
+	name := pkgName(fn.pkgPath) + "." + fn.name
+
+	if !fn.dir {
+		return &analysis.Diagnostic{
+			Pos: call.Pos(),
+			End: call.End(),
+			Message: fmt.Sprintf("%s in a test leaves the temporary file behind if the test fails before removing it; "+
+				"create it in %s.TempDir(), which is removed when the test ends", name, tb.Name()),
+		}, nil
+	}
+
+	diagnostic := &analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("%s in a test leaves the temporary directory behind if the test fails before removing it; "+
+			"use %s.TempDir(), which is removed when the test ends", name, tb.Name()),
+	}
+
+	// t.TempDir() is always in the default temporary directory, so calls
+	// choosing another one are only reported.
+	if !isEmptyString(pass.TypesInfo, call.Args[0]) {
+		return diagnostic, nil
+	}
+
+	edits, replaced := tempDirEdits(pass.TypesInfo, call, stack, tb)
+	if edits != nil {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Replace with %s.TempDir()", tb.Name()),
+			TextEdits: edits,
+		}}
+	}
+
+	return diagnostic, replaced
+}
+
+// isEmptyString checks if expr is the constant "", which selects the default
+// temporary directory.
+func isEmptyString(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) == ""
+}
+
+func findTempFunc(info *types.Info, call *ast.CallExpr) (tempFunc, bool) {
+	for _, fn := range tempFuncs {
+		if isPkgFunc(info, call, fn.pkgPath, fn.name) {
+			return fn, true
+		}
+	}
+
+	return tempFunc{}, false
+}
+
+func pkgName(pkgPath string) string {
+	if pkgPath == "io/ioutil" {
+		return "ioutil"
+	}
+
+	return pkgPath
+}
+
+// isTempDirCall checks if expr calls the TempDir method of a testing type,
+// whose result is already removed when the test ends.
+func isTempDirCall(info *types.Info, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	fn := typeutil.Callee(info, call)
+
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "testing" && fn.Name() == "TempDir"
+}
+
+// testingParam returns the innermost parameter of type *testing.T,
+// *testing.B, *testing.F, or testing.TB of the functions on the stack.
+func testingParam(info *types.Info, stack []ast.Node) *types.Var {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var funcType *ast.FuncType
+
+		switch node := stack[i].(type) {
+		case *ast.FuncDecl:
+			funcType = node.Type
+		case *ast.FuncLit:
+			funcType = node.Type
+		default:
+			continue
+		}
+
+		for _, field := range funcType.Params.List {
+			for _, name := range field.Names {
+				if param, ok := info.Defs[name].(*types.Var); ok && name.Name != "_" && isTestingType(param.Type()) {
+					return param
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isTestingType checks if typ is *testing.T, *testing.B, *testing.F, or
+// testing.TB.
+func isTestingType(typ types.Type) bool {
+	want := []string{"TB"}
+
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ, want = ptr.Elem(), []string{"T", "B", "F"}
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "testing" {
+		return false
+	}
+
+	for _, name := range want {
+		if named.Obj().Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tempDirEdits returns the edits replacing the assignment of call with
+// tb.TempDir(), and the statements they replace. The error result must either
+// be discarded or be declared by the assignment and only checked by an if
+// statement right after it, which is removed too. It returns nil if the call
+// cannot be replaced.
+func tempDirEdits(
+	info *types.Info, call *ast.CallExpr, stack []ast.Node, tb *types.Var,
+) ([]analysis.TextEdit, []ast.Node) {
+	// The stack starts with the file and the enclosing declaration, and ends
+	// with the enclosing block, the assignment, and the call.
+	if len(stack) < 5 {
+		return nil, nil
+	}
+
+	assign, ok := stack[len(stack)-2].(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 || ast.Unparen(assign.Rhs[0]) != call {
+		return nil, nil
+	}
+
+	dir, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || dir.Name == "_" {
+		return nil, nil
+	}
+
+	errIdent, ok := assign.Lhs[1].(*ast.Ident)
+	if !ok || (errIdent.Name != "_" && info.Defs[errIdent] == nil) {
+		return nil, nil
+	}
+
+	// dir must be new for := to be valid without the error.
+	tok := token.ASSIGN
+	if assign.Tok == token.DEFINE && info.Defs[dir] != nil {
+		tok = token.DEFINE
+	}
+
+	replacement := fmt.Sprintf("%s %s %s.TempDir()", dir.Name, tok, tb.Name())
+
+	if errIdent.Name == "_" {
+		return []analysis.TextEdit{{Pos: assign.Pos(), End: assign.End(), NewText: []byte(replacement)}},
+			[]ast.Node{assign}
+	}
+
+	block, ok := stack[len(stack)-3].(*ast.BlockStmt)
+	if !ok {
+		return nil, nil
+	}
+
+	// The error must not be used outside the removed check.
+	errObj := info.ObjectOf(errIdent)
+
+	check := errCheckAfter(info, block, assign, errObj)
+	if check == nil || countUses(info, stack[1], errObj) != countUses(info, check, errObj) {
+		return nil, nil
+	}
+
+	return []analysis.TextEdit{{Pos: assign.Pos(), End: check.End(), NewText: []byte(replacement)}},
+		[]ast.Node{assign, check}
+}
+
+// errCheckAfter returns the statement following assign in block if it is
+// if err != nil { ... } without init or else clause.
+func errCheckAfter(info *types.Info, block *ast.BlockStmt, assign *ast.AssignStmt, errObj types.Object) *ast.IfStmt {
+	if errObj == nil {
+		return nil
+	}
+
+	for i, stmt := range block.List[:len(block.List)-1] {
+		if stmt != assign {
+			continue
+		}
+
+		ifStmt, ok := block.List[i+1].(*ast.IfStmt)
+		if !ok || ifStmt.Init != nil || ifStmt.Else != nil {
+			return nil
+		}
+
+		cond, ok := ast.Unparen(ifStmt.Cond).(*ast.BinaryExpr)
+		if !ok || cond.Op != token.NEQ || !refersTo(info, cond.X, errObj) || !isNil(info, cond.Y) {
+			return nil
+		}
+
+		return ifStmt
+	}
+
+	return nil
+}
+
+// countUses counts the identifiers in root referring to obj.
+func countUses(info *types.Info, root ast.Node, obj types.Object) int {
+	count := 0
+
+	ast.Inspect(root, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == obj {
+			count++
+		}
+
+		return true
+	})
+
+	return count
+}
+
+func refersTo(info *types.Info, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+
+	return ok && info.Uses[ident] == obj
+}
+
+func isNil(info *types.Info, expr ast.Expr) bool {
+	tv, ok := info.Types[expr]
+
+	return ok && tv.IsNil()
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
@@ -0,0 +1,12 @@
+// Command withtimeoutgodernize runs the withtimeout analyzer.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/jaeyeom/godernize/withtimeout"
+)
+
+func main() {
+	singlechecker.Main(withtimeout.Analyzer)
+}
@@ -0,0 +1,34 @@
+package a
+
+import (
+	"context"
+	"time"
+)
+
+func relative(ctx context.Context, d time.Duration) {
+	_, cancel := context.WithDeadline(ctx, time.Now().Add(d)) // want `context.WithDeadline with time.Now\(\).Add\(d\) is context.WithTimeout with d, use context.WithTimeout instead`
+	defer cancel()
+
+	_, cancel2 := context.WithDeadline(ctx, time.Now().Add(5*time.Second)) // want `context.WithDeadline with time.Now\(\).Add\(d\)`
+	defer cancel2()
+}
+
+func absolute(ctx context.Context, at time.Time, d time.Duration) {
+	_, cancel := context.WithDeadline(ctx, at)
+	defer cancel()
+
+	_, cancel2 := context.WithDeadline(ctx, at.Add(d))
+	defer cancel2()
+
+	_, cancel3 := context.WithDeadline(ctx, time.Now().Truncate(d).Add(d))
+	defer cancel3()
+
+	_, cancel4 := context.WithTimeout(ctx, d)
+	defer cancel4()
+}
+
+func ignored(ctx context.Context, d time.Duration) {
+	//godernize:ignore=withtimeout
+	_, cancel := context.WithDeadline(ctx, time.Now().Add(d))
+	defer cancel()
+}
@@ -0,0 +1,20 @@
+package autofix
+
+import (
+	"context"
+	"time"
+)
+
+func fetch(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(timeout)) // want `context.WithDeadline with time.Now\(\).Add\(d\)`
+	defer cancel()
+
+	return ctx.Err()
+}
+
+func poll(ctx context.Context) error {
+	ctx, cancel := context.WithDeadline(ctx, (time.Now()).Add(2*time.Second)) // want `context.WithDeadline with time.Now\(\).Add\(d\)`
+	defer cancel()
+
+	return ctx.Err()
+}
@@ -0,0 +1,11 @@
+package autofix
+
+import "time"
+
+type config struct {
+	timeout, retry time.Duration
+}
+
+func (c config) Timeout() time.Duration { return c.timeout }
+
+func (c config) Retry() time.Duration { return c.retry }
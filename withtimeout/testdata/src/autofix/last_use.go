@@ -0,0 +1,16 @@
+package autofix
+
+import (
+	"context"
+	"time"
+)
+
+// The removed time.Now() calls are the last uses of time in the file, so the
+// fix of the first call also removes the import.
+func deadline(ctx context.Context, cfg config) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, time.Now().Add(cfg.Timeout())) // want `context.WithDeadline with time.Now\(\).Add\(d\)`
+}
+
+func retry(ctx context.Context, cfg config) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(ctx, time.Now().Add(cfg.Retry())) // want `context.WithDeadline with time.Now\(\).Add\(d\)`
+}
@@ -0,0 +1,286 @@
+// Package withtimeout provides an analyzer to detect context.WithDeadline
+// calls with a deadline relative to now, which context.WithTimeout expresses.
+package withtimeout
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/jaeyeom/godernize/internal/directive"
+	"github.com/jaeyeom/godernize/internal/filemap"
+	"github.com/jaeyeom/godernize/internal/importutil"
+)
+
+const (
+	contextPath = "context"
+	timePath    = "time"
+)
+
+// Doc describes what this analyzer does.
+const Doc = `check for context.WithDeadline(parent, time.Now().Add(d))
+
+This analyzer reports context.WithDeadline calls whose deadline is
+time.Now().Add(d), which is what context.WithTimeout computes:
+- context.WithDeadline(ctx, time.Now().Add(d)) -> context.WithTimeout(ctx, d)
+
+Deadlines computed otherwise, such as absolute times, are not reported. The
+fix renames the call and keeps the duration as written, and removes the time
+import when the removed time.Now() was its last use.`
+
+// Analyzer is the main analyzer for context.WithDeadline relative to now.
+//
+//nolint:gochecknoglobals // analyzer pattern requires global variable
+var Analyzer = &analysis.Analyzer{
+	Name:     "withtimeout",
+	Doc:      Doc,
+	URL:      "https://pkg.go.dev/github.com/jaeyeom/godernize/withtimeout",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+//nolint:nilnil // analyzer pattern
+func run(pass *analysis.Pass) (any, error) {
+	if pass == nil {
+		return nil, nil
+	}
+
+	inspect, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok || inspect == nil {
+		return nil, nil // Don't fail, just skip analysis
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	fileMap := filemap.New(pass.Files)
+
+	// Diagnostics are collected per file so that their fixes can share a
+	// single removal of the time import.
+	var (
+		files []*ast.File
+		found = make(map[*ast.File][]callDiagnostic)
+	)
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call == nil {
+			return
+		}
+
+		file := fileMap.File(call.Pos())
+
+		diagnostic, now := diagnoseCallExpr(pass, file, call)
+		if diagnostic == nil {
+			return
+		}
+
+		if _, seen := found[file]; !seen {
+			files = append(files, file)
+		}
+
+		found[file] = append(found[file], callDiagnostic{diagnostic: *diagnostic, now: now})
+	})
+
+	for _, file := range files {
+		for _, diagnostic := range consolidateFixes(pass, file, found[file]) {
+			pass.Report(diagnostic)
+		}
+	}
+
+	return nil, nil
+}
+
+// callDiagnostic is a diagnostic with the time.Now() call its fix removes.
+type callDiagnostic struct {
+	diagnostic analysis.Diagnostic
+	now        *ast.CallExpr
+}
+
+// consolidateFixes adds one adjustment of the imports to the fixes in file:
+// time is removed when the removed time.Now() calls held its last references.
+// With several fixes, only the first carries the rewrites of all of them, so
+// that applying every fix of the file does not apply the same import edit
+// twice; the others keep their messages.
+func consolidateFixes(pass *analysis.Pass, file *ast.File, found []callDiagnostic) []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, len(found))
+
+	var (
+		edits    []analysis.TextEdit
+		replaced []ast.Node
+	)
+
+	for i, f := range found {
+		diagnostics[i] = f.diagnostic
+		edits = append(edits, f.diagnostic.SuggestedFixes[0].TextEdits...)
+		replaced = append(replaced, f.now)
+	}
+
+	if !importutil.UsedOutside(pass.TypesInfo, file, timePath, replaced...) {
+		edits = append(edits, importutil.Edits(pass.Fset, file, nil, []string{timePath})...)
+	}
+
+	diagnostics[0].SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   diagnostics[0].SuggestedFixes[0].Message,
+		TextEdits: edits,
+	}}
+
+	for i := 1; i < len(diagnostics); i++ {
+		diagnostics[i].SuggestedFixes = nil
+	}
+
+	return diagnostics
+}
+
+// diagnoseCallExpr reports call if its deadline is relative to now, together
+// with the time.Now() call removed by its fix. The import is removed by
+// consolidateFixes.
+func diagnoseCallExpr(pass *analysis.Pass, file *ast.File, call *ast.CallExpr) (*analysis.Diagnostic, *ast.CallExpr) {
+	if file == nil || len(call.Args) != 2 || !isPkgFunc(pass.TypesInfo, call, contextPath, "WithDeadline") {
+		return nil, nil
+	}
+
+	deadline := call.Args[1]
+
+	timeout, now := relativeToNow(pass.TypesInfo, deadline)
+	name := funcName(call.Fun)
+
+	if timeout == nil || name == nil || shouldIgnore(pass.Fset, file, call, "withtimeout") {
+		return nil, nil
+	}
+
+	return &analysis.Diagnostic{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		Message: "context.WithDeadline with time.Now().Add(d) is context.WithTimeout with d, use context.WithTimeout instead",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with context.WithTimeout",
+			TextEdits: []analysis.TextEdit{
+				{Pos: name.Pos(), End: name.End(), NewText: []byte("WithTimeout")},
+				{Pos: deadline.Pos(), End: timeout.Pos()},
+				{Pos: timeout.End(), End: deadline.End()},
+			},
+		}},
+	}, now
+}
+
+// relativeToNow returns d and the time.Now() call if deadline is
+// time.Now().Add(d), and nil otherwise.
+func relativeToNow(info *types.Info, deadline ast.Expr) (ast.Expr, *ast.CallExpr) {
+	add, ok := ast.Unparen(deadline).(*ast.CallExpr)
+	if !ok || len(add.Args) != 1 {
+		return nil, nil
+	}
+
+	sel, ok := ast.Unparen(add.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+
+	method, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || method.FullName() != "(time.Time).Add" {
+		return nil, nil
+	}
+
+	now, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+	if !ok || !isPkgFunc(info, now, timePath, "Now") {
+		return nil, nil
+	}
+
+	return add.Args[0], now
+}
+
+// funcName returns the identifier naming the called function, as in
+// context.WithDeadline or, with a dot import, WithDeadline.
+func funcName(fun ast.Expr) *ast.Ident {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	}
+
+	return nil
+}
+
+func isPkgFunc(info *types.Info, call *ast.CallExpr, pkgPath, name string) bool {
+	fn := typeutil.StaticCallee(info, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath || fn.Name() != name {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Recv() == nil
+}
+
+func shouldIgnore(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	if file == nil {
+		return false
+	}
+
+	return shouldIgnoreInFile(file, analyzerName) ||
+		shouldIgnoreInRange(file, node, analyzerName) ||
+		shouldIgnoreInFunction(file, node, analyzerName) ||
+		shouldIgnoreFromComment(fset, file, node, analyzerName)
+}
+
+// shouldIgnoreInFile checks the file-level directive, which applies to the
+// whole file.
+func shouldIgnoreInFile(file *ast.File, analyzerName string) bool {
+	ignore := directive.FileIgnore(file)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}
+
+// shouldIgnoreInRange checks the blocks between ignore-begin and ignore-end
+// directives.
+func shouldIgnoreInRange(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, r := range directive.IgnoreRanges(file) {
+		if r.Contains(node.Pos()) && r.ShouldIgnore(analyzerName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreInFunction(file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		if node.Pos() >= funcDecl.Pos() && node.End() <= funcDecl.End() {
+			ignore := directive.FuncIgnore(file, funcDecl)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func shouldIgnoreFromComment(fset *token.FileSet, file *ast.File, node ast.Node, analyzerName string) bool {
+	for _, cg := range file.Comments {
+		// Check if comment appears before the node and is reasonably close
+		if cg.End() <= node.Pos() && node.Pos()-cg.End() <= 200 {
+			ignore := directive.ParseGodernize(cg)
+			if ignore != nil && ignore.ShouldIgnore(analyzerName) {
+				return true
+			}
+		}
+	}
+
+	ignore := directive.NolintIgnore(fset, file, node)
+
+	return ignore != nil && ignore.ShouldIgnore(analyzerName)
+}